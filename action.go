@@ -0,0 +1,95 @@
+package kong
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// invalidAction is an action of the sprint editor which is not available for
+// the issue it was applied to.
+type invalidAction struct {
+	key        string
+	action     string
+	suggestion string
+}
+
+// InvalidActionsError reports all actions of the sprint editor which are not
+// available for their issue. The actions are validated before any issue is
+// changed so that a typo does not leave the sprint half updated.
+type InvalidActionsError []invalidAction
+
+func (e InvalidActionsError) Error() string {
+	messages := make([]string, len(e))
+	for i, action := range e {
+		messages[i] = fmt.Sprintf("%s: %v: %s", action.key, errUnknownTransition, action.action)
+		if action.suggestion != "" {
+			messages[i] += fmt.Sprintf(", did you mean %s?", action.suggestion)
+		}
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Is reports the error as unknown transition.
+func (e InvalidActionsError) Is(target error) bool {
+	return target == errUnknownTransition
+}
+
+// newInvalidAction returns the invalid action of the issue along with the
+// closest action available for the issue.
+func newInvalidAction(issue Issue, action string) invalidAction {
+	actions := []string{backlogAcronym}
+	if issue.Status.Acronym != "" {
+		actions = append(actions, issue.Status.Acronym)
+	}
+	for acronym := range issue.TransitionsByAcronym {
+		actions = append(actions, acronym)
+	}
+	return invalidAction{
+		key:        issue.Key,
+		action:     action,
+		suggestion: closestAction(action, actions),
+	}
+}
+
+// closestAction returns the action with the smallest edit distance to the
+// given action, preferring the alphabetically first on ties.
+func closestAction(action string, actions []string) string {
+	sort.Strings(actions)
+	closest, best := "", -1
+	for _, candidate := range actions {
+		if d := editDistance(action, candidate); best < 0 || d < best {
+			closest, best = candidate, d
+		}
+	}
+	return closest
+}
+
+// editDistance returns the Levenshtein distance of the strings.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	prev := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		curr := make([]int, len(t)+1)
+		curr[0] = i
+		for j := 1; j <= len(t); j++ {
+			// deletion, insertion or substitution
+			curr[j] = prev[j] + 1
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			if prev[j-1]+cost < curr[j] {
+				curr[j] = prev[j-1] + cost
+			}
+		}
+		prev = curr
+	}
+	return prev[len(t)]
+}
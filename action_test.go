@@ -0,0 +1,47 @@
+package kong
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "ip", b: "ip", want: 0},
+		{a: "pi", b: "ip", want: 2},
+		{a: "inp", b: "ip", want: 1},
+		{a: "", b: "done", want: 4},
+		{a: "kitten", b: "sitting", want: 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) got %v, want: %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestInvalidActionsError(t *testing.T) {
+	issue := Issue{
+		Key:    "KONG-1",
+		Status: Status{Acronym: "td"},
+		TransitionsByAcronym: map[string]Transition{
+			"ip": {Name: "In Progress", Acronym: "ip"},
+			"d":  {Name: "Done", Acronym: "d"},
+		},
+	}
+	err := InvalidActionsError{
+		newInvalidAction(issue, "ipp"),
+		newInvalidAction(Issue{Key: "KONG-2"}, "ic"),
+	}
+	want := "KONG-1: transition does not exist: ipp, did you mean ip?\n" +
+		"KONG-2: transition does not exist: ic, did you mean ice?"
+	if got := err.Error(); got != want {
+		t.Errorf("got %q, want: %q", got, want)
+	}
+	if !errors.Is(err, errUnknownTransition) {
+		t.Errorf("got %v, want: %v", err, errUnknownTransition)
+	}
+}
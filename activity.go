@@ -0,0 +1,110 @@
+package kong
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// maxActivity is the number of events retained in the activity ring buffer.
+const maxActivity = 500
+
+// Event types recorded in the activity stream.
+const (
+	EventCreated      = "created"
+	EventTransitioned = "transitioned"
+	EventCommented    = "commented"
+)
+
+// Activity is a chronological feed of events on issues computed by diffing
+// consecutive syncs of the daemon.
+type Activity []Event
+
+// Event is a single change observed on an issue.
+type Event struct {
+	Time    time.Time
+	Key     string
+	Summary string
+	Type    string
+	Detail  string
+}
+
+// diffIssues returns the events which explain the difference between the
+// previously synced issues and the current issues. Issues which were not
+// synced before are only reported as created if they were created since the
+// last sync, otherwise they just started to match the query, for instance
+// after being assigned.
+func diffIssues(prev map[string]Issue, issues Issues, since, now time.Time) []Event {
+	var events []Event
+	seen := make(map[string]struct{}, len(issues))
+	for _, issue := range issues {
+		if _, ok := seen[issue.Key]; ok {
+			continue
+		}
+		seen[issue.Key] = struct{}{}
+
+		event := Event{
+			Time:    now,
+			Key:     issue.Key,
+			Summary: issue.Summary,
+		}
+		old, ok := prev[issue.Key]
+		if !ok {
+			if !issue.Created.Before(since) {
+				event.Type = EventCreated
+				events = append(events, event)
+			}
+			continue
+		}
+		if old.Status.Name != issue.Status.Name {
+			event.Type = EventTransitioned
+			event.Detail = old.Status.Name + " → " + issue.Status.Name
+			events = append(events, event)
+		}
+		if issue.Comments > old.Comments {
+			event.Type = EventCommented
+			event.Detail = fmt.Sprintf("%d new", issue.Comments-old.Comments)
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// add appends the events and drops the oldest events exceeding the capacity
+// of the ring buffer.
+func (a Activity) add(events ...Event) Activity {
+	a = append(a, events...)
+	if len(a) > maxActivity {
+		a = append(Activity(nil), a[len(a)-maxActivity:]...)
+	}
+	return a
+}
+
+// Print writes the events in chronological order, optionally only those of
+// the given project.
+func (a Activity) Print(output io.Writer, project string) {
+	events := make(Activity, 0, len(a))
+	for _, event := range a {
+		if project != "" && !strings.HasPrefix(event.Key, project+"-") {
+			continue
+		}
+		events = append(events, event)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+
+	w := tabwriter.NewWriter(output, 1, 1, 1, ' ', 0)
+	for _, event := range events {
+		timestamp := event.Time.Local().Format("2006/1/2 15:04")
+		fmt.Fprintf(w, "%s\t-\t%s\t-\t%s\t-\t%s", timestamp, event.Key, event.Type, event.Summary)
+		if event.Detail != "" {
+			fmt.Fprintf(w, " (%s)", Plain(event.Detail))
+		}
+		fmt.Fprint(w, "\n")
+	}
+	w.Flush()
+}
@@ -0,0 +1,49 @@
+package kong
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffIssues(t *testing.T) {
+	now := time.Date(2023, time.July, 3, 0, 0, 0, 0, time.UTC)
+	since := now.Add(-time.Minute)
+	prev := map[string]Issue{
+		"KONG-1": {Key: "KONG-1", Summary: "Unchanged", Status: Status{Name: "To Do"}},
+		"KONG-2": {Key: "KONG-2", Summary: "Transitioned", Status: Status{Name: "To Do"}},
+		"KONG-3": {Key: "KONG-3", Summary: "Commented", Status: Status{Name: "To Do"}, Comments: 1},
+	}
+	issues := Issues{
+		{Key: "KONG-1", Summary: "Unchanged", Status: Status{Name: "To Do"}},
+		{Key: "KONG-2", Summary: "Transitioned", Status: Status{Name: "In Progress"}},
+		{Key: "KONG-3", Summary: "Commented", Status: Status{Name: "To Do"}, Comments: 3},
+		{Key: "KONG-4", Summary: "Created", Status: Status{Name: "To Do"}, Created: since.Add(time.Second)},
+		{Key: "KONG-4", Summary: "Created", Status: Status{Name: "To Do"}, Created: since.Add(time.Second)},
+		{Key: "KONG-5", Summary: "Assigned", Status: Status{Name: "To Do"}, Created: since.Add(-time.Hour)},
+	}
+
+	got := diffIssues(prev, issues, since, now)
+	want := []Event{
+		{Time: now, Key: "KONG-2", Summary: "Transitioned", Type: EventTransitioned, Detail: "To Do → In Progress"},
+		{Time: now, Key: "KONG-3", Summary: "Commented", Type: EventCommented, Detail: "2 new"},
+		{Time: now, Key: "KONG-4", Summary: "Created", Type: EventCreated},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestActivityAdd(t *testing.T) {
+	var activity Activity
+	for i := 0; i < maxActivity+10; i++ {
+		activity = activity.add(Event{Time: time.Unix(int64(i), 0)})
+	}
+	if got := len(activity); got != maxActivity {
+		t.Fatalf("got %d, want: %d", got, maxActivity)
+	}
+	if got := activity[0].Time.Unix(); got != 10 {
+		t.Errorf("got %d, want: %d", got, 10)
+	}
+}
@@ -0,0 +1,85 @@
+package kong
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	errCommandAliasInvalid = errors.New("command alias must be a single word")
+	errCommandAliasEmpty   = errors.New("command alias requires a command line")
+	errCommandAliasQuote   = errors.New("command alias has an unterminated quote")
+)
+
+// ExpandAlias replaces the alias in the first argument with the arguments of
+// its command line, followed by the remaining arguments. Like git aliases,
+// aliases are not expanded recursively. The arguments are returned unchanged
+// if the first argument is not an alias.
+func (c Config) ExpandAlias(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return args, nil
+	}
+	line, ok := c.Aliases[args[0]]
+	if !ok {
+		return args, nil
+	}
+	expanded, err := splitCommandLine(line)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, args[0])
+	}
+	return append(expanded, args[1:]...), nil
+}
+
+func validateCommandAlias(name, line string) error {
+	if name == "" || strings.ContainsAny(name, " \t") || strings.HasPrefix(name, "-") {
+		return fmt.Errorf("%w: %q", errCommandAliasInvalid, name)
+	}
+	args, err := splitCommandLine(line)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, name)
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("%w: %s", errCommandAliasEmpty, name)
+	}
+	return nil
+}
+
+// splitCommandLine splits the command line into arguments separated by
+// whitespace. Single and double quotes group arguments containing whitespace,
+// for instance a filter expression.
+func splitCommandLine(line string) ([]string, error) {
+	var (
+		args  []string
+		arg   strings.Builder
+		quote rune
+		inArg bool
+	)
+	for _, r := range line {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			arg.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, errCommandAliasQuote
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
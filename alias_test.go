@@ -0,0 +1,99 @@
+package kong
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExpandAlias(t *testing.T) {
+	config := Config{
+		Aliases: map[string]string{
+			"wip":   "issue move --current ip",
+			"big":   `issues --filter 'points > 3 && status == "In Progress"'`,
+			"quote": `issues --filter "points > 3`,
+		},
+	}
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "no arguments",
+		},
+		{
+			name: "no alias",
+			args: []string{"sprint", "--by-epic"},
+			want: []string{"sprint", "--by-epic"},
+		},
+		{
+			name: "alias",
+			args: []string{"wip", "KONG-1"},
+			want: []string{"issue", "move", "--current", "ip", "KONG-1"},
+		},
+		{
+			name: "quoted",
+			args: []string{"big"},
+			want: []string{"issues", "--filter", `points > 3 && status == "In Progress"`},
+		},
+		{
+			name:    "unterminated quote",
+			args:    []string{"quote"},
+			wantErr: errCommandAliasQuote,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := config.ExpandAlias(tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want: %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateCommandAlias(t *testing.T) {
+	tests := []struct {
+		name  string
+		alias string
+		line  string
+		want  error
+	}{
+		{
+			name:  "valid",
+			alias: "wip",
+			line:  "issue move --current ip",
+		},
+		{
+			name:  "whitespace",
+			alias: "w ip",
+			line:  "issues",
+			want:  errCommandAliasInvalid,
+		},
+		{
+			name:  "flag",
+			alias: "--wip",
+			line:  "issues",
+			want:  errCommandAliasInvalid,
+		},
+		{
+			name:  "empty",
+			alias: "wip",
+			line:  " ",
+			want:  errCommandAliasEmpty,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateCommandAlias(tt.alias, tt.line); !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want: %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package kong
+
+import (
+	"strings"
+	"unicode"
+)
+
+// asciiOutput replaces symbols in the output with ASCII for terminals and
+// fonts which render them badly.
+var asciiOutput bool
+
+// asciiReplacer maps the symbols Kong prints to their closest ASCII form.
+var asciiReplacer = strings.NewReplacer(
+	" 🦍", "",
+	"🦍 ", "",
+	"→", "->",
+	"—", "-",
+	"…", "...",
+	"·", ".",
+	"█", "#",
+	"┤", "|",
+	"│", "|",
+	"├", "+",
+	"└", "+",
+	"─", "-",
+)
+
+// SetASCII enables plain ASCII output for the remainder of the process.
+func SetASCII(enabled bool) {
+	asciiOutput = enabled
+}
+
+// Plain returns s with symbols replaced by ASCII if plain ASCII output is
+// enabled. Remaining symbols like emoji are removed while letters of Jira
+// content, for instance in names, are kept.
+func Plain(s string) string {
+	if !asciiOutput {
+		return s
+	}
+	s = asciiReplacer.Replace(s)
+	return strings.Map(func(r rune) rune {
+		if r > unicode.MaxASCII && (unicode.Is(unicode.So, r) || unicode.Is(unicode.Sk, r) || r == '\ufe0f' || r == '\u200d') {
+			return -1
+		}
+		return r
+	}, s)
+}
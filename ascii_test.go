@@ -0,0 +1,27 @@
+package kong
+
+import (
+	"testing"
+)
+
+func TestPlain(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{s: "3 done today, 5-day streak 🦍\n", want: "3 done today, 5-day streak\n"},
+		{s: "To Do → In Progress", want: "To Do -> In Progress"},
+		{s: "12 issues — 34 pts", want: "12 issues - 34 pts"},
+		{s: "└── blocks KONG-2", want: "+-- blocks KONG-2"},
+		{s: "Ship it 🚀️ Jürgen", want: "Ship it  Jürgen"},
+	}
+	SetASCII(true)
+	defer SetASCII(false)
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := Plain(tt.s); got != tt.want {
+				t.Errorf("got %q, want: %q", got, tt.want)
+			}
+		})
+	}
+}
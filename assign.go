@@ -0,0 +1,148 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+const (
+	// assignSelf and assignNone are the shortcuts to assign an issue to the
+	// current user or to nobody.
+	assignSelf = "me"
+	assignNone = "none"
+
+	// assignAcronym is the sprint editor action to assign an issue to the
+	// user given in place of the summary. It is not a letter so that it
+	// cannot collide with status acronyms.
+	assignAcronym = "@"
+)
+
+var (
+	errUnknownUser   = errors.New("user does not exist")
+	errAmbiguousUser = errors.New("user is ambiguous")
+	errUserMissing   = errors.New("user missing")
+)
+
+// isServer reports whether the configuration points to Jira Server or Data
+// Center rather than Jira Cloud. Personal access tokens only exist on the
+// former, which identifies users by name instead of account ID.
+func (c Config) isServer() bool {
+	return c.AuthType == AuthPAT
+}
+
+// FindUser returns the user matching the query by name, email address or
+// display name. A user whose name or email address matches exactly is
+// preferred over users whose display name only contains the query.
+func (j Jira) FindUser(ctx context.Context, query string) (jira.User, error) {
+	param := "query"
+	if j.config.isServer() {
+		param = "username"
+	}
+	endpoint := fmt.Sprintf("/rest/api/2/user/search?%s=%s", param, url.QueryEscape(query))
+	req, err := j.client.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return jira.User{}, err
+	}
+	var users []jira.User
+	resp, err := j.client.Do(req, &users)
+	if err != nil {
+		return jira.User{}, fmt.Errorf("FindUser: %w", parseResponseError(resp))
+	}
+	return matchUser(query, users)
+}
+
+// matchUser picks the user of the search results for the query.
+func matchUser(query string, users []jira.User) (jira.User, error) {
+	var exact []jira.User
+	for _, user := range users {
+		for _, field := range []string{user.Name, user.EmailAddress, user.DisplayName} {
+			if strings.EqualFold(field, query) {
+				exact = append(exact, user)
+				break
+			}
+		}
+	}
+	if len(exact) == 1 {
+		return exact[0], nil
+	}
+	if len(exact) == 0 && len(users) == 1 {
+		return users[0], nil
+	}
+	if len(exact) == 0 && len(users) == 0 {
+		return jira.User{}, fmt.Errorf("%w: %s", errUnknownUser, query)
+	}
+	if len(exact) > 0 {
+		users = exact
+	}
+	names := make([]string, len(users))
+	for i, user := range users {
+		names[i] = user.DisplayName
+		if user.EmailAddress != "" {
+			names[i] += " <" + user.EmailAddress + ">"
+		}
+	}
+	return jira.User{}, fmt.Errorf("%w: %s matches %s", errAmbiguousUser, query, strings.Join(names, ", "))
+}
+
+// resolveAssignee returns the user for the name or the shortcuts me and none,
+// which returns nil to unassign the issue.
+func (j Jira) resolveAssignee(ctx context.Context, name string) (*jira.User, error) {
+	switch name {
+	case "":
+		return nil, errUserMissing
+	case assignSelf:
+		return j.user, nil
+	case assignNone:
+		return nil, nil
+	}
+	user, err := j.FindUser(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// AssignIssueTo assigns the issue to the user given by name, me or none.
+func (j Jira) AssignIssueTo(ctx context.Context, key, name string) error {
+	user, err := j.resolveAssignee(ctx, name)
+	if err != nil {
+		return err
+	}
+	return j.assign(ctx, key, user)
+}
+
+// assign sets the assignee of the issue or removes it if user is nil.
+func (j Jira) assign(ctx context.Context, key string, user *jira.User) error {
+	if user == nil {
+		return j.unassign(ctx, key)
+	}
+	resp, err := j.client.Issue.UpdateAssigneeWithContext(ctx, key, user)
+	if err != nil {
+		return fmt.Errorf("AssignIssue: %w", parseResponseError(resp))
+	}
+	fmt.Printf("%s - Assigned to %s\n", key, user.DisplayName)
+	return nil
+}
+
+func (j Jira) unassign(ctx context.Context, key string) error {
+	// the assignee is removed by setting the user identifier to null
+	body := map[string]interface{}{"accountId": nil}
+	if j.config.isServer() {
+		body = map[string]interface{}{"name": nil}
+	}
+	req, err := j.client.NewRequestWithContext(ctx, "PUT", "/rest/api/2/issue/"+key+"/assignee", body)
+	if err != nil {
+		return err
+	}
+	resp, err := j.client.Do(req, nil)
+	if err != nil {
+		return fmt.Errorf("AssignIssue: %w", parseResponseError(resp))
+	}
+	fmt.Printf("%s - Unassigned\n", key)
+	return nil
+}
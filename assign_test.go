@@ -0,0 +1,99 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMatchUser(t *testing.T) {
+	alice := jira.User{Name: "alice", EmailAddress: "alice@example.com", DisplayName: "Alice Smith"}
+	alicia := jira.User{Name: "alicia", EmailAddress: "alicia@example.com", DisplayName: "Alicia Smith"}
+	tests := []struct {
+		name    string
+		query   string
+		users   []jira.User
+		want    jira.User
+		wantErr error
+	}{
+		{
+			name:  "exact name",
+			query: "alice",
+			users: []jira.User{alice, alicia},
+			want:  alice,
+		},
+		{
+			name:  "exact email address",
+			query: "ALICIA@example.com",
+			users: []jira.User{alice, alicia},
+			want:  alicia,
+		},
+		{
+			name:  "single result",
+			query: "ali",
+			users: []jira.User{alice},
+			want:  alice,
+		},
+		{
+			name:    "ambiguous",
+			query:   "smith",
+			users:   []jira.User{alice, alicia},
+			wantErr: errAmbiguousUser,
+		},
+		{
+			name:    "unknown",
+			query:   "bob",
+			wantErr: errUnknownUser,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matchUser(tt.query, tt.users)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want: %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestFindUser(t *testing.T) {
+	tests := []struct {
+		authType string
+		want     string
+	}{
+		{authType: AuthToken, want: "query=alice%40example.com"},
+		{authType: AuthPAT, want: "username=alice%40example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.authType, func(t *testing.T) {
+			var got string
+			client := newTestJira(t, func(req *http.Request) (*http.Response, error) {
+				got = req.URL.RawQuery
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`[{"name": "alice", "displayName": "Alice"}]`)),
+				}, nil
+			})
+			j := Jira{client: client, config: Config{AuthType: tt.authType}}
+			user, err := j.FindUser(context.Background(), "alice@example.com")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want: %v", got, tt.want)
+			}
+			if user.Name != "alice" {
+				t.Errorf("got %v, want: %v", user.Name, "alice")
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package kong
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+)
+
+// maxAuditPayload is the number of bytes of a request payload kept in the
+// audit log.
+const maxAuditPayload = 200
+
+// Audit is the log of all mutations Kong performed against the Jira API.
+type Audit []AuditEntry
+
+// AuditEntry is a single request which changed or attempted to change Jira.
+type AuditEntry struct {
+	Time    time.Time `json:"time"`
+	Method  string    `json:"method"`
+	Path    string    `json:"path"`
+	Payload string    `json:"payload,omitempty"`
+	Status  int       `json:"status,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// auditPath returns the path of the append-only audit log next to the data
+// file.
+func auditPath() string {
+	return cachePath() + ".audit"
+}
+
+// auditTransport appends every request which is not a read to the audit log.
+type auditTransport struct {
+	transport http.RoundTripper
+}
+
+func (t auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return transport.RoundTrip(req)
+	}
+
+	entry := AuditEntry{
+		Time:    time.Now(),
+		Method:  req.Method,
+		Path:    req.URL.Path,
+		Payload: requestPayload(req),
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Status = resp.StatusCode
+	}
+	if err := appendAudit(entry); err != nil {
+		fmt.Fprintln(os.Stderr, "audit:", err)
+	}
+	return resp, err
+}
+
+// requestPayload returns the compacted and truncated request body without
+// consuming it.
+func requestPayload(req *http.Request) string {
+	if req.GetBody == nil {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+	return summarizePayload(b)
+}
+
+func summarizePayload(b []byte) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, b); err == nil {
+		b = buf.Bytes()
+	}
+	runes := []rune(string(bytes.TrimSpace(b)))
+	if len(runes) > maxAuditPayload {
+		return string(runes[:maxAuditPayload]) + Plain("…")
+	}
+	return string(runes)
+}
+
+func appendAudit(entry AuditEntry) error {
+	return appendJSON(auditPath(), entry)
+}
+
+// appendJSON appends the value as a single line of JSON to the file at path.
+func appendJSON(path string, v any) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(f).Encode(v); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ReadAudit returns the entries of the audit log recorded since the given
+// time. A missing audit log has no entries.
+func ReadAudit(since time.Time) (Audit, error) {
+	f, err := os.Open(auditPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var audit Audit
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("ReadAudit: %w", err)
+		}
+		if entry.Time.Before(since) {
+			continue
+		}
+		audit = append(audit, entry)
+	}
+	return audit, scanner.Err()
+}
+
+// Print writes the entries in the order they were recorded.
+func (a Audit) Print(output io.Writer) {
+	w := tabwriter.NewWriter(output, 1, 1, 1, ' ', 0)
+	for _, entry := range a {
+		result := fmt.Sprint(entry.Status)
+		if entry.Error != "" {
+			result = entry.Error
+		}
+		timestamp := entry.Time.Local().Format("2006/1/2 15:04:05")
+		fmt.Fprintf(w, "%s\t-\t%s %s\t-\t%s\t-\t%s\n", timestamp, entry.Method, entry.Path, result, entry.Payload)
+	}
+	w.Flush()
+}
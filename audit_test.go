@@ -0,0 +1,83 @@
+package kong
+
+import (
+	"bytes"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/google/go-cmp/cmp"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newTestJira returns a Jira API client which answers every request with the
+// round trip function instead of contacting Jira.
+func newTestJira(t *testing.T, f roundTripFunc) *jira.Client {
+	t.Helper()
+	client, err := jira.NewClient(&http.Client{Transport: f}, "https://jira.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestAuditTransport(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+
+	transport := auditTransport{
+		transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusNoContent}, nil
+		}),
+	}
+	requests := []struct {
+		method string
+		body   string
+	}{
+		{method: http.MethodGet},
+		{method: http.MethodPut, body: `{"fields": {"priority": {"name": "High"}}}`},
+	}
+	for _, r := range requests {
+		req, err := http.NewRequest(r.method, "https://jira.example.com/rest/api/2/issue/KONG-1", strings.NewReader(r.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := transport.RoundTrip(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	audit, err := ReadAudit(time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(audit) != 1 {
+		t.Fatalf("got %v, want: %v", len(audit), 1)
+	}
+	audit[0].Time = time.Time{}
+	want := AuditEntry{
+		Method:  http.MethodPut,
+		Path:    "/rest/api/2/issue/KONG-1",
+		Payload: `{"fields":{"priority":{"name":"High"}}}`,
+		Status:  http.StatusNoContent,
+	}
+	if diff := cmp.Diff(audit[0], want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestSummarizePayload(t *testing.T) {
+	long := bytes.Repeat([]byte("a"), maxAuditPayload+1)
+	got := summarizePayload(long)
+	want := strings.Repeat("a", maxAuditPayload) + "…"
+	if got != want {
+		t.Errorf("got %v, want: %v", got, want)
+	}
+}
@@ -0,0 +1,170 @@
+package kong
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// maxSnapshots is the number of daily snapshots retained.
+	maxSnapshots = 400
+	// burndownHeight is the number of rows of the burndown chart.
+	burndownHeight = 8
+	// snapshotDateLayout formats the day of a snapshot.
+	snapshotDateLayout = "2006-01-02"
+)
+
+// Snapshot records the story points of the active sprint on a given day in
+// the board timezone. The daemon overwrites the snapshot of the current day on
+// every sync so that the last sync of a day wins.
+type Snapshot struct {
+	Date      string
+	SprintID  int
+	Remaining float64
+	Total     float64
+}
+
+// recordSnapshot updates the snapshot of the active sprint for the day of now.
+func (d *Data) recordSnapshot(now time.Time) {
+	sprint, err := d.Sprints.ActiveSprint()
+	if err != nil {
+		return
+	}
+	snapshot := Snapshot{
+		Date:     now.Format(snapshotDateLayout),
+		SprintID: sprint.ID,
+	}
+	for _, issue := range d.SprintIssues {
+		snapshot.Total += issue.StoryPoints
+		if !issue.Status.IsDone {
+			snapshot.Remaining += issue.StoryPoints
+		}
+	}
+	for i, s := range d.Snapshots {
+		if s.Date == snapshot.Date && s.SprintID == snapshot.SprintID {
+			d.Snapshots[i] = snapshot
+			return
+		}
+	}
+	d.Snapshots = append(d.Snapshots, snapshot)
+	if len(d.Snapshots) > maxSnapshots {
+		d.Snapshots = append([]Snapshot(nil), d.Snapshots[len(d.Snapshots)-maxSnapshots:]...)
+	}
+}
+
+// SprintSnapshots returns the snapshots of the sprint ordered by date.
+func (d Data) SprintSnapshots(sprint Sprint) []Snapshot {
+	var snapshots []Snapshot
+	for _, s := range d.Snapshots {
+		if s.SprintID == sprint.ID {
+			snapshots = append(snapshots, s)
+		}
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Date < snapshots[j].Date
+	})
+	return snapshots
+}
+
+// PrintBurndown renders the remaining points per day of the sprint as bars
+// next to the ideal burndown drawn as dots. Days are taken from the start to
+// the end of the sprint in the given location and extended by the snapshots.
+func PrintBurndown(output io.Writer, sprint Sprint, snapshots []Snapshot, loc *time.Location) {
+	if len(snapshots) == 0 {
+		fmt.Fprintf(output, "%s has no snapshots yet, they are recorded daily by the daemon\n", sprint.Name)
+		return
+	}
+	remaining := make(map[string]float64, len(snapshots))
+	var total float64
+	for _, s := range snapshots {
+		remaining[s.Date] = s.Remaining
+		total = math.Max(total, s.Total)
+	}
+
+	first, _ := time.ParseInLocation(snapshotDateLayout, snapshots[0].Date, loc)
+	last, _ := time.ParseInLocation(snapshotDateLayout, snapshots[len(snapshots)-1].Date, loc)
+	if !sprint.StartDate.IsZero() {
+		first = minTime(first, startOfDay(sprint.StartDate.In(loc)))
+	}
+	if !sprint.EndDate.IsZero() {
+		last = maxTime(last, startOfDay(sprint.EndDate.In(loc)))
+	}
+	var days []string
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		days = append(days, day.Format(snapshotDateLayout))
+	}
+
+	current := snapshots[len(snapshots)-1]
+	fmt.Fprintf(output, "%s (%s of %s pts remaining)\n", sprint.Name, formatValue(current.Remaining), formatValue(current.Total))
+	if total == 0 {
+		total = 1
+	}
+
+	label := formatValue(total)
+	for row := burndownHeight; row > 0; row-- {
+		prefix := strings.Repeat(" ", len(label))
+		if row == burndownHeight {
+			prefix = label
+		}
+		var b strings.Builder
+		for i, day := range days {
+			ideal := total
+			if len(days) > 1 {
+				ideal = total * float64(len(days)-1-i) / float64(len(days)-1)
+			}
+			value, ok := remaining[day]
+			switch {
+			case ok && rowOf(value, total) >= row:
+				b.WriteString("█ ")
+			case rowOf(ideal, total) == row:
+				b.WriteString("· ")
+			default:
+				b.WriteString("  ")
+			}
+		}
+		fmt.Fprint(output, Plain(fmt.Sprintf("%s ┤%s\n", prefix, strings.TrimRight(b.String(), " "))))
+	}
+	fmt.Fprint(output, Plain(fmt.Sprintf("%*s └%s\n", len(label), "0", strings.Repeat("──", len(days)))))
+
+	start, end := formatDay(days[0]), formatDay(days[len(days)-1])
+	gap := 2*len(days) - len(start) - len(end)
+	if gap < 1 {
+		gap = 1
+	}
+	fmt.Fprintf(output, "%s  %s%s%s\n", strings.Repeat(" ", len(label)), start, strings.Repeat(" ", gap), end)
+}
+
+// rowOf returns the chart row from 0 to burndownHeight the value reaches.
+func rowOf(value, total float64) int {
+	return int(math.Round(value / total * burndownHeight))
+}
+
+func formatDay(date string) string {
+	day, err := time.Parse(snapshotDateLayout, date)
+	if err != nil {
+		return date
+	}
+	return day.Format("1/2")
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
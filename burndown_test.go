@@ -0,0 +1,60 @@
+package kong
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRecordSnapshot(t *testing.T) {
+	data := NewData()
+	data.Sprints = Sprints{{ID: 1, Name: "Komodo", State: "active"}}
+	data.SprintIssues = Issues{
+		{Key: "KONG-1", StoryPoints: 3},
+		{Key: "KONG-2", StoryPoints: 5, Status: Status{IsDone: true}},
+	}
+	now := time.Date(2022, 6, 1, 9, 0, 0, 0, time.UTC)
+	data.recordSnapshot(now)
+
+	// a later sync on the same day replaces the snapshot
+	data.SprintIssues[0].Status.IsDone = true
+	data.recordSnapshot(now.Add(8 * time.Hour))
+
+	want := []Snapshot{{Date: "2022-06-01", SprintID: 1, Remaining: 0, Total: 8}}
+	if diff := cmp.Diff(data.Snapshots, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestPrintBurndown(t *testing.T) {
+	sprint := Sprint{
+		ID:        1,
+		Name:      "Komodo",
+		StartDate: time.Date(2022, 6, 1, 9, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2022, 6, 5, 17, 0, 0, 0, time.UTC),
+	}
+	snapshots := []Snapshot{
+		{Date: "2022-06-01", SprintID: 1, Remaining: 8, Total: 8},
+		{Date: "2022-06-02", SprintID: 1, Remaining: 8, Total: 8},
+		{Date: "2022-06-03", SprintID: 1, Remaining: 3, Total: 8},
+	}
+	var buf bytes.Buffer
+	PrintBurndown(&buf, sprint, snapshots, time.UTC)
+
+	want := "Komodo (3 of 8 pts remaining)\n" +
+		"8 ┤█ █\n" +
+		"  ┤█ █\n" +
+		"  ┤█ █\n" +
+		"  ┤█ █\n" +
+		"  ┤█ █ ·\n" +
+		"  ┤█ █ █\n" +
+		"  ┤█ █ █ ·\n" +
+		"  ┤█ █ █\n" +
+		"0 └──────────\n" +
+		"   6/1    6/5\n"
+	if diff := cmp.Diff(buf.String(), want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
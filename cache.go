@@ -0,0 +1,457 @@
+package kong
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/gofrs/flock"
+)
+
+// cacheVersion is the schema version of the data file. Increment it when a
+// change to Data cannot be decoded from files of the previous version and
+// register a migration for the previous version.
+const cacheVersion = 3
+
+// cacheMagic prefixes data files with a header. Files without it were
+// written before the header was introduced and are treated as version 0.
+var cacheMagic = []byte("KONG")
+
+// cacheHeaderSize is the size of the magic and the version which are followed
+// by sections prefixed by their length and checksum.
+const (
+	cacheHeaderSize   = 8
+	sectionHeaderSize = 12
+)
+
+var (
+	errCacheCorrupt = errors.New("data file is corrupt")
+	errCacheVersion = errors.New("data file was written by a newer version of kong, run kong cache reset")
+)
+
+// cacheMigrations upgrade data decoded from files of an older version, keyed
+// by the version they upgrade from. Migrations run in order of the version
+// until the data reaches cacheVersion.
+var cacheMigrations = map[uint32]func(d *Data) error{
+	0: migrateState,
+}
+
+// cacheV0 holds the fields of version 0 files which later versions keep in
+// the state file.
+type cacheV0 struct {
+	LastIssueCreated string
+}
+
+// migrateState moves the key of the last created issue, which version 0
+// files stored along with the data, into the state file unless the state
+// file has one.
+func migrateState(d *Data) error {
+	if d.LastIssueCreated == "" {
+		return nil
+	}
+	key := d.LastIssueCreated
+	return updateState(func(s *State) error {
+		if s.LastIssueCreated == "" {
+			s.LastIssueCreated = key
+		}
+		return nil
+	})
+}
+
+// issueBody holds the large text fields of an issue which most commands never
+// show. They are decoded on demand with Data.LoadBodies.
+type issueBody struct {
+	Description        string
+	AcceptanceCriteria string
+}
+
+// issueBodies are the bodies of issues by key.
+type issueBodies map[string]issueBody
+
+// cachePayload is the layout of the data section. The transitions of every
+// issue are stored once per workflow and issues which are part of Issues are
+// not repeated in IssueByKey. Version 0 files encode Data as is.
+type cachePayload struct {
+	Data          Data
+	Workflows     [][]Transition
+	WorkflowByKey map[string]int
+}
+
+func newCachePayload(d Data) (cachePayload, issueBodies) {
+	p := cachePayload{WorkflowByKey: make(map[string]int)}
+	bodies := make(issueBodies)
+	d.IssueByKey = unlistedIssues(d.Issues, d.IssueByKey)
+
+	// the state is written to the state file on its own
+	d.State = State{}
+	if d.Projects != nil {
+		projects := make(map[string]ProjectData, len(d.Projects))
+		for name, project := range d.Projects {
+			project.IssueByKey = unlistedIssues(project.Issues, project.IssueByKey)
+			projects[name] = project
+		}
+		d.Projects = projects
+	}
+	workflows := make(map[string]int)
+	d.rewriteIssues(func(issue Issue) Issue {
+		if len(issue.Transitions) > 0 {
+			key := transitionsKey(issue.Transitions)
+			i, ok := workflows[key]
+			if !ok {
+				i = len(p.Workflows)
+				workflows[key] = i
+				p.Workflows = append(p.Workflows, issue.Transitions)
+			}
+			p.WorkflowByKey[issue.Key] = i
+		}
+		if _, ok := bodies[issue.Key]; !ok && (issue.Description != "" || issue.AcceptanceCriteria != "") {
+			bodies[issue.Key] = issueBody{issue.Description, issue.AcceptanceCriteria}
+		}
+		issue.Transitions = nil
+		issue.TransitionsByAcronym = nil
+		issue.OrderByTransitionStatus = nil
+		issue.Description = ""
+		issue.AcceptanceCriteria = ""
+		return issue
+	})
+	p.Data = d
+	return p, bodies
+}
+
+// data restores the data from the payload.
+func (p cachePayload) data() Data {
+	c := newCompactor()
+	tables := make([]transitionTable, len(p.Workflows))
+	for i, workflow := range p.Workflows {
+		tables[i] = c.table(workflow)
+	}
+	d := p.Data
+	d.IssueByKey = withListedIssues(d.Issues, d.IssueByKey)
+	for name, project := range d.Projects {
+		project.IssueByKey = withListedIssues(project.Issues, project.IssueByKey)
+		d.Projects[name] = project
+	}
+	d.rewriteIssues(func(issue Issue) Issue {
+		if i, ok := p.WorkflowByKey[issue.Key]; ok && i < len(tables) {
+			issue = tables[i].apply(issue)
+		}
+		return c.issue(issue)
+	})
+	if len(d.Transitions) > 0 {
+		d.Transitions = c.table(d.Transitions).transitions
+	}
+	return d
+}
+
+// encodeCache encodes the data prefixed by the header. The issue bodies
+// follow the other data so that reading the data can stop before them.
+func encodeCache(d Data) ([]byte, error) {
+	p, bodies := newCachePayload(d)
+	b := make([]byte, cacheHeaderSize)
+	copy(b, cacheMagic)
+	binary.BigEndian.PutUint32(b[4:8], cacheVersion)
+	for _, v := range []any{p, bodies} {
+		var section bytes.Buffer
+		if err := gob.NewEncoder(&section).Encode(v); err != nil {
+			return nil, err
+		}
+		var header [sectionHeaderSize]byte
+		binary.BigEndian.PutUint64(header[0:8], uint64(section.Len()))
+		binary.BigEndian.PutUint32(header[8:12], crc32.ChecksumIEEE(section.Bytes()))
+		b = append(append(b, header[:]...), section.Bytes()...)
+	}
+	return b, nil
+}
+
+// decodeCache decodes the data file including the issue bodies.
+func decodeCache(b []byte) (Data, error) {
+	return readCache(bytes.NewReader(b), true)
+}
+
+// withoutBodies cuts the content of the data file after the data section so
+// that readers which skip the issue bodies do not have to transfer them.
+// Version 0 files have no sections and are returned as is.
+func withoutBodies(b []byte) []byte {
+	if len(b) < cacheHeaderSize+sectionHeaderSize || !bytes.Equal(b[:len(cacheMagic)], cacheMagic) {
+		return b
+	}
+	length := binary.BigEndian.Uint64(b[cacheHeaderSize : cacheHeaderSize+8])
+	if length > uint64(len(b)-cacheHeaderSize-sectionHeaderSize) {
+		return b
+	}
+	return b[:cacheHeaderSize+sectionHeaderSize+int(length)]
+}
+
+// readCache decodes the data file while reading it and migrates data of older
+// versions. The issue bodies are skipped unless withBodies is set, version 0
+// files always include them.
+func readCache(r io.Reader, withBodies bool) (Data, error) {
+	br := bufio.NewReader(r)
+	if magic, _ := br.Peek(len(cacheMagic)); !bytes.Equal(magic, cacheMagic) {
+		return readCacheV0(br)
+	}
+	var header [cacheHeaderSize]byte
+	if _, err := io.ReadFull(br, header[:]); err != nil {
+		return NewData(), errCacheCorrupt
+	}
+	version := binary.BigEndian.Uint32(header[4:8])
+	if version > cacheVersion {
+		return NewData(), fmt.Errorf("%w (version %d)", errCacheVersion, version)
+	}
+	// files of version 1 and 2 are fetched again rather than decoded
+	if version < 3 {
+		return NewData(), fmt.Errorf("%w (version %d)", errCacheCorrupt, version)
+	}
+
+	p := cachePayload{Data: NewData()}
+	if err := readSection(br, &p); err != nil {
+		return NewData(), err
+	}
+	data := p.data()
+	if withBodies {
+		var bodies issueBodies
+		if err := readSection(br, &bodies); err != nil {
+			return NewData(), err
+		}
+		data.setBodies(bodies)
+	} else {
+		data.bodiesPending = true
+	}
+	if err := migrateCache(&data, version); err != nil {
+		return NewData(), err
+	}
+	return data, nil
+}
+
+// readCacheV0 decodes files written before the header was introduced, which
+// encode Data as is, and migrates them.
+func readCacheV0(r io.Reader) (Data, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return NewData(), err
+	}
+	data := NewData()
+	if err := decodeSection(bytes.NewReader(b), -1, nil, &data); err != nil {
+		return NewData(), err
+	}
+	var legacy cacheV0
+	if err := decodeSection(bytes.NewReader(b), -1, nil, &legacy); err != nil {
+		return NewData(), err
+	}
+	data.compact()
+	data.LastIssueCreated = legacy.LastIssueCreated
+	if err := migrateCache(&data, 0); err != nil {
+		return NewData(), err
+	}
+	return data, nil
+}
+
+func migrateCache(data *Data, version uint32) error {
+	for ; version < cacheVersion; version++ {
+		if migrate, ok := cacheMigrations[version]; ok {
+			if err := migrate(data); err != nil {
+				return fmt.Errorf("migrateCache(%d): %w", version, err)
+			}
+		}
+	}
+	return nil
+}
+
+// readSection decodes the section at the current position of the reader.
+func readSection(r io.Reader, v any) error {
+	var header [sectionHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return errCacheCorrupt
+	}
+	length := int64(binary.BigEndian.Uint64(header[0:8]))
+	sum := binary.BigEndian.Uint32(header[8:12])
+	return decodeSection(r, length, &sum, v)
+}
+
+// decodeSection decodes v from the next length bytes of the reader, or up to
+// the end if length is negative, and verifies the checksum if given.
+func decodeSection(r io.Reader, length int64, sum *uint32, v any) error {
+	if length >= 0 {
+		r = io.LimitReader(r, length)
+	}
+	hash := crc32.NewIEEE()
+	r = io.TeeReader(r, hash)
+	if err := gob.NewDecoder(r).Decode(v); err != nil {
+		return fmt.Errorf("%w: %v", errCacheCorrupt, err)
+	}
+	// the checksum covers bytes the decoder did not need to read
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return fmt.Errorf("%w: %v", errCacheCorrupt, err)
+	}
+	if sum != nil && hash.Sum32() != *sum {
+		return errCacheCorrupt
+	}
+	return nil
+}
+
+// readBodies decodes the issue bodies section of the data file. Version 0
+// files have no such section.
+func readBodies(path string) (issueBodies, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var header [cacheHeaderSize + sectionHeaderSize]byte
+	if _, err := io.ReadFull(f, header[:]); err != nil {
+		return nil, nil
+	}
+	if !bytes.Equal(header[:4], cacheMagic) || binary.BigEndian.Uint32(header[4:8]) < 3 {
+		return nil, nil
+	}
+	length := int64(binary.BigEndian.Uint64(header[cacheHeaderSize : cacheHeaderSize+8]))
+	if _, err := f.Seek(length, io.SeekCurrent); err != nil {
+		return nil, err
+	}
+	var bodies issueBodies
+	if err := readSection(bufio.NewReader(f), &bodies); err != nil {
+		return nil, fmt.Errorf("readBodies(%s): %w", path, err)
+	}
+	return bodies, nil
+}
+
+// LoadBodies decodes the descriptions and acceptance criteria of the issues
+// which are skipped when reading the data file. Issues which already have a
+// body, for instance because they were fetched since, keep it.
+func (d *Data) LoadBodies() error {
+	if !d.bodiesPending {
+		return nil
+	}
+	unlock, err := lockCache()
+	if err != nil {
+		return err
+	}
+	bodies, err := readBodies(cachePath())
+	unlock()
+	if err != nil {
+		return err
+	}
+	d.setBodies(bodies)
+	return nil
+}
+
+func (d *Data) setBodies(bodies issueBodies) {
+	d.bodiesPending = false
+	if len(bodies) == 0 {
+		return
+	}
+	d.rewriteIssues(bodies.fill)
+}
+
+// fill sets the body of the issue unless it has one.
+func (b issueBodies) fill(issue Issue) Issue {
+	body, ok := b[issue.Key]
+	if ok && issue.Description == "" && issue.AcceptanceCriteria == "" {
+		issue.Description = body.Description
+		issue.AcceptanceCriteria = body.AcceptanceCriteria
+	}
+	return issue
+}
+
+// withBodies returns the issues with the bodies of the data file for issues
+// without a body. The issues are returned as is if the bodies cannot be read.
+func (i Issues) withBodies() Issues {
+	unlock, err := lockCache()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return i
+	}
+	bodies, err := readBodies(cachePath())
+	unlock()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return i
+	}
+	result := make(Issues, len(i))
+	for j, issue := range i {
+		result[j] = bodies.fill(issue)
+	}
+	return result
+}
+
+// writeCache replaces the data file with the encoded data. The data is
+// written to a temporary file first so that readers never see a partially
+// written file.
+func writeCache(path string, d Data) error {
+	b, err := encodeCache(d)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, b)
+}
+
+// writeFileAtomic replaces the file through a temporary file.
+func writeFileAtomic(path string, b []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// cacheLockPath returns the path of the lock file guarding the data file. It
+// is separate from the data file which is replaced on every write.
+func cacheLockPath() string {
+	return cachePath() + ".lock"
+}
+
+// ResetCache removes the data file so that the next sync starts from scratch.
+// The local state like archived and pinned issues, the history, audit log and
+// completed issues are kept.
+func ResetCache() error {
+	session.mu.Lock()
+	session.data.reset()
+	session.mu.Unlock()
+
+	lock, err := lockCache()
+	if err != nil {
+		return err
+	}
+	defer lock()
+	if err := os.Remove(cachePath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// lockCache takes the file lock guarding the data file and returns the func
+// releasing it.
+func lockCache() (func(), error) {
+	path := cacheLockPath()
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, err
+	}
+	lock := flock.New(path)
+	if err := lock.Lock(); err != nil {
+		return nil, err
+	}
+	return func() {
+		if err := lock.Unlock(); err != nil {
+			fmt.Fprint(os.Stderr, err)
+		}
+	}, nil
+}
@@ -0,0 +1,196 @@
+package kong
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDecodeCache(t *testing.T) {
+	data := NewData()
+	data.Project = "KONG-1"
+	encoded, err := encodeCache(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	version1 := append([]byte(nil), encoded...)
+	binary.BigEndian.PutUint32(version1[4:8], 1)
+	corrupt := append([]byte(nil), encoded...)
+	corrupt[len(corrupt)-1] ^= 0xff
+	newer := append([]byte(nil), encoded...)
+	binary.BigEndian.PutUint32(newer[4:8], cacheVersion+1)
+
+	tests := []struct {
+		name    string
+		b       []byte
+		want    string
+		wantErr error
+	}{
+		{
+			name: "current",
+			b:    encoded,
+			want: "KONG-1",
+		},
+		{
+			name:    "version 1",
+			b:       version1,
+			wantErr: errCacheCorrupt,
+		},
+		{
+			name:    "checksum",
+			b:       corrupt,
+			wantErr: errCacheCorrupt,
+		},
+		{
+			name:    "truncated",
+			b:       encoded[:cacheHeaderSize-1],
+			wantErr: errCacheCorrupt,
+		},
+		{
+			name:    "garbage",
+			b:       []byte("not a data file"),
+			wantErr: errCacheCorrupt,
+		},
+		{
+			name:    "newer version",
+			b:       newer,
+			wantErr: errCacheVersion,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeCache(tt.b)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want: %v", err, tt.wantErr)
+			}
+			if got.Project != tt.want {
+				t.Errorf("got %v, want: %v", got.Project, tt.want)
+			}
+		})
+	}
+}
+
+// baselineData is the layout of Data in version 0 files, which were written
+// before the header was introduced.
+type baselineData struct {
+	Timestamp        int64
+	Issues           []baselineIssue
+	IssueByKey       map[string]baselineIssue
+	SprintsByName    map[string]Sprint
+	BoardID          int
+	LastIssueCreated string
+}
+
+type baselineIssue struct {
+	Key      string
+	Summary  string
+	Priority string
+	Status   Status
+	SprintID int
+}
+
+func TestReadCacheVersion0(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+	session.state.reset()
+
+	issue := baselineIssue{Key: "KONG-1", Summary: "Add daemon", Priority: "High", Status: Status{Name: "To Do"}}
+	var b bytes.Buffer
+	if err := gob.NewEncoder(&b).Encode(baselineData{
+		Timestamp:        1,
+		Issues:           []baselineIssue{issue},
+		IssueByKey:       map[string]baselineIssue{"KONG-1": issue},
+		BoardID:          2,
+		LastIssueCreated: "KONG-1",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := decodeCache(b.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Issues) != 1 || got.Issues[0].Summary != "Add daemon" || got.BoardID != 2 {
+		t.Errorf("got %+v, want the baseline issue and board", got)
+	}
+
+	// the key of the last created issue moved into the state file
+	state, err := readState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.LastIssueCreated != "KONG-1" {
+		t.Errorf("got %v, want: %v", state.LastIssueCreated, "KONG-1")
+	}
+}
+
+func TestReadDataCorrupt(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+	session.data.reset()
+
+	if err := os.WriteFile(cachePath(), []byte("corrupt"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadData(); !errors.Is(err, ErrDataMissing) {
+		t.Errorf("got %v, want: %v", err, ErrDataMissing)
+	}
+	if _, err := os.Stat(cachePath() + ".corrupt"); err != nil {
+		t.Errorf("got %v, want corrupt file to be kept", err)
+	}
+}
+
+func TestResetCache(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+
+	data := NewData()
+	data.Project = "KONG-1"
+	if err := data.WriteFile(); err != nil {
+		t.Fatal(err)
+	}
+	if err := ResetCache(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadData(); !errors.Is(err, ErrDataMissing) {
+		t.Errorf("got %v, want: %v", err, ErrDataMissing)
+	}
+	// resetting a missing cache is not an error
+	if err := ResetCache(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLoadBodies(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+	session.data.reset()
+
+	data := NewData()
+	data.Issues = Issues{{Key: "KONG-1", Summary: "Add bodies", Description: "Decode on demand"}}
+	if err := data.WriteFile(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Issues[0].Description != "" {
+		t.Errorf("got description %q, want it to be skipped", got.Issues[0].Description)
+	}
+
+	// writing the data must keep the bodies which were not read
+	got.Project = "KONG-1"
+	if err := got.WriteFile(); err != nil {
+		t.Fatal(err)
+	}
+	got, err = ReadData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := got.LoadBodies(); err != nil {
+		t.Fatal(err)
+	}
+	if want := "Decode on demand"; got.Issues[0].Description != want {
+		t.Errorf("got %q, want: %q", got.Issues[0].Description, want)
+	}
+}
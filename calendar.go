@@ -0,0 +1,70 @@
+package kong
+
+import (
+	"time"
+)
+
+const holidayLayout = "2006-01-02"
+
+// Calendar determines sprint boundaries either in calendar days or in working
+// days which skip weekends and configured holidays.
+type Calendar struct {
+	WorkingDays bool
+	Holidays    map[string]struct{}
+}
+
+// NewCalendar returns a new instance of Calendar. Holidays are expected in
+// the format YYYY-MM-DD.
+func NewCalendar(workingDays bool, holidays []string) Calendar {
+	c := Calendar{
+		WorkingDays: workingDays,
+		Holidays:    make(map[string]struct{}, len(holidays)),
+	}
+	for _, holiday := range holidays {
+		c.Holidays[holiday] = struct{}{}
+	}
+	return c
+}
+
+// IsWorkingDay reports whether the given day is neither on a weekend nor a
+// holiday.
+func (c Calendar) IsWorkingDay(t time.Time) bool {
+	switch t.Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+	_, ok := c.Holidays[t.Format(holidayLayout)]
+	return !ok
+}
+
+// EndDate returns the end of a sprint which starts at the given date and
+// lasts the given number of days. In working days mode the sprint ends at the
+// end of its last working day, counting the start date as the first day.
+func (c Calendar) EndDate(start time.Time, days int) time.Time {
+	if !c.WorkingDays {
+		return start.AddDate(0, 0, days+1)
+	}
+	day := start
+	for n := 0; ; day = day.AddDate(0, 0, 1) {
+		if c.IsWorkingDay(day) {
+			n++
+		}
+		if n >= days {
+			break
+		}
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), 23, 59, 59, 0, day.Location())
+}
+
+// DaysRemaining returns the number of days left until the end date including
+// the current day. In working days mode only working days are counted.
+func (c Calendar) DaysRemaining(now, end time.Time) int {
+	var n int
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for ; day.Before(end); day = day.AddDate(0, 0, 1) {
+		if !c.WorkingDays || c.IsWorkingDay(day) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,57 @@
+package kong
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalendarEndDate(t *testing.T) {
+	// Monday
+	start := time.Date(2023, time.July, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		calendar Calendar
+		days     int
+		want     time.Time
+	}{
+		{
+			name:     "calendar-days",
+			calendar: NewCalendar(false, nil),
+			days:     14,
+			want:     time.Date(2023, time.July, 18, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "working-days",
+			calendar: NewCalendar(true, nil),
+			days:     10,
+			want:     time.Date(2023, time.July, 14, 23, 59, 59, 0, time.UTC),
+		},
+		{
+			name:     "working-days-with-holiday",
+			calendar: NewCalendar(true, []string{"2023-07-04"}),
+			days:     10,
+			want:     time.Date(2023, time.July, 17, 23, 59, 59, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.calendar.EndDate(start, tt.days); !got.Equal(tt.want) {
+				t.Errorf("got %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendarDaysRemaining(t *testing.T) {
+	// Thursday
+	now := time.Date(2023, time.July, 13, 10, 0, 0, 0, time.UTC)
+	end := time.Date(2023, time.July, 18, 0, 0, 0, 0, time.UTC)
+
+	if got := NewCalendar(false, nil).DaysRemaining(now, end); got != 5 {
+		t.Errorf("got %d, want: %d", got, 5)
+	}
+	if got := NewCalendar(true, nil).DaysRemaining(now, end); got != 3 {
+		t.Errorf("got %d, want: %d", got, 3)
+	}
+}
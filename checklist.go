@@ -0,0 +1,108 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var errChecklistItemEmpty = errors.New("checklist item empty")
+
+// Checklist is the definition of done of a status. Its items are shown when
+// issues are transitioned to the status through kong and have to be
+// confirmed. If Comment is set the confirmed checklist is posted as comment on
+// each transitioned issue.
+type Checklist struct {
+	Items   []string `yaml:"items"`
+	Comment bool     `yaml:"comment"`
+}
+
+func (c Checklist) validate() error {
+	for _, item := range c.Items {
+		if strings.TrimSpace(item) == "" {
+			return errChecklistItemEmpty
+		}
+	}
+	return nil
+}
+
+// pendingChecklist is the checklist of a status together with the issues
+// transitioned to it.
+type pendingChecklist struct {
+	status    string
+	checklist Checklist
+	keys      []string
+}
+
+// pendingChecklists returns the checklists of the target statuses of the
+// transitions in the order the statuses first appear.
+func (c Config) pendingChecklists(issueTransitions []issueTransition) []pendingChecklist {
+	var pending []pendingChecklist
+	index := make(map[string]int)
+	for _, t := range issueTransitions {
+		status := t.transition.Name
+		checklist, ok := c.Checklists[status]
+		if !ok || len(checklist.Items) == 0 {
+			continue
+		}
+		i, ok := index[status]
+		if !ok {
+			i = len(pending)
+			index[status] = i
+			pending = append(pending, pendingChecklist{status: status, checklist: checklist})
+		}
+		pending[i].keys = append(pending[i].keys, t.issueKey)
+	}
+	return pending
+}
+
+// String formats the checklist for the terminal.
+func (p pendingChecklist) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Definition of done for %s (%s):\n", p.status, strings.Join(p.keys, ", "))
+	for _, item := range p.checklist.Items {
+		fmt.Fprintf(&sb, "  [ ] %s\n", item)
+	}
+	return sb.String()
+}
+
+// comment formats the confirmed checklist as Jira comment.
+func (p pendingChecklist) comment() string {
+	lines := make([]string, 0, len(p.checklist.Items)+1)
+	lines = append(lines, fmt.Sprintf("Definition of done for %s:", p.status))
+	for _, item := range p.checklist.Items {
+		lines = append(lines, "(/) "+item)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// confirmChecklists prints the checklists of the target statuses of the
+// transitions and asks the user to confirm them. It returns true if no
+// checklist is configured for any of the statuses.
+func (j Jira) confirmChecklists(issueTransitions []issueTransition, confirm func(prompt string) (bool, error)) (bool, error) {
+	pending := j.config.pendingChecklists(issueTransitions)
+	if len(pending) == 0 {
+		return true, nil
+	}
+	for _, p := range pending {
+		fmt.Print(p)
+	}
+	return confirm("All items done?")
+}
+
+// commentChecklists posts the confirmed checklists on the transitioned issues
+// if configured.
+func (j Jira) commentChecklists(ctx context.Context, issueTransitions []issueTransition) error {
+	for _, p := range j.config.pendingChecklists(issueTransitions) {
+		if !p.checklist.Comment {
+			continue
+		}
+		for _, key := range p.keys {
+			if err := j.AddComment(ctx, key, p.comment()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
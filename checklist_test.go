@@ -0,0 +1,89 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPendingChecklists(t *testing.T) {
+	config := Config{
+		Checklists: map[string]Checklist{
+			"Done":   {Items: []string{"PR merged", "Docs updated"}, Comment: true},
+			"Review": {Items: []string{"Tests pass"}},
+			"Closed": {},
+		},
+	}
+	issueTransitions := []issueTransition{
+		{issueKey: "KONG-1", transition: Transition{Name: "Done"}},
+		{issueKey: "KONG-2", transition: Transition{Name: "In Progress"}},
+		{issueKey: "KONG-3", transition: Transition{Name: "Review"}},
+		{issueKey: "KONG-4", transition: Transition{Name: "Done"}},
+		{issueKey: "KONG-5", transition: Transition{Name: "Closed"}},
+	}
+	got := config.pendingChecklists(issueTransitions)
+	want := []pendingChecklist{
+		{status: "Done", checklist: config.Checklists["Done"], keys: []string{"KONG-1", "KONG-4"}},
+		{status: "Review", checklist: config.Checklists["Review"], keys: []string{"KONG-3"}},
+	}
+	if diff := cmp.Diff(got, want, cmp.AllowUnexported(pendingChecklist{})); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+
+	wantString := "Definition of done for Done (KONG-1, KONG-4):\n  [ ] PR merged\n  [ ] Docs updated\n"
+	if got := got[0].String(); got != wantString {
+		t.Errorf("got %q, want: %q", got, wantString)
+	}
+	wantComment := "Definition of done for Done:\n(/) PR merged\n(/) Docs updated"
+	if got := got[0].comment(); got != wantComment {
+		t.Errorf("got %q, want: %q", got, wantComment)
+	}
+}
+
+func TestCommentChecklists(t *testing.T) {
+	var commented []string
+	client := newTestJira(t, func(req *http.Request) (*http.Response, error) {
+		commented = append(commented, req.URL.Path)
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       io.NopCloser(strings.NewReader(`{}`)),
+		}, nil
+	})
+	j := Jira{client: client, config: Config{
+		Checklists: map[string]Checklist{
+			"Done":   {Items: []string{"PR merged"}, Comment: true},
+			"Review": {Items: []string{"Tests pass"}},
+		},
+	}}
+	issueTransitions := []issueTransition{
+		{issueKey: "KONG-1", transition: Transition{Name: "Done"}},
+		{issueKey: "KONG-2", transition: Transition{Name: "Review"}},
+	}
+	if err := j.commentChecklists(context.Background(), issueTransitions); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"/rest/api/2/issue/KONG-1/comment"}
+	if diff := cmp.Diff(commented, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestChecklistValidate(t *testing.T) {
+	tests := []struct {
+		checklist Checklist
+		err       error
+	}{
+		{checklist: Checklist{Items: []string{"PR merged"}}},
+		{checklist: Checklist{Items: []string{"PR merged", " "}}, err: errChecklistItemEmpty},
+	}
+	for _, tt := range tests {
+		if err := tt.checklist.validate(); !errors.Is(err, tt.err) {
+			t.Errorf("got %v, want: %v", err, tt.err)
+		}
+	}
+}
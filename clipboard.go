@@ -0,0 +1,126 @@
+package kong
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+var errNoClipboard = errors.New("no clipboard command found to paste from")
+
+// Clipboard copies text to the system clipboard.
+type Clipboard interface {
+	Copy(ctx context.Context, b []byte) error
+}
+
+// NewClipboard returns the clipboard to use. A configured copy command takes
+// precedence, otherwise the clipboard tool is detected based on the platform
+// falling back to the OSC52 terminal escape sequence which also works over
+// SSH.
+func NewClipboard(copyCommand string) Clipboard {
+	if args := strings.Fields(copyCommand); len(args) > 0 {
+		return commandClipboard(args)
+	}
+	for _, args := range clipboardCommands() {
+		if _, err := exec.LookPath(args[0]); err == nil {
+			return commandClipboard(args)
+		}
+	}
+	return osc52Clipboard{}
+}
+
+func clipboardCommands() [][]string {
+	switch runtime.GOOS {
+	case "darwin":
+		return [][]string{{"pbcopy"}}
+	case "windows":
+		return [][]string{{"clip.exe"}}
+	}
+	var commands [][]string
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		commands = append(commands, []string{"wl-copy"})
+	}
+	if os.Getenv("DISPLAY") != "" {
+		commands = append(commands,
+			[]string{"xclip", "-selection", "clipboard"},
+			[]string{"xsel", "--clipboard", "--input"},
+		)
+	}
+	// Windows Subsystem for Linux
+	return append(commands, []string{"clip.exe"})
+}
+
+// commandClipboard pipes the text into an external command.
+type commandClipboard []string
+
+func (c commandClipboard) Copy(ctx context.Context, b []byte) error {
+	cmd := exec.CommandContext(ctx, c[0], c[1:]...)
+	cmd.Stdin = bytes.NewBuffer(b)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %w", c[0], err)
+	}
+	return nil
+}
+
+// osc52Clipboard asks the terminal emulator to set the clipboard.
+type osc52Clipboard struct{}
+
+func (osc52Clipboard) Copy(ctx context.Context, b []byte) error {
+	var w io.Writer = os.Stderr
+	if tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0); err == nil {
+		defer tty.Close()
+		w = tty
+	}
+	_, err := fmt.Fprintf(w, "\x1b]52;c;%s\a", base64.StdEncoding.EncodeToString(b))
+	return err
+}
+
+// copyToClipboard copies b using the configured copy command or the detected
+// clipboard.
+func copyToClipboard(ctx context.Context, copyCommand string, b []byte) error {
+	return NewClipboard(copyCommand).Copy(ctx, b)
+}
+
+func pasteCommands() [][]string {
+	switch runtime.GOOS {
+	case "darwin":
+		return [][]string{{"pbpaste"}}
+	case "windows":
+		return [][]string{{"powershell.exe", "-NoProfile", "-Command", "Get-Clipboard"}}
+	}
+	var commands [][]string
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		commands = append(commands, []string{"wl-paste", "--no-newline"})
+	}
+	if os.Getenv("DISPLAY") != "" {
+		commands = append(commands,
+			[]string{"xclip", "-selection", "clipboard", "-out"},
+			[]string{"xsel", "--clipboard", "--output"},
+		)
+	}
+	// Windows Subsystem for Linux
+	return append(commands, []string{"powershell.exe", "-NoProfile", "-Command", "Get-Clipboard"})
+}
+
+// ReadClipboard returns the text of the system clipboard using the first
+// available clipboard tool of the platform.
+func ReadClipboard(ctx context.Context) ([]byte, error) {
+	for _, args := range pasteCommands() {
+		if _, err := exec.LookPath(args[0]); err != nil {
+			continue
+		}
+		b, err := exec.CommandContext(ctx, args[0], args[1:]...).Output()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", args[0], err)
+		}
+		return b, nil
+	}
+	return nil, errNoClipboard
+}
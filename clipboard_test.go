@@ -0,0 +1,62 @@
+package kong
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewClipboard(t *testing.T) {
+	if _, err := exec.LookPath("tee"); err != nil {
+		t.Skip("tee is not available")
+	}
+	path := filepath.Join(t.TempDir(), "clipboard")
+
+	// the configured copy command takes precedence over detected tools
+	clipboard := NewClipboard("tee " + path)
+	if diff := cmp.Diff(clipboard, commandClipboard{"tee", path}); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	if err := clipboard.Copy(context.Background(), []byte("KONG-1")); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(b); got != "KONG-1" {
+		t.Errorf("got %q, want: %q", got, "KONG-1")
+	}
+}
+
+func TestCommandClipboardError(t *testing.T) {
+	if _, err := exec.LookPath("false"); err != nil {
+		t.Skip("false is not available")
+	}
+	err := commandClipboard{"false"}.Copy(context.Background(), nil)
+	if err == nil || !strings.HasPrefix(err.Error(), "false: ") {
+		t.Errorf("got %v, want error of the command", err)
+	}
+}
+
+func TestClipboardCommands(t *testing.T) {
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		t.Skip("clipboard tools are detected by platform")
+	}
+	t.Setenv("WAYLAND_DISPLAY", "wayland-0")
+	t.Setenv("DISPLAY", "")
+	want := [][]string{{"wl-copy"}, {"clip.exe"}}
+	if diff := cmp.Diff(clipboardCommands(), want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	want = [][]string{{"wl-paste", "--no-newline"}, {"powershell.exe", "-NoProfile", "-Command", "Get-Clipboard"}}
+	if diff := cmp.Diff(pasteCommands(), want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
@@ -3,18 +3,66 @@ package main
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/konradreiche/kong"
 	"github.com/spf13/cobra"
 )
 
 var (
-	projectFlag string
-	allFlag     bool
+	projectFlag   string
+	allFlag       bool
+	openFlag      bool
+	fromGitFlag   string
+	versionFlag   string
+	createFlag    bool
+	startFlag     bool
+	dotFlag       bool
+	daysFlag      int
+	copyFlag      int
+	formatFlag    string
+	inputFlag     string
+	filterFlag    string
+	countFlag     bool
+	archiveFlag   bool
+	issuesFlag    bool
+	byEpicFlag    bool
+	estimateFlag  string
+	noneFlag      bool
+	readOnlyFlag  bool
+	pluginFlag    bool
+	launcherFlag  string
+	clipboardFlag bool
+	csvFlag       bool
+	fieldFlag     []string
+	oldestFlag    bool
+	profileFlag   string
+	snoozedFlag   bool
+	messageFlag   string
+	commentsFlag  bool
+	asciiFlag     bool
+	todayFlag     bool
+	outputFlag    string
+	saveFlag      string
+	templateFlag  string
+	sprintFlag    string
+	markdownFlag  bool
+	labelFlag     []string
+)
+
+var (
+	// invokedArgs are the arguments kong was invoked with after expanding
+	// command aliases.
+	invokedArgs []string
+	// mutatingCmds are the commands which change Jira.
+	mutatingCmds = make(map[*cobra.Command]bool)
 )
 
 func main() {
@@ -22,8 +70,9 @@ func main() {
 }
 
 var cmd = &cobra.Command{
-	Use:   "kong",
-	Short: "🦍 Kong is a Jira CLI for low-latency workflows",
+	Use:              "kong",
+	Short:            "🦍 Kong is a Jira CLI for low-latency workflows",
+	PersistentPreRun: recordHistory,
 	Run: func(cmd *cobra.Command, args []string) {
 		must(cmd.Help())
 	},
@@ -33,17 +82,138 @@ var daemonCmd = &cobra.Command{
 	Use:   "daemon",
 	Short: "Run background process",
 	Run: func(cmd *cobra.Command, args []string) {
+		unlock, err := kong.LockDaemon()
+		if err != nil {
+			exit(err)
+		}
+		defer unlock()
 		d, err := kong.NewDaemon()
 		if err != nil {
 			exit(err)
 		}
-		d.Run(cmd.Context())
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		go func() {
+			if err := d.ServeRPC(ctx); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}()
+		d.Run(ctx)
+	},
+}
+
+var startDaemonCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start the background process unless it is running already",
+	Run: func(cmd *cobra.Command, args []string) {
+		must(kong.StartDaemon())
+		fmt.Println("Daemon started")
+	},
+}
+
+var stopDaemonCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the running background process",
+	Run: func(cmd *cobra.Command, args []string) {
+		must(kong.StopDaemon())
+		fmt.Println("Daemon stopped")
+	},
+}
+
+var restartDaemonCmd = &cobra.Command{
+	Use:   "restart",
+	Short: "Stop the background process if it is running and start it again",
+	Run: func(cmd *cobra.Command, args []string) {
+		must(kong.RestartDaemon())
+		fmt.Println("Daemon restarted")
+	},
+}
+
+var statusDaemonCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether the background process is running, its last refresh and the cache age",
+	Run: func(cmd *cobra.Command, args []string) {
+		status, err := kong.ReadDaemonStatus()
+		if err != nil {
+			exit(err)
+		}
+		must(kong.PrintDaemonStatus(cmd.OutOrStdout(), status, time.Now()))
+	},
+}
+
+var serviceCmd = &cobra.Command{
+	Use:   "service",
+	Short: "Manage the background process as user service",
+	Run: func(cmd *cobra.Command, args []string) {
+		must(cmd.Help())
+	},
+}
+
+var installServiceCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install the user service and start it on login",
+	Run: func(cmd *cobra.Command, args []string) {
+		service, err := kong.NewService()
+		if err != nil {
+			exit(err)
+		}
+		must(service.Install(cmd.Context()))
+		must(service.Start(cmd.Context()))
+	},
+}
+
+var uninstallServiceCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Stop and remove the user service",
+	Run: func(cmd *cobra.Command, args []string) {
+		service, err := kong.NewService()
+		if err != nil {
+			exit(err)
+		}
+		must(service.Uninstall(cmd.Context()))
+	},
+}
+
+var startServiceCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start or restart the background process",
+	Run: func(cmd *cobra.Command, args []string) {
+		service, err := kong.NewService()
+		if err != nil {
+			exit(err)
+		}
+		must(service.Start(cmd.Context()))
+	},
+}
+
+var stopServiceCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the background process",
+	Run: func(cmd *cobra.Command, args []string) {
+		service, err := kong.NewService()
+		if err != nil {
+			exit(err)
+		}
+		must(service.Stop(cmd.Context()))
+	},
+}
+
+var statusServiceCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Print the status of the background process",
+	Run: func(cmd *cobra.Command, args []string) {
+		service, err := kong.NewService()
+		if err != nil {
+			exit(err)
+		}
+		must(service.Status(cmd.Context()))
 	},
 }
 
 var issuesCmd = &cobra.Command{
-	Use:   "issues",
-	Short: "List and create issues",
+	Use:   "issues [words...]",
+	Short: "List and create issues, optionally narrowed down by words in the summary",
+	Args:  cobra.ArbitraryArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
 		if projectFlag != "" {
@@ -55,7 +225,8 @@ var issuesCmd = &cobra.Command{
 			if err != nil {
 				exit(err)
 			}
-			issues.Print(cmd.OutOrStderr())
+			issues = filterIssues(issues).Search(args)
+			printIssues(cmd, cmd.OutOrStderr(), issues, nil, issues.Print)
 			return
 		}
 		data, err := kong.LoadData()
@@ -66,7 +237,62 @@ var issuesCmd = &cobra.Command{
 		if err != nil {
 			exit(err)
 		}
-		issues.Print(cmd.OutOrStdout())
+		issues = filterIssues(withoutSnoozed(data, issues)).Search(args)
+		printPinned(cmd.OutOrStdout(), data)
+		printIssues(cmd, cmd.OutOrStdout(), issues, data.Sprints, issues.Print)
+	},
+}
+
+var searchCmd = &cobra.Command{
+	Use:   "search JQL|NAME",
+	Short: "List the issues matching a JQL query or a saved query",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := kong.LoadConfig()
+		if err != nil {
+			exit(err)
+		}
+		if saveFlag != "" {
+			must(config.SaveQuery(saveFlag, args[0]))
+			must(config.Write())
+		}
+		jira, err := kong.NewJira()
+		if err != nil {
+			exit(err)
+		}
+		issues, err := jira.Search(cmd.Context(), config.Query(args[0]))
+		if err != nil {
+			exit(err)
+		}
+		issues = filterIssues(issues)
+		printIssues(cmd, cmd.OutOrStdout(), issues, nil, issues.Print)
+	},
+}
+
+var viewCmd = &cobra.Command{
+	Use:   "view [name]",
+	Short: "List issues of a saved view",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := kong.LoadConfig()
+		if err != nil {
+			exit(err)
+		}
+		view, err := config.View(args[0])
+		if err != nil {
+			exit(err)
+		}
+		data, err := kong.LoadData()
+		if err != nil {
+			exit(err)
+		}
+		issues, err := view.Issues(cmd.Context(), data)
+		if err != nil {
+			exit(err)
+		}
+		printIssues(cmd, cmd.OutOrStdout(), issues, data.Sprints, func(w io.Writer) {
+			view.Print(w, issues)
+		})
 	},
 }
 
@@ -78,30 +304,203 @@ var issueCmd = &cobra.Command{
 	},
 }
 
+var remainingIssueCmd = &cobra.Command{
+	Use:   "remaining KEY ESTIMATE",
+	Short: "Set the remaining estimate of an issue, e.g. 4h",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		jira, err := kong.NewJira()
+		if err != nil {
+			exit(err)
+		}
+		must(jira.SetRemainingEstimate(cmd.Context(), args[0], args[1]))
+	},
+}
+
+var epicIssueCmd = &cobra.Command{
+	Use:   "epic KEY [EPIC-KEY]",
+	Short: "Move an issue to another epic",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if noneFlag {
+			return cobra.ExactArgs(1)(cmd, args)
+		}
+		return cobra.ExactArgs(2)(cmd, args)
+	},
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		data, err := kong.ReadData()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		switch len(args) {
+		case 0:
+			return completeKeys(data.Issues), cobra.ShellCompDirectiveNoFileComp
+		case 1:
+			return completeKeys(data.WithoutArchived(data.Epics)), cobra.ShellCompDirectiveNoFileComp
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		jira, err := kong.NewJira()
+		if err != nil {
+			exit(err)
+		}
+		var epicKey string
+		if !noneFlag {
+			epicKey = args[1]
+		}
+		must(jira.SetEpic(cmd.Context(), args[0], epicKey))
+	},
+}
+
+var assignIssueCmd = &cobra.Command{
+	Use:   "assign KEY USER",
+	Short: "Assign an issue to a user, me or none",
+	Args:  cobra.ExactArgs(2),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		data, err := kong.ReadData()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		switch len(args) {
+		case 0:
+			return completeKeys(data.Issues), cobra.ShellCompDirectiveNoFileComp
+		case 1:
+			return []string{"me", "none"}, cobra.ShellCompDirectiveNoFileComp
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		jira, err := kong.NewJira()
+		if err != nil {
+			exit(err)
+		}
+		must(jira.AssignIssueTo(cmd.Context(), args[0], args[1]))
+	},
+}
+
 var editIssueCmd = &cobra.Command{
 	Use:   "edit",
 	Short: "Edit an existing issue",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
-		editor, err := kong.NewEditor(ctx)
+		editor, err := kong.NewEditor(ctx, kong.SectionIssues)
 		if err != nil {
 			exit(err)
 		}
+		editor.SetInput(inputFlag)
 		must(editor.OpenEditIssueEditor(ctx, args[0]))
 	},
 }
 
+var viewIssueCmd = &cobra.Command{
+	Use:   "view KEY",
+	Short: "Show the fields and description of an issue",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := kong.ReadData()
+		if err != nil && err != kong.ErrDataMissing {
+			exit(err)
+		}
+		must(data.LoadBodies())
+		issue, err := data.LookupIssue(cmd.Context(), args[0])
+		if err != nil {
+			exit(err)
+		}
+		issue.PrintDetails(cmd.OutOrStdout())
+		if !commentsFlag {
+			return
+		}
+		jira, err := kong.NewJira()
+		if err != nil {
+			exit(err)
+		}
+		comments, err := jira.ListComments(cmd.Context(), issue.Key)
+		if err != nil {
+			exit(err)
+		}
+		kong.PrintComments(cmd.OutOrStdout(), comments)
+	},
+}
+
+var logIssueCmd = &cobra.Command{
+	Use:   "log KEY DURATION [comment...]",
+	Short: "Log time spent on an issue, e.g. 1h 30m",
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		jira, err := kong.NewJira()
+		if err != nil {
+			exit(err)
+		}
+		_, err = jira.AddWorklog(cmd.Context(), args[0], args[1], strings.Join(args[2:], " "))
+		if err != nil {
+			exit(err)
+		}
+	},
+}
+
+var worklogCmd = &cobra.Command{
+	Use:   "worklog",
+	Short: "List the time logged this week or today",
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := kong.LoadConfig()
+		if err != nil {
+			exit(err)
+		}
+		location, err := config.Location()
+		if err != nil {
+			exit(err)
+		}
+		data, err := kong.LoadData()
+		if err != nil {
+			exit(err)
+		}
+		worklogs, err := data.GetWorklogs(cmd.Context())
+		if err != nil {
+			exit(err)
+		}
+		worklogs.Print(cmd.OutOrStdout(), time.Now().In(location), todayFlag)
+	},
+}
+
+var commentIssueCmd = &cobra.Command{
+	Use:   "comment KEY",
+	Short: "Comment on an issue",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		if messageFlag != "" {
+			jira, err := kong.NewJira()
+			if err != nil {
+				exit(err)
+			}
+			must(jira.AddComment(ctx, args[0], messageFlag))
+			fmt.Printf("%s - Comment added\n", args[0])
+			return
+		}
+		editor, err := kong.NewEditor(ctx, kong.SectionIssues)
+		if err != nil {
+			exit(err)
+		}
+		editor.SetInput(inputFlag)
+		must(editor.OpenCommentEditor(ctx, args[0]))
+	},
+}
+
 var newIssuesCmd = &cobra.Command{
 	Use:   "new",
 	Short: "Create new issues",
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
-		editor, err := kong.NewEditor(ctx)
+		editor, err := kong.NewEditor(ctx, kong.SectionEpics|kong.SectionSprints)
 		if err != nil {
 			exit(err)
 		}
-		must(editor.OpenNewIssueEditor(ctx))
+		editor.SetInput(inputFlag)
+		editor.SetProject(projectFlag)
+		must(editor.SetEstimate(estimateFlag))
+		must(editor.SetFields(fieldFlag))
+		must(editor.OpenNewIssueEditor(ctx, openFlag))
 	},
 }
 
@@ -118,7 +517,8 @@ var epicsCmd = &cobra.Command{
 			if err != nil {
 				exit(err)
 			}
-			epics.Print(cmd.OutOrStderr())
+			epics = filterIssues(epics)
+			printIssues(cmd, cmd.OutOrStderr(), epics, nil, epics.Print)
 			return
 		}
 
@@ -126,112 +526,912 @@ var epicsCmd = &cobra.Command{
 		if err != nil {
 			exit(err)
 		}
-		epics, err := data.GetEpics(cmd.Context())
+		epics, err := data.GetEpics(cmd.Context())
+		if err != nil {
+			exit(err)
+		}
+		if !archiveFlag {
+			epics = data.WithoutArchived(epics)
+		}
+		epics = filterIssues(epics)
+		printIssues(cmd, cmd.OutOrStderr(), epics, data.Sprints, func(w io.Writer) {
+			epics.PrintProgress(w, data.EpicProgress)
+		})
+	},
+}
+
+var searchEpicsCmd = &cobra.Command{
+	Use:   "search TEXT",
+	Short: "Search epics and initiatives by summary, falls back to Jira if none are cached",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		text := strings.Join(args, " ")
+		var matches kong.Issues
+		if projectFlag == "" {
+			data, err := kong.ReadData()
+			if err != nil && err != kong.ErrDataMissing {
+				exit(err)
+			}
+			matches = data.SearchParents(text)
+		}
+		if len(matches) == 0 {
+			jira, err := kong.NewJira()
+			if err != nil {
+				exit(err)
+			}
+			project := projectFlag
+			if project == "" {
+				config, err := kong.LoadConfig()
+				if err != nil {
+					exit(err)
+				}
+				project = config.Project
+			}
+			matches, err = jira.SearchParents(ctx, project, text)
+			if err != nil {
+				exit(err)
+			}
+		}
+		printList(cmd.OutOrStdout(), matches, matches.Print)
+	},
+}
+
+var archiveEpicsCmd = &cobra.Command{
+	Use:   "archive [key...]",
+	Short: "Hide epics from listings and editors without changing Jira",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := kong.LoadData()
+		if err != nil {
+			exit(err)
+		}
+		must(data.UpdateState(func(d *kong.Data) error {
+			return d.ArchiveEpics(args...)
+		}))
+	},
+}
+
+var unarchiveEpicsCmd = &cobra.Command{
+	Use:   "unarchive [key...]",
+	Short: "Reveal archived epics again",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := kong.LoadData()
+		if err != nil {
+			exit(err)
+		}
+		must(data.UpdateState(func(d *kong.Data) error {
+			d.UnarchiveEpics(args...)
+			return nil
+		}))
+	},
+}
+
+var pinCmd = &cobra.Command{
+	Use:   "pin [key...]",
+	Short: "Pin issues to the top of kong sprint and kong issues",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := kong.LoadData()
+		if err != nil {
+			exit(err)
+		}
+		must(data.UpdateState(func(d *kong.Data) error {
+			return d.Pin(args...)
+		}))
+	},
+}
+
+var unpinCmd = &cobra.Command{
+	Use:   "unpin [key...]",
+	Short: "Remove issues from the pinned issues",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := kong.LoadData()
+		if err != nil {
+			exit(err)
+		}
+		must(data.UpdateState(func(d *kong.Data) error {
+			d.Unpin(args...)
+			return nil
+		}))
+	},
+}
+
+var snoozeCmd = &cobra.Command{
+	Use:   "snooze KEY DURATION",
+	Short: "Hide an issue from kong sprint and kong issues for a while, e.g. 3d",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := kong.LoadData()
+		if err != nil {
+			exit(err)
+		}
+		var until time.Time
+		must(data.UpdateState(func(d *kong.Data) error {
+			until, err = d.Snooze(args[0], args[1], time.Now())
+			return err
+		}))
+		fmt.Printf("%s - Snoozed until %s\n", args[0], until.Format("Mon Jan 2 15:04"))
+	},
+}
+
+var unsnoozeCmd = &cobra.Command{
+	Use:   "unsnooze [key...]",
+	Short: "Reveal snoozed issues again",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := kong.LoadData()
+		if err != nil {
+			exit(err)
+		}
+		must(data.UpdateState(func(d *kong.Data) error {
+			d.Unsnooze(args...)
+			return nil
+		}))
+	},
+}
+
+var newEpicsCmd = &cobra.Command{
+	Use:   "new",
+	Short: "Create new epics",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		editor, err := kong.NewEditor(ctx, kong.SectionInitiatives|kong.SectionSprints)
+		if err != nil {
+			exit(err)
+		}
+		editor.SetInput(inputFlag)
+		must(editor.SetFields(fieldFlag))
+		if issuesFlag {
+			must(editor.OpenEpicWithIssuesEditor(ctx, openFlag))
+			return
+		}
+		must(editor.OpenEpicEditor(ctx, openFlag))
+	},
+}
+
+var scaffoldEpicsCmd = &cobra.Command{
+	Use:   "scaffold KEY",
+	Short: "Create the child issues of an epic from a template",
+	Args:  cobra.ExactArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		data, err := kong.ReadData()
+		if err != nil || len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeKeys(data.Epics), cobra.ShellCompDirectiveNoFileComp
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		sections := kong.SectionIssues
+		if sprintFlag != "" {
+			sections |= kong.SectionSprints
+		}
+		data, err := kong.LoadDataBlocking(ctx, sections)
+		if err != nil {
+			exit(err)
+		}
+		jira, err := kong.NewJira()
+		if err != nil {
+			exit(err)
+		}
+		if _, err := jira.ScaffoldEpic(ctx, data, args[0], templateFlag, sprintFlag); err != nil {
+			exit(err)
+		}
+	},
+}
+
+var initiativesCmd = &cobra.Command{
+	Use:   "initiatives",
+	Short: "List Initiatives",
+	Run: func(cmd *cobra.Command, args []string) {
+		if projectFlag != "" {
+			jira, err := kong.NewJira()
+			if err != nil {
+				exit(err)
+			}
+			initiatives, err := jira.ListInitiatives(cmd.Context(), projectFlag)
+			if err != nil {
+				exit(err)
+			}
+			initiatives.Print(cmd.OutOrStderr())
+			return
+		}
+
+		data, err := kong.LoadData()
+		if err != nil {
+			exit(err)
+		}
+		initiatives, err := data.GetInitiatives(cmd.Context())
+		if err != nil {
+			exit(err)
+		}
+		initiatives.Print(cmd.OutOrStderr())
+	},
+}
+
+var sprintsCmd = &cobra.Command{
+	Use:   "sprints",
+	Short: "List and create sprints",
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := kong.LoadConfig()
+		if err != nil {
+			exit(err)
+		}
+
+		// request sprints if an alternative project is provided
+		if projectFlag != "" {
+			jira, err := kong.NewJira()
+			if err != nil {
+				exit(err)
+			}
+			boardID, err := jira.GetBoardID(projectFlag)
+			if err != nil {
+				exit(err)
+			}
+			sprints, err := jira.ListSprintsForBoard(boardID)
+			if err != nil {
+				exit(err)
+			}
+			sprints.Print(config.Calendar())
+			return
+		}
+
+		data, err := kong.LoadData()
+		if err != nil {
+			exit(err)
+		}
+		sprints, err := data.GetSprints(cmd.Context())
+		if err != nil {
+			exit(err)
+		}
+		sprints.Print(config.Calendar())
+	},
+}
+
+var newSprintCmd = &cobra.Command{
+	Use:                   "new [name] [mm/dd]",
+	Short:                 "Create a new sprint",
+	Args:                  cobra.ExactArgs(2),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		date := strings.Split(args[1], "/")
+		if len(date) != 2 {
+			exitPrompt("Error: requires month and day")
+		}
+
+		month, err := strconv.Atoi(date[0])
+		if err != nil {
+			exitPrompt("Error: month has to be numeric")
+		}
+		day, err := strconv.Atoi(date[1])
+		if err != nil {
+			exitPrompt("Error: day has to be numeric")
+		}
+
+		data, err := kong.LoadData()
+		if err != nil {
+			exit(err)
+		}
+		jira, err := kong.NewJira()
+		if err != nil {
+			exit(err)
+		}
+		name = kong.NewVariables(data, jira).Expand(name)
+		must(jira.CreateSprint(name, month, day, data.BoardID))
+	},
+}
+
+var sprintCmd = &cobra.Command{
+	Use:   "sprint [words...]",
+	Short: "List issues in current sprint, optionally narrowed down by words in the summary",
+	Args:  cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := kong.LoadData()
+		if err != nil {
+			exit(err)
+		}
+		issues, err := data.GetSprintIssues(cmd.Context())
+		if err != nil {
+			exit(err)
+		}
+		issues = filterIssues(withoutSnoozed(data, issues)).Search(args)
+
+		// the summary and outputs only count the listed issues
+		if !allFlag {
+			issues = issues.WithoutDone()
+		}
+		printPinned(cmd.OutOrStdout(), data)
+		printIssues(cmd, cmd.OutOrStdout(), issues, data.Sprints, func(w io.Writer) {
+			if byEpicFlag {
+				issues.PrintSprintByEpic(w, data.Epics, allFlag)
+				return
+			}
+			issues.PrintSprint(allFlag)
+		})
+	},
+}
+
+var burndownSprintCmd = &cobra.Command{
+	Use:   "burndown",
+	Short: "Chart the remaining story points per day of the active sprint",
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := kong.LoadConfig()
+		if err != nil {
+			exit(err)
+		}
+		location, err := config.Location()
+		if err != nil {
+			exit(err)
+		}
+		data, err := kong.ReadData()
+		if err != nil {
+			exit(err)
+		}
+		must(data.CheckBoard())
+		sprint, err := data.Sprints.ActiveSprint()
+		if err != nil {
+			exit(err)
+		}
+		kong.PrintBurndown(cmd.OutOrStdout(), sprint, data.SprintSnapshots(sprint), location)
+	},
+}
+
+var editSprintCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Update sprint board issue progress",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		editor, err := kong.NewEditor(ctx, kong.SectionSprintIssues)
+		if err != nil {
+			exit(err)
+		}
+		editor.SetInput(inputFlag)
+		must(editor.OpenSprintEditor(ctx, allFlag))
+	},
+}
+
+var rolloverSprintCmd = &cobra.Command{
+	Use:   "rollover",
+	Short: "Move incomplete issues into the next sprint or backlog",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		editor, err := kong.NewEditor(ctx, kong.SectionSprintIssues|kong.SectionSprints)
+		if err != nil {
+			exit(err)
+		}
+		editor.SetInput(inputFlag)
+		must(editor.OpenRolloverEditor(ctx, startFlag))
+	},
+}
+
+var standupCmd = &cobra.Command{
+	Use:   "standup [name]",
+	Short: "Create a template-based Slack standup message",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		editor, err := kong.NewEditor(ctx, kong.SectionSprintIssues|kong.SectionEpics)
+		if err != nil {
+			exit(err)
+		}
+		editor.SetInput(inputFlag)
+		must(editor.OpenStandupEditor(ctx, args[0]))
+	},
+}
+
+var standupHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Review or copy previously generated standup messages",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		since := time.Now().AddDate(0, 0, -daysFlag)
+		standups, err := kong.ListStandups(since)
+		if err != nil {
+			exit(err)
+		}
+		if copyFlag == 0 {
+			kong.PrintStandups(cmd.OutOrStdout(), standups)
+			return
+		}
+		if copyFlag < 0 || copyFlag > len(standups) {
+			exitPrompt("Error: standup does not exist")
+		}
+		config, err := kong.LoadConfig()
+		if err != nil {
+			exit(err)
+		}
+		clipboard := kong.NewClipboard(config.CopyCommand)
+		must(standups[copyFlag-1].Copy(cmd.Context(), clipboard))
+	},
+}
+
+var weekCmd = &cobra.Command{
+	Use:   "week",
+	Short: "Generate a summary report of the past 7 days",
+	Run: func(cmd *cobra.Command, args []string) {
+		jira, err := kong.NewJira()
+		if err != nil {
+			exit(err)
+		}
+		must(jira.PrintWeek(cmd.Context()))
+	},
+}
+
+var teamCmd = &cobra.Command{
+	Use:   "team",
+	Short: "List the sprint workload of each team member",
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := kong.LoadConfig()
+		if err != nil {
+			exit(err)
+		}
+		jira, err := kong.NewJira()
+		if err != nil {
+			exit(err)
+		}
+		team, err := jira.ListTeam(cmd.Context())
+		if err != nil {
+			exit(err)
+		}
+		team.Print(cmd.OutOrStdout(), config.TeamCapacity)
+	},
+}
+
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "List configured templates",
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := kong.LoadConfig()
+		if err != nil {
+			exit(err)
+		}
+		for _, name := range config.TemplateNames() {
+			fmt.Fprintln(cmd.OutOrStdout(), name)
+		}
+	},
+}
+
+var testTemplateCmd = &cobra.Command{
+	Use:   "test [name]",
+	Short: "Render a template against cached data",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := kong.LoadConfig()
+		if err != nil {
+			exit(err)
+		}
+		data, err := kong.LoadData()
+		if err != nil {
+			exit(err)
+		}
+		text, err := kong.TestTemplate(config, data, args[0])
+		if err != nil {
+			exit(err)
+		}
+		fmt.Fprint(cmd.OutOrStdout(), text)
+	},
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Print a compact sprint summary for status bars",
+	Run: func(cmd *cobra.Command, args []string) {
+		// only read from cache to respond quickly
+		data, err := kong.ReadData()
+		if err != nil {
+			exit(err)
+		}
+		config, err := kong.LoadConfig()
+		if err != nil && err != kong.ErrConfigMissing {
+			exit(err)
+		}
+		status := kong.NewStatusLine(data, config.Calendar(), time.Now())
+		line, err := status.Render(formatFlag)
+		if err != nil {
+			exit(err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), line)
+	},
+}
+
+var promptCmd = &cobra.Command{
+	Use:   "prompt",
+	Short: "Print the issue of the current git branch for shell prompts",
+	Run: func(cmd *cobra.Command, args []string) {
+		// only read from cache to respond quickly
+		data, err := kong.ReadData()
+		if err != nil && err != kong.ErrDataMissing {
+			exit(err)
+		}
+		issue, ok := kong.CurrentIssue(cmd.Context(), data)
+		if !ok {
+			return
+		}
+		if issue.Status.Name == "" {
+			fmt.Fprintln(cmd.OutOrStdout(), issue.Key)
+			return
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s %s\n", issue.Key, issue.Status.Name)
+	},
+}
+
+var activityCmd = &cobra.Command{
+	Use:   "activity",
+	Short: "Show recent events on issues recorded by the daemon",
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := kong.ReadData()
+		if err != nil {
+			exit(err)
+		}
+		data.Activity.Print(cmd.OutOrStdout(), projectFlag)
+	},
+}
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Review the changes kong made to Jira",
+	Run: func(cmd *cobra.Command, args []string) {
+		audit, err := kong.ReadAudit(time.Now().AddDate(0, 0, -daysFlag))
+		if err != nil {
+			exit(err)
+		}
+		audit.Print(cmd.OutOrStdout())
+	},
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List previously invoked commands, * marks commands which changed Jira",
+	Run: func(cmd *cobra.Command, args []string) {
+		history, err := kong.ReadHistory(time.Now().AddDate(0, 0, -daysFlag))
+		if err != nil {
+			exit(err)
+		}
+		history.Print(cmd.OutOrStdout())
+	},
+}
+
+var streakCmd = &cobra.Command{
+	Use:   "streak",
+	Short: "List the issues completed per day and the current streak",
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := kong.LoadConfig()
+		if err != nil {
+			exit(err)
+		}
+		location, err := config.Location()
+		if err != nil {
+			exit(err)
+		}
+		completions, err := kong.ReadCompletions(time.Time{})
+		if err != nil {
+			exit(err)
+		}
+		now := time.Now().In(location)
+		must(kong.PrintStreak(cmd.OutOrStdout(), completions, now, daysFlag, config.Calendar()))
+	},
+}
+
+var rerunCmd = &cobra.Command{
+	Use:     "rerun",
+	Aliases: []string{"!!"},
+	Short:   "Re-run the last command which changed Jira",
+	// the replayed command changes Jira and does not inherit --read-only
+	PreRun: checkReadOnly,
+	Run: func(cmd *cobra.Command, args []string) {
+		history, err := kong.ReadHistory(time.Time{})
+		if err != nil {
+			exit(err)
+		}
+		entry, err := history.LastMutating()
+		if err != nil {
+			exit(err)
+		}
+		executable, err := os.Executable()
+		if err != nil {
+			exit(err)
+		}
+		fmt.Fprintln(cmd.ErrOrStderr(), entry.CommandLine())
+		rerun := exec.CommandContext(cmd.Context(), executable, entry.Args...)
+		rerun.Stdin = os.Stdin
+		rerun.Stdout = cmd.OutOrStdout()
+		rerun.Stderr = cmd.ErrOrStderr()
+		if err := rerun.Run(); err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				os.Exit(exitErr.ExitCode())
+			}
+			exit(err)
+		}
+	},
+}
+
+var launcherCmd = &cobra.Command{
+	Use:   "launcher [open|start KEY]",
+	Short: "List sprint issues for Alfred or rofi and perform the selected action",
+	Args:  cobra.RangeArgs(0, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := kong.LoadData()
+		if err != nil {
+			exit(err)
+		}
+		action, key, ok := kong.RofiSelection()
+		if len(args) == 2 {
+			action, key, ok = args[0], args[1], true
+		}
+		if ok {
+			if action == kong.LauncherStart {
+				checkReadOnly(cmd, args)
+			}
+			jira, err := kong.NewJira()
+			if err != nil {
+				exit(err)
+			}
+			must(jira.RunLauncherAction(cmd.Context(), data, action, key))
+			return
+		}
+		issues, err := data.GetSprintIssues(cmd.Context())
+		if err != nil {
+			exit(err)
+		}
+		must(kong.PrintLauncher(cmd.OutOrStdout(), launcherFlag, issues))
+	},
+}
+
+var scanCmd = &cobra.Command{
+	Use:   "scan",
+	Short: "Print the status of all issue keys found in stdin or the clipboard",
+	Run: func(cmd *cobra.Command, args []string) {
+		var (
+			b   []byte
+			err error
+		)
+		if clipboardFlag {
+			b, err = kong.ReadClipboard(cmd.Context())
+		} else {
+			b, err = io.ReadAll(cmd.InOrStdin())
+		}
+		if err != nil {
+			exit(err)
+		}
+		data, err := kong.ReadData()
+		if err != nil {
+			exit(err)
+		}
+		issues, unknown := data.ScanIssueKeys(string(b))
+		kong.PrintScan(cmd.OutOrStdout(), issues, unknown)
+	},
+}
+
+var useCmd = &cobra.Command{
+	Use:   "use [PROJECT]",
+	Short: "Switch the default project or list the synced projects",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := kong.LoadConfig()
+		if err != nil {
+			exit(err)
+		}
+		data, err := kong.ReadData()
+		if err != nil && err != kong.ErrDataMissing {
+			exit(err)
+		}
+		if len(args) == 0 {
+			data.PrintProjects(cmd.OutOrStdout(), config.SyncedProjects())
+			return
+		}
+
+		project := args[0]
+		config.UseProject(project)
+		must(config.Write())
+		if err == kong.ErrDataMissing {
+			fmt.Printf("Switched to %s\n", project)
+			return
+		}
+		synced := data.Use(project)
+		must(data.WriteFile())
+		if !synced {
+			fmt.Printf("Switched to %s, issues are available after the next sync\n", project)
+			return
+		}
+		fmt.Printf("Switched to %s\n", project)
+	},
+}
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "List the configuration profiles, * marks the profile in use",
+	Run: func(cmd *cobra.Command, args []string) {
+		profiles, err := kong.ListProfiles()
+		if err != nil {
+			exit(err)
+		}
+		kong.PrintProfiles(cmd.OutOrStdout(), profiles, kong.CurrentProfile())
+	},
+}
+
+var useProfileCmd = &cobra.Command{
+	Use:   "use NAME",
+	Short: "Switch the configuration profile used without --profile",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		must(kong.UseProfile(args[0]))
+		fmt.Printf("Switched to profile %s, restart the daemon to sync it\n", args[0])
+	},
+}
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Export statistics recorded by the daemon",
+	Run: func(cmd *cobra.Command, args []string) {
+		must(cmd.Help())
+	},
+}
+
+var cfdStatsCmd = &cobra.Command{
+	Use:   "cfd",
+	Short: "Print the issue counts per status and day for cumulative flow diagrams",
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := kong.ReadData()
+		if err != nil {
+			exit(err)
+		}
+		must(data.PrintFlow(cmd.OutOrStdout(), csvFlag))
+	},
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export cached issues for other tools",
+	Run: func(cmd *cobra.Command, args []string) {
+		must(cmd.Help())
+	},
+}
+
+var exportVimCmd = &cobra.Command{
+	Use:   "vim",
+	Short: "Print issue keys and summaries as Vim completion items",
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := kong.ReadData()
+		if err != nil {
+			exit(err)
+		}
+		must(data.ExportVim(cmd.OutOrStdout(), pluginFlag))
+	},
+}
+
+var boardCmd = &cobra.Command{
+	Use:   "board",
+	Short: "Perform actions on the sprint board",
+	Run: func(cmd *cobra.Command, args []string) {
+		must(cmd.Help())
+	},
+}
+
+var exportBoardCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Print the sprint issues as kanban board by status",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !markdownFlag {
+			exitPrompt("Error: requires --md, the board is exported as Markdown")
+		}
+		config, err := kong.LoadConfig()
+		if err != nil {
+			exit(err)
+		}
+		data, err := kong.LoadData()
 		if err != nil {
 			exit(err)
 		}
-		epics.Print(cmd.OutOrStderr())
+		must(data.ExportBoard(cmd.OutOrStdout(), config))
 	},
 }
 
-var newEpicsCmd = &cobra.Command{
-	Use:   "new",
-	Short: "Create new epics",
+var exportCSVCmd = &cobra.Command{
+	Use:   "csv",
+	Short: "Print all cached issues and epics as comma-separated values",
+	Args:  cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := cmd.Context()
-		editor, err := kong.NewEditor(ctx)
+		data, err := kong.ReadData()
 		if err != nil {
 			exit(err)
 		}
-		must(editor.OpenEpicEditor(ctx))
+		must(data.ExportCSV(cmd.OutOrStdout()))
 	},
 }
 
-var initiativesCmd = &cobra.Command{
-	Use:   "initiatives",
-	Short: "List Initiatives",
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import issues from other trackers",
 	Run: func(cmd *cobra.Command, args []string) {
-		if projectFlag != "" {
-			jira, err := kong.NewJira()
-			if err != nil {
-				exit(err)
-			}
-			initiatives, err := jira.ListInitiatives(cmd.Context(), projectFlag)
-			if err != nil {
-				exit(err)
-			}
-			initiatives.Print(cmd.OutOrStderr())
-			return
-		}
+		must(cmd.Help())
+	},
+}
 
+var importGitHubCmd = &cobra.Command{
+	Use:   "github OWNER/REPO",
+	Short: "Create Jira issues for the open issues of a GitHub repository",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
 		data, err := kong.LoadData()
 		if err != nil {
 			exit(err)
 		}
-		initiatives, err := data.GetInitiatives(cmd.Context())
+		jira, err := kong.NewJira()
 		if err != nil {
 			exit(err)
 		}
-		initiatives.Print(cmd.OutOrStderr())
+		must(jira.ImportGitHubIssues(cmd.Context(), &data, args[0], labelFlag))
 	},
 }
 
-var sprintsCmd = &cobra.Command{
-	Use:   "sprints",
-	Short: "List and create sprints",
+var branchCmd = &cobra.Command{
+	Use:   "branch",
+	Short: "Create a new branch named after the most recently created issue key",
 	Run: func(cmd *cobra.Command, args []string) {
-		// request sprints if an alternative project is provided
-		if projectFlag != "" {
-			jira, err := kong.NewJira()
-			if err != nil {
-				exit(err)
-			}
-			boardID, err := jira.GetBoardID(projectFlag)
-			if err != nil {
-				exit(err)
-			}
-			sprints, err := jira.ListSprintsForBoard(boardID)
-			if err != nil {
-				exit(err)
-			}
-			sprints.Print()
-			return
-		}
-
+		ctx := cmd.Context()
 		data, err := kong.LoadData()
 		if err != nil {
 			exit(err)
 		}
-		sprints, err := data.GetSprints(cmd.Context())
+		if data.LastIssueCreated == "" {
+			exit(errors.New("no recently created issue"))
+		}
+		gitCmd := exec.CommandContext(ctx, "git", "checkout", "-b", data.LastIssueCreated)
+		stdoutStderr, err := gitCmd.CombinedOutput()
 		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", string(stdoutStderr))
 			exit(err)
 		}
-		sprints.Print()
+		if len(stdoutStderr) > 0 {
+			exit(errors.New(string(stdoutStderr)))
+		}
 	},
 }
 
-var newSprintCmd = &cobra.Command{
-	Use:                   "new [name] [mm/dd]",
-	Short:                 "Create a new sprint",
-	Args:                  cobra.ExactArgs(2),
-	DisableFlagsInUseLine: true,
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Synchronize local data with Jira right away instead of waiting for the daemon",
+	Args:  cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		name := args[0]
-		date := strings.Split(args[1], "/")
-		if len(date) != 2 {
-			exitPrompt("Error: requires month and day")
-		}
-
-		month, err := strconv.Atoi(date[0])
-		if err != nil {
-			exitPrompt("Error: month has to be numeric")
-		}
-		day, err := strconv.Atoi(date[1])
+		data, err := kong.LoadDataBlocking(cmd.Context(), kong.SectionAll)
 		if err != nil {
-			exitPrompt("Error: day has to be numeric")
+			exit(err)
 		}
+		must(data.WriteFile())
+		must(data.PrintLoadTimings(cmd.OutOrStdout()))
+	},
+}
 
-		data, err := kong.LoadData()
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the local data cache",
+	Run: func(cmd *cobra.Command, args []string) {
+		must(cmd.Help())
+	},
+}
+
+var resetCacheCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Remove the cached data, the daemon fetches it again on the next refresh",
+	Run: func(cmd *cobra.Command, args []string) {
+		must(kong.ResetCache())
+		fmt.Println("Cache reset")
+	},
+}
+
+var syncFileCmd = &cobra.Command{
+	Use:   "file [path]",
+	Short: "Synchronize a Markdown or org-mode task file with Jira issues",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		taskFile, err := kong.ReadTaskFile(args[0])
 		if err != nil {
 			exit(err)
 		}
@@ -239,75 +1439,145 @@ var newSprintCmd = &cobra.Command{
 		if err != nil {
 			exit(err)
 		}
-		must(jira.CreateSprint(name, month, day, data.BoardID))
+		must(taskFile.Sync(cmd.Context(), jira))
 	},
 }
 
-var sprintCmd = &cobra.Command{
-	Use:   "sprint",
-	Short: "List issues in current sprint",
+var triageCmd = &cobra.Command{
+	Use:   "triage",
+	Short: "Triage new unassigned bugs one at a time",
 	Run: func(cmd *cobra.Command, args []string) {
-		data, err := kong.LoadData()
+		ctx := cmd.Context()
+		triage, err := kong.NewTriage(ctx)
 		if err != nil {
 			exit(err)
 		}
-		issues, err := data.GetSprintIssues(cmd.Context())
+		triage.SetOldestFirst(oldestFlag)
+		must(triage.Run(ctx, os.Stdin, cmd.OutOrStdout()))
+	},
+}
+
+var releaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Perform actions on a release",
+	Run: func(cmd *cobra.Command, args []string) {
+		must(cmd.Help())
+	},
+}
+
+var closeReleaseCmd = &cobra.Command{
+	Use:   "close",
+	Short: "Transition all issues referenced in a git revision range to the released status",
+	Run: func(cmd *cobra.Command, args []string) {
+		if fromGitFlag == "" {
+			exitPrompt("Error: requires --from-git revision range, e.g. v1.2.0..v1.3.0")
+		}
+		version := versionFlag
+		if version == "" {
+			// default to the end of the revision range
+			revisions := strings.Split(fromGitFlag, "..")
+			version = revisions[len(revisions)-1]
+		}
+		jira, err := kong.NewJira()
 		if err != nil {
 			exit(err)
 		}
-		issues.PrintSprint(allFlag)
+		must(jira.CloseRelease(cmd.Context(), fromGitFlag, version))
 	},
 }
 
-var editSprintCmd = &cobra.Command{
-	Use:   "edit",
-	Short: "Update sprint board issue progress",
+var prCmd = &cobra.Command{
+	Use:   "pr",
+	Short: "Perform actions on pull requests",
+	Run: func(cmd *cobra.Command, args []string) {
+		must(cmd.Help())
+	},
+}
+
+var describePRCmd = &cobra.Command{
+	Use:   "describe [key]",
+	Short: "Generate a pull request description from an issue",
 	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := cmd.Context()
-		editor, err := kong.NewEditor(ctx)
+		var key string
+		if len(args) > 0 {
+			key = args[0]
+		}
+		jira, err := kong.NewJira()
 		if err != nil {
 			exit(err)
 		}
-		must(editor.OpenSprintEditor(ctx, allFlag))
+		must(jira.DescribePullRequest(cmd.Context(), key, createFlag))
 	},
 }
 
-var standupCmd = &cobra.Command{
-	Use:   "standup",
-	Short: "Create a template-based Slack standup message",
+var reviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "Perform actions on reviews",
+	Run: func(cmd *cobra.Command, args []string) {
+		must(cmd.Help())
+	},
+}
+
+var assignReviewCmd = &cobra.Command{
+	Use:   "assign KEY",
+	Short: "Assign the next reviewer of the rotation to an issue",
 	Args:  cobra.ExactArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		data, err := kong.ReadData()
+		if err != nil || len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeKeys(data.Issues), cobra.ShellCompDirectiveNoFileComp
+	},
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := cmd.Context()
-		editor, err := kong.NewEditor(ctx)
+		data, err := kong.LoadData()
 		if err != nil {
 			exit(err)
 		}
-		must(editor.OpenStandupEditor(ctx, args[0]))
+		jira, err := kong.NewJira()
+		if err != nil {
+			exit(err)
+		}
+		must(jira.AssignReviewer(cmd.Context(), &data, args[0]))
 	},
 }
 
-var branchCmd = &cobra.Command{
-	Use:   "branch",
-	Short: "Create a new branch named after the most recently created issue key",
+var openCmd = &cobra.Command{
+	Use:   "open [key]",
+	Short: "Open an issue in the browser, by default the issue of the current branch",
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := cmd.Context()
-		data, err := kong.LoadData()
+		var key string
+		if len(args) > 0 {
+			key = args[0]
+		}
+		jira, err := kong.NewJira()
 		if err != nil {
 			exit(err)
 		}
-		if data.LastIssueCreated == "" {
-			exit(errors.New("no recently created issue"))
+		must(jira.OpenIssue(cmd.Context(), key))
+	},
+}
+
+var depsCmd = &cobra.Command{
+	Use:   "deps [key]",
+	Short: "Show blocking dependencies of an issue",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		jira, err := kong.NewJira()
+		if err != nil {
+			exit(err)
 		}
-		gitCmd := exec.CommandContext(ctx, "git", "checkout", "-b", data.LastIssueCreated)
-		stdoutStderr, err := gitCmd.CombinedOutput()
+		deps, err := jira.ListDependencies(cmd.Context(), args[0])
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "%s\n", string(stdoutStderr))
 			exit(err)
 		}
-		if len(stdoutStderr) > 0 {
-			exit(errors.New(string(stdoutStderr)))
+		if dotFlag {
+			deps.PrintDOT(cmd.OutOrStdout())
+			return
 		}
+		deps.PrintTree(cmd.OutOrStdout())
 	},
 }
 
@@ -320,12 +1590,22 @@ var configureCmd = &cobra.Command{
 			exit(err)
 		}
 
-		fmt.Println("Configure Jira using basic authentication.")
+		fmt.Println("Configure Jira using basic authentication, a Jira Cloud API token or a personal access token.")
 
 		r := kong.NewConfigReader()
 		must(r.ReadString("Endpoint", &config.Endpoint))
-		must(r.ReadString("Username", &config.Username))
-		must(r.ReadString("Password", &config.Password))
+		must(r.ReadString("Auth Type (basic, token or pat)", &config.AuthType))
+		switch config.AuthType {
+		case kong.AuthToken:
+			must(r.ReadString("Email", &config.Username))
+			must(r.ReadString("API Token", &config.Token))
+		case kong.AuthPAT:
+			must(r.ReadString("Username", &config.Username))
+			must(r.ReadString("Personal Access Token", &config.Token))
+		default:
+			must(r.ReadString("Username", &config.Username))
+			must(r.ReadString("Password", &config.Password))
+		}
 
 		must(r.ReadString("Project", &config.Project))
 		must(r.ReadString("Issue Type", &config.IssueType))
@@ -334,12 +1614,14 @@ var configureCmd = &cobra.Command{
 
 		must(r.ReadString("Sprint Keyword", &config.SprintKeyword))
 		must(r.ReadInt("Sprint Duration (days)", &config.SprintDuration))
+		must(r.ReadString("Board Timezone (e.g. America/Los_Angeles)", &config.Timezone))
 
 		must(r.ReadString("Epic Field", &config.CustomFields.Epics))
 		must(r.ReadString("Sprint Field", &config.CustomFields.Sprints))
 		must(r.ReadString("Story Points", &config.CustomFields.StoryPoints))
 
-		must(r.ReadString("Copy Command", &config.CopyCommand))
+		must(r.ReadString("Copy Command (empty to detect)", &config.CopyCommand))
+		must(config.ValidateTemplates())
 		must(config.Write())
 	},
 }
@@ -350,13 +1632,83 @@ func Execute() {
 	// root commands
 	cmd.AddCommand(configureCmd)
 	cmd.AddCommand(daemonCmd)
+	daemonCmd.AddCommand(startDaemonCmd)
+	daemonCmd.AddCommand(stopDaemonCmd)
+	daemonCmd.AddCommand(restartDaemonCmd)
+	daemonCmd.AddCommand(statusDaemonCmd)
 	cmd.AddCommand(initiativesCmd)
 	cmd.AddCommand(standupCmd)
+	standupCmd.AddCommand(standupHistoryCmd)
+	cmd.AddCommand(weekCmd)
+	cmd.AddCommand(teamCmd)
+	cmd.AddCommand(statusCmd)
+	cmd.AddCommand(promptCmd)
+	cmd.AddCommand(activityCmd)
+	cmd.AddCommand(auditCmd)
+	cmd.AddCommand(historyCmd)
+	cmd.AddCommand(rerunCmd)
+	cmd.AddCommand(streakCmd)
+	cmd.AddCommand(worklogCmd)
+	cmd.AddCommand(pinCmd)
+	cmd.AddCommand(unpinCmd)
+	cmd.AddCommand(snoozeCmd)
+	cmd.AddCommand(unsnoozeCmd)
+	cmd.AddCommand(exportCmd)
+	cmd.AddCommand(launcherCmd)
+	cmd.AddCommand(scanCmd)
+	cmd.AddCommand(statsCmd)
+	cmd.AddCommand(useCmd)
+	cmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(useProfileCmd)
+	statsCmd.AddCommand(cfdStatsCmd)
+	exportCmd.AddCommand(exportVimCmd)
+	exportCmd.AddCommand(exportCSVCmd)
+	cmd.AddCommand(boardCmd)
+	boardCmd.AddCommand(exportBoardCmd)
+	cmd.AddCommand(importCmd)
+	importCmd.AddCommand(importGitHubCmd)
+	cmd.AddCommand(viewCmd)
+	cmd.AddCommand(searchCmd)
+
+	// service command and service sub-commands
+	cmd.AddCommand(serviceCmd)
+	serviceCmd.AddCommand(installServiceCmd)
+	serviceCmd.AddCommand(uninstallServiceCmd)
+	serviceCmd.AddCommand(startServiceCmd)
+	serviceCmd.AddCommand(stopServiceCmd)
+	serviceCmd.AddCommand(statusServiceCmd)
+
+	// templates command and templates sub-commands
+	cmd.AddCommand(templatesCmd)
+	templatesCmd.AddCommand(testTemplateCmd)
 	cmd.AddCommand(branchCmd)
+	cmd.AddCommand(openCmd)
+	cmd.AddCommand(triageCmd)
+	cmd.AddCommand(depsCmd)
+
+	// pr command and pr sub-commands
+	cmd.AddCommand(prCmd)
+	prCmd.AddCommand(describePRCmd)
+	cmd.AddCommand(reviewCmd)
+	reviewCmd.AddCommand(assignReviewCmd)
+
+	// release command and release sub-commands
+	cmd.AddCommand(releaseCmd)
+	releaseCmd.AddCommand(closeReleaseCmd)
+
+	// sync command and sync sub-commands
+	cmd.AddCommand(syncCmd)
+	syncCmd.AddCommand(syncFileCmd)
+
+	// cache command and cache sub-commands
+	cmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(resetCacheCmd)
 
 	// sprint command and sprint sub-commands
 	cmd.AddCommand(sprintCmd)
 	sprintCmd.AddCommand(editSprintCmd)
+	sprintCmd.AddCommand(rolloverSprintCmd)
+	sprintCmd.AddCommand(burndownSprintCmd)
 
 	// issues command and issues sub-commands
 	cmd.AddCommand(issuesCmd)
@@ -365,10 +1717,20 @@ func Execute() {
 	// issue command and issue sub-commands
 	cmd.AddCommand(issueCmd)
 	issueCmd.AddCommand(editIssueCmd)
+	issueCmd.AddCommand(viewIssueCmd)
+	issueCmd.AddCommand(commentIssueCmd)
+	issueCmd.AddCommand(logIssueCmd)
+	issueCmd.AddCommand(remainingIssueCmd)
+	issueCmd.AddCommand(epicIssueCmd)
+	issueCmd.AddCommand(assignIssueCmd)
 
 	// epics and epics sub-commands
 	cmd.AddCommand(epicsCmd)
 	epicsCmd.AddCommand(newEpicsCmd)
+	epicsCmd.AddCommand(archiveEpicsCmd)
+	epicsCmd.AddCommand(searchEpicsCmd)
+	epicsCmd.AddCommand(unarchiveEpicsCmd)
+	epicsCmd.AddCommand(scaffoldEpicsCmd)
 
 	// sprints and sprints sub-commands
 	cmd.AddCommand(sprintsCmd)
@@ -376,6 +1738,31 @@ func Execute() {
 
 	// configure flags
 	sprintCmd.Flags().BoolVarP(&allFlag, "all", "a", false, "Include issues that are done")
+	sprintCmd.Flags().BoolVar(&byEpicFlag, "by-epic", false, "Group issues by epic with story point subtotals")
+	newIssuesCmd.Flags().BoolVarP(&openFlag, "open", "o", false, "Open created issues in the browser")
+	epicIssueCmd.Flags().BoolVar(&noneFlag, "none", false, "Remove the issue from its epic")
+	commentIssueCmd.Flags().StringVarP(&messageFlag, "message", "m", "", "Comment body instead of opening the editor")
+	viewIssueCmd.Flags().BoolVar(&commentsFlag, "comments", false, "List the comments of the issue")
+	worklogCmd.Flags().BoolVar(&todayFlag, "today", false, "Only list the time logged today")
+	searchCmd.Flags().StringVar(&saveFlag, "save", "", "Save the query under the given name")
+	newIssuesCmd.Flags().StringVarP(&projectFlag, "project", "p", "", "Create the issues in another project of the sprint board")
+	newIssuesCmd.Flags().StringVar(&estimateFlag, "estimate", "", "Original estimate of created issues, e.g. 2d")
+	newEpicsCmd.Flags().BoolVarP(&openFlag, "open", "o", false, "Open created epics in the browser")
+	newEpicsCmd.Flags().BoolVar(&issuesFlag, "with-issues", false, "Create issues indented below each epic")
+	scaffoldEpicsCmd.Flags().StringVarP(&templateFlag, "template", "t", "feature", "Epic template to create the issues from")
+	scaffoldEpicsCmd.Flags().StringVar(&sprintFlag, "sprint", "", "Add the issues to a sprint, active, next or a sprint ID")
+	for _, cmd := range []*cobra.Command{newIssuesCmd, newEpicsCmd} {
+		cmd.Flags().StringArrayVarP(&fieldFlag, "field", "F", nil, "Custom field value as name=value, e.g. team=Platform")
+	}
+	rolloverSprintCmd.Flags().BoolVarP(&startFlag, "start", "s", false, "Close the active sprint and start the next sprint")
+	standupHistoryCmd.Flags().IntVarP(&daysFlag, "days", "d", 7, "Number of days to look back")
+	standupHistoryCmd.Flags().IntVarP(&copyFlag, "copy", "c", 0, "Copy the standup with the given number")
+	statusCmd.Flags().StringVarP(&formatFlag, "format", "f", "", "Template for the status line")
+	epicsCmd.Flags().BoolVar(&archiveFlag, "archived", false, "Include archived epics")
+	depsCmd.Flags().BoolVar(&dotFlag, "dot", false, "Print dependency graph in Graphviz DOT format")
+	describePRCmd.Flags().BoolVarP(&createFlag, "create", "c", false, "Create the pull request with gh")
+	closeReleaseCmd.Flags().StringVar(&fromGitFlag, "from-git", "", "Git revision range to scan for issue keys")
+	closeReleaseCmd.Flags().StringVar(&versionFlag, "version", "", "Fix version, defaults to the end of the revision range")
 
 	for _, cmd := range []*cobra.Command{
 		issuesCmd,
@@ -385,12 +1772,253 @@ func Execute() {
 	} {
 		cmd.Flags().StringVarP(&projectFlag, "project", "p", "", "Reference alternative project")
 	}
+	for _, cmd := range []*cobra.Command{
+		issuesCmd,
+		epicsCmd,
+		sprintCmd,
+		searchCmd,
+	} {
+		cmd.Flags().StringVar(&filterFlag, "filter", "", `Filter issues by expression, e.g. 'points > 3 && status == "In Progress"'`)
+	}
+	for _, cmd := range []*cobra.Command{
+		issuesCmd,
+		epicsCmd,
+		sprintCmd,
+		viewCmd,
+		searchCmd,
+	} {
+		cmd.Flags().BoolVar(&countFlag, "count-only", false, "Only print the number of issues")
+	}
+	for _, cmd := range []*cobra.Command{
+		issuesCmd,
+		sprintCmd,
+	} {
+		cmd.Flags().BoolVar(&snoozedFlag, "snoozed", false, "Include snoozed issues")
+	}
+	for _, cmd := range []*cobra.Command{
+		issuesCmd,
+		epicsCmd,
+		sprintCmd,
+		viewCmd,
+		searchCmd,
+	} {
+		cmd.Flags().StringVar(&outputFlag, "output", kong.OutputText, "Output format, text, json or csv")
+		cmd.PreRun = func(cmd *cobra.Command, args []string) {
+			must(kong.ValidateOutput(outputFlag))
+		}
+	}
+	triageCmd.Flags().BoolVar(&oldestFlag, "oldest-first", false, "Triage the oldest bugs first")
+	cfdStatsCmd.Flags().BoolVar(&csvFlag, "csv", false, "Print comma-separated values")
+	scanCmd.Flags().BoolVarP(&clipboardFlag, "clipboard", "c", false, "Read the text from the clipboard instead of stdin")
+	launcherCmd.Flags().StringVar(&launcherFlag, "format", kong.LauncherRofi, "Launcher output format, alfred or rofi")
+	exportVimCmd.Flags().BoolVar(&pluginFlag, "plugin", false, "Include a completion function for commit messages and notes")
+	exportBoardCmd.Flags().BoolVar(&markdownFlag, "md", false, "Print the board as Markdown with a section per status")
+	importGitHubCmd.Flags().StringArrayVar(&labelFlag, "label", nil, "Only import GitHub issues with the label, may be repeated")
+	auditCmd.Flags().IntVarP(&daysFlag, "days", "d", 7, "Number of days to look back")
+	historyCmd.Flags().IntVarP(&daysFlag, "days", "d", 7, "Number of days to look back")
+	streakCmd.Flags().IntVarP(&daysFlag, "days", "d", 14, "Number of days to look back")
+	activityCmd.Flags().StringVarP(&projectFlag, "project", "p", "", "Only show events of the given project")
+	searchEpicsCmd.Flags().StringVarP(&projectFlag, "project", "p", "", "Search the given project in Jira instead of the cache")
+
+	// the profile is applied before commands run to expand its aliases
+	cmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "Use the configuration and data of the named profile")
+	cmd.PersistentFlags().BoolVar(&asciiFlag, "ascii", false, "Replace emoji and other symbols in the output with ASCII")
+
+	// commands which change Jira are disabled in read-only mode
+	cmd.PersistentFlags().BoolVar(&readOnlyFlag, "read-only", false, "Disable all commands which change Jira")
+	for _, cmd := range []*cobra.Command{
+		editIssueCmd,
+		newIssuesCmd,
+		remainingIssueCmd,
+		epicIssueCmd,
+		assignIssueCmd,
+		commentIssueCmd,
+		logIssueCmd,
+		newEpicsCmd,
+		scaffoldEpicsCmd,
+		newSprintCmd,
+		editSprintCmd,
+		rolloverSprintCmd,
+		syncFileCmd,
+		triageCmd,
+		closeReleaseCmd,
+		assignReviewCmd,
+		importGitHubCmd,
+	} {
+		cmd.PreRun = checkReadOnly
+		mutatingCmds[cmd] = true
+	}
+
+	for _, cmd := range []*cobra.Command{
+		editIssueCmd,
+		newIssuesCmd,
+		commentIssueCmd,
+		newEpicsCmd,
+		editSprintCmd,
+		rolloverSprintCmd,
+		standupCmd,
+	} {
+		cmd.Flags().StringVar(&inputFlag, "input", "", "Read the editor content from a file or - for stdin")
+	}
 
+	if profile, ok := profileArg(os.Args[1:]); ok {
+		must(kong.SetProfile(profile))
+	}
+	if config, err := kong.LoadConfig(); asciiArg(os.Args[1:]) || err == nil && config.ASCII {
+		kong.SetASCII(true)
+		cmd.Short = kong.Plain(cmd.Short)
+	}
+	invokedArgs = expandAlias(os.Args[1:])
+	cmd.SetArgs(invokedArgs)
 	if err := cmd.Execute(); err != nil {
 		exit(err)
 	}
 }
 
+// recordHistory appends the invoked command to the history unless it is
+// one of the history commands itself.
+func recordHistory(c *cobra.Command, args []string) {
+	if c == historyCmd || c == rerunCmd || c.Hidden {
+		return
+	}
+	entry := kong.HistoryEntry{
+		Time:     time.Now(),
+		Args:     invokedArgs,
+		Mutating: mutatingCmds[c],
+	}
+	if err := kong.AppendHistory(entry); err != nil {
+		fmt.Fprintln(os.Stderr, "history:", err)
+	}
+}
+
+// profileArg returns the value of the --profile flag. It is parsed ahead of
+// cobra since the profile determines the configuration aliases are read from.
+func profileArg(args []string) (string, bool) {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if strings.HasPrefix(arg, "--profile=") {
+			return strings.TrimPrefix(arg, "--profile="), true
+		}
+		if arg == "--profile" && i+1 < len(args) {
+			return args[i+1], true
+		}
+	}
+	return "", false
+}
+
+// asciiArg reports whether the --ascii flag is set. It is parsed ahead of
+// cobra so that the help output is plain ASCII as well.
+func asciiArg(args []string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if arg == "--ascii" {
+			return true
+		}
+		if strings.HasPrefix(arg, "--ascii=") {
+			enabled, err := strconv.ParseBool(strings.TrimPrefix(arg, "--ascii="))
+			return err == nil && enabled
+		}
+	}
+	return false
+}
+
+// expandAlias expands a command alias in the first argument. Built-in
+// commands take precedence over aliases of the same name.
+func expandAlias(args []string) []string {
+	if len(args) == 0 {
+		return args
+	}
+	if found, _, err := cmd.Find(args); err == nil && found != cmd {
+		return args
+	}
+	config, err := kong.LoadConfig()
+	if err != nil {
+		return args
+	}
+	expanded, err := config.ExpandAlias(args)
+	if err != nil {
+		exit(err)
+	}
+	return expanded
+}
+
+// printPinned writes the pinned issues unless only the number of issues is
+// printed.
+func printPinned(w io.Writer, data kong.Data) {
+	if countFlag || outputFlag != kong.OutputText {
+		return
+	}
+	kong.PrintPinned(w, data.PinnedIssues())
+}
+
+// printList prints the issues followed by a summary line or only the number of
+// issues if the count flag is set.
+func printList(w io.Writer, issues kong.Issues, print func(io.Writer)) {
+	if countFlag {
+		fmt.Fprintln(w, len(issues))
+		return
+	}
+	print(w)
+	fmt.Fprintln(w, issues.Summary())
+}
+
+// printIssues prints the issues in the format of the output flag. JSON and
+// CSV are always written to stdout to be redirected.
+func printIssues(cmd *cobra.Command, w io.Writer, issues kong.Issues, sprints kong.Sprints, print func(io.Writer)) {
+	switch outputFlag {
+	case kong.OutputJSON:
+		must(issues.PrintJSON(cmd.OutOrStdout()))
+	case kong.OutputCSV:
+		must(issues.PrintCSV(cmd.OutOrStdout(), sprints))
+	default:
+		printList(w, issues, print)
+	}
+}
+
+// checkReadOnly exits if read-only mode is enabled by flag or configuration.
+func checkReadOnly(cmd *cobra.Command, args []string) {
+	if readOnlyFlag {
+		exit(kong.ErrReadOnly)
+	}
+	config, err := kong.LoadConfig()
+	if err == nil && config.ReadOnly {
+		exit(kong.ErrReadOnly)
+	}
+}
+
+// completeKeys returns the issue keys with their summary for shell completion.
+func completeKeys(issues kong.Issues) []string {
+	keys := make([]string, len(issues))
+	for i, issue := range issues {
+		keys[i] = issue.Key + "\t" + issue.Summary
+	}
+	return keys
+}
+
+// filterIssues returns the issues matching the filter flag.
+// withoutSnoozed removes snoozed issues unless they are revealed by flag.
+func withoutSnoozed(data kong.Data, issues kong.Issues) kong.Issues {
+	if snoozedFlag {
+		return issues
+	}
+	return data.WithoutSnoozed(issues, time.Now())
+}
+
+func filterIssues(issues kong.Issues) kong.Issues {
+	if filterFlag == "" {
+		return issues
+	}
+	filter, err := kong.ParseFilter(filterFlag)
+	if err != nil {
+		exit(err)
+	}
+	return issues.Filter(filter)
+}
+
 func must(err error) {
 	if err == nil {
 		return
@@ -2,7 +2,10 @@ package main
 
 import (
 	"bytes"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -46,12 +49,107 @@ func TestIssueCommand(t *testing.T) {
 	}
 
 	got := buf.String()
-	want := "KONG-1 - To Do - Add command to list issues\n"
+	want := "KONG-1 - To Do - Add command to list issues\n" +
+		"1 issue — 1 To Do\n"
 	if diff := cmp.Diff(got, want); diff != "" {
 		t.Errorf("diff: %s", diff)
 	}
 }
 
+func TestSprintCommandSummary(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+
+	data := kong.Data{
+		Timestamp: time.Now().Unix(),
+		SprintIssues: kong.Issues{
+			{Key: "KONG-1", Status: kong.Status{Name: "To Do"}},
+			{Key: "KONG-2", Status: kong.Status{Name: "Done", IsDone: true}},
+		},
+	}
+	if err := data.WriteFile(); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	sprintCmd.SetOut(&buf)
+	sprintCmd.Run(sprintCmd, nil)
+
+	// done issues are hidden without --all and not counted
+	if got, want := buf.String(), "1 issue — 1 To Do\n"; got != want {
+		t.Errorf("got %q, want: %q", got, want)
+	}
+}
+
+func TestStatusCommand(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+
+	data := kong.Data{
+		Timestamp: time.Now().Unix(),
+		SprintIssues: kong.Issues{
+			{Key: "KONG-1", Status: kong.Status{Name: "To Do"}},
+			{Key: "KONG-2", Status: kong.Status{Name: "In Progress"}},
+			{Key: "KONG-3", Status: kong.Status{Name: "Done", IsDone: true}},
+		},
+		Sprints: kong.Sprints{
+			{ID: 1, Name: "Komodo", State: "active"},
+		},
+	}
+	if err := data.WriteFile(); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	statusCmd.SetOut(&buf)
+	statusCmd.Run(statusCmd, nil)
+
+	got := buf.String()
+	want := "1/3 Komodo 0d\n"
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+// BenchmarkIssueCommand measures the startup of a command answered from the
+// cache. The data file is touched before every iteration so that each run
+// decodes it like a new process instead of reusing the data of the previous
+// run.
+func BenchmarkIssueCommand(b *testing.B) {
+	b.Setenv("HOME", b.TempDir())
+	path := filepath.Join(b.TempDir(), "kong")
+	b.Setenv("KONG_CACHE", path)
+
+	data := kong.Data{
+		Timestamp: time.Now().Unix(),
+	}
+	for i := 1; i <= 500; i++ {
+		data.Issues = append(data.Issues, kong.Issue{
+			Key:     fmt.Sprintf("KONG-%d", i),
+			Summary: "Add command to list issues",
+			Status:  kong.Status{Name: "To Do"},
+		})
+	}
+	if err := data.WriteFile(); err != nil {
+		b.Fatal(err)
+	}
+	issuesCmd.SetOut(io.Discard)
+
+	modTime := time.Now()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		modTime = modTime.Add(time.Second)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			b.Fatal(err)
+		}
+		b.StartTimer()
+		if err := issuesCmd.Execute(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func setEnvironmentVariable(t *testing.T, value string) {
 	t.Helper()
 
@@ -0,0 +1,61 @@
+package kong
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// epicColor is a Jira epic color with the closest ANSI terminal color.
+type epicColor struct {
+	name string
+	ansi int
+}
+
+// epicColors maps the label classes Jira stores as epic color.
+var epicColors = map[string]epicColor{
+	"ghx-label-1":  {name: "brown", ansi: 33},
+	"ghx-label-2":  {name: "orange", ansi: 93},
+	"ghx-label-3":  {name: "yellow", ansi: 33},
+	"ghx-label-4":  {name: "blue", ansi: 34},
+	"ghx-label-5":  {name: "slate", ansi: 90},
+	"ghx-label-6":  {name: "lime", ansi: 92},
+	"ghx-label-7":  {name: "mauve", ansi: 95},
+	"ghx-label-8":  {name: "purple", ansi: 35},
+	"ghx-label-9":  {name: "pink", ansi: 95},
+	"ghx-label-10": {name: "navy", ansi: 34},
+	"ghx-label-11": {name: "green", ansi: 32},
+	"ghx-label-12": {name: "grey", ansi: 37},
+	"ghx-label-13": {name: "teal", ansi: 36},
+	"ghx-label-14": {name: "red", ansi: 31},
+}
+
+// defaultForeground resets the foreground color for uncolored text.
+const defaultForeground = 39
+
+// ColorName returns the name of the epic color or an empty string if the epic
+// has no known color.
+func (i Issue) ColorName() string {
+	return epicColors[i.EpicColor].name
+}
+
+// colorKey returns the key wrapped in the ANSI escape codes of the epic color.
+// Keys without color are wrapped as well so that all keys have the same
+// number of invisible bytes and stay aligned in a tabwriter.
+func (i Issue) colorKey() string {
+	code := defaultForeground
+	if color, ok := epicColors[i.EpicColor]; ok {
+		code = color.ansi
+	}
+	return fmt.Sprintf("\x1b[%dm%s\x1b[0m", code, i.Key)
+}
+
+// colorEnabled reports whether colors should be written to output, which is
+// only the case for terminals unless NO_COLOR is set.
+func colorEnabled(output io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	f, ok := output.(*os.File)
+	return ok && isTerminal(f)
+}
@@ -0,0 +1,29 @@
+package kong
+
+import "testing"
+
+func TestIssueColorKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		issue Issue
+		want  string
+	}{
+		{
+			name:  "colored",
+			issue: Issue{Key: "KONG-1", EpicColor: "ghx-label-14"},
+			want:  "\x1b[31mKONG-1\x1b[0m",
+		},
+		{
+			name:  "unknown-color",
+			issue: Issue{Key: "KONG-1", EpicColor: "ghx-label-99"},
+			want:  "\x1b[39mKONG-1\x1b[0m",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.issue.colorKey(); got != tt.want {
+				t.Errorf("got %q, want: %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+// Comment is a comment on an issue.
+type Comment struct {
+	Author  string
+	Created time.Time
+	Body    string
+}
+
+// ListComments returns the comments of the issue in the order they were
+// created.
+func (j Jira) ListComments(ctx context.Context, key string) ([]Comment, error) {
+	issue, resp, err := j.client.Issue.GetWithContext(ctx, key, &jira.GetQueryOptions{Fields: "comment"})
+	if err != nil {
+		return nil, fmt.Errorf("ListComments: %w", parseResponseError(resp))
+	}
+	if issue.Fields == nil || issue.Fields.Comments == nil {
+		return nil, nil
+	}
+	return newComments(issue.Fields.Comments.Comments), nil
+}
+
+func newComments(jiraComments []*jira.Comment) []Comment {
+	comments := make([]Comment, 0, len(jiraComments))
+	for _, c := range jiraComments {
+		if c == nil {
+			continue
+		}
+		// keep comments with a malformed timestamp without one
+		created, _ := time.Parse(jiraTimeLayout, c.Created)
+		comments = append(comments, Comment{
+			Author:  c.Author.DisplayName,
+			Created: created,
+			Body:    strings.TrimSpace(c.Body),
+		})
+	}
+	return comments
+}
+
+// PrintComments writes the comments with their author and timestamp.
+func PrintComments(output io.Writer, comments []Comment) {
+	if len(comments) == 0 {
+		fmt.Fprintln(output, "\nNo comments")
+		return
+	}
+	for _, c := range comments {
+		fmt.Fprintf(output, "\n%s on %s\n", c.Author, formatTimestamp(c.Created))
+		for _, line := range strings.Split(c.Body, "\n") {
+			fmt.Fprintf(output, "  %s\n", line)
+		}
+	}
+}
+
+// commentBody returns the comment written in the editor without the comment
+// lines of the template. Empty lines within the comment are kept.
+func commentBody(s string) string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,62 @@
+package kong
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCommentBody(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{
+			name: "empty",
+			s:    "# Comment on KONG-1\n# Lines starting with # are ignored\n\n",
+		},
+		{
+			name: "paragraphs",
+			s:    "# Comment on KONG-1\n\nFirst paragraph\n\nSecond paragraph\n",
+			want: "First paragraph\n\nSecond paragraph",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := commentBody(tt.s); got != tt.want {
+				t.Errorf("got %q, want: %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintComments(t *testing.T) {
+	comments := newComments([]*jira.Comment{
+		{
+			Author:  jira.User{DisplayName: "Jane Doe"},
+			Created: "2024-03-13T09:30:00.000+0000",
+			Body:    "Looks good\nShip it\n",
+		},
+		nil,
+	})
+	want := []Comment{
+		{
+			Author:  "Jane Doe",
+			Created: time.Date(2024, 3, 13, 9, 30, 0, 0, time.UTC),
+			Body:    "Looks good\nShip it",
+		},
+	}
+	if diff := cmp.Diff(comments, want, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+
+	var b bytes.Buffer
+	PrintComments(&b, nil)
+	if got := b.String(); got != "\nNo comments\n" {
+		t.Errorf("got %q, want: %q", got, "\nNo comments\n")
+	}
+}
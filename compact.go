@@ -0,0 +1,206 @@
+package kong
+
+import "strings"
+
+// transitionTable is the workflow of an issue. Issues of the same workflow
+// share one table instead of holding copies of the same transitions.
+type transitionTable struct {
+	transitions []Transition
+	byAcronym   map[string]Transition
+	order       map[string]int
+}
+
+func (t transitionTable) apply(issue Issue) Issue {
+	issue.Transitions = t.transitions
+	issue.TransitionsByAcronym = t.byAcronym
+	issue.OrderByTransitionStatus = t.order
+	return issue
+}
+
+// transitionsKey identifies transitions by content to deduplicate them.
+func transitionsKey(transitions []Transition) string {
+	var b strings.Builder
+	for _, t := range transitions {
+		b.WriteString(t.ID)
+		b.WriteByte(0)
+		b.WriteString(t.Name)
+		b.WriteByte(0)
+		b.WriteString(t.Description)
+		b.WriteByte(0)
+		b.WriteString(t.Acronym)
+		b.WriteByte(0)
+	}
+	return b.String()
+}
+
+// compactor deduplicates the strings and transition tables of decoded issues.
+// Decoding allocates every string and slice of every issue on its own, even if
+// the same issue is listed more than once, which adds up on large projects.
+type compactor struct {
+	strings map[string]string
+	tables  map[string]transitionTable
+}
+
+func newCompactor() *compactor {
+	return &compactor{
+		strings: make(map[string]string),
+		tables:  make(map[string]transitionTable),
+	}
+}
+
+func (c *compactor) string(s string) string {
+	if s == "" {
+		return s
+	}
+	if interned, ok := c.strings[s]; ok {
+		return interned
+	}
+	c.strings[s] = s
+	return s
+}
+
+// table returns the table of the transitions, building it the same way as
+// NewIssues on first use.
+func (c *compactor) table(transitions []Transition) transitionTable {
+	key := transitionsKey(transitions)
+	if t, ok := c.tables[key]; ok {
+		return t
+	}
+	t := transitionTable{
+		transitions: make([]Transition, len(transitions)),
+		byAcronym:   make(map[string]Transition, len(transitions)),
+		order:       make(map[string]int, len(transitions)),
+	}
+	for i, transition := range transitions {
+		transition = Transition{
+			ID:          c.string(transition.ID),
+			Name:        c.string(transition.Name),
+			Description: c.string(transition.Description),
+			Acronym:     c.string(transition.Acronym),
+		}
+		t.transitions[i] = transition
+		t.byAcronym[transition.Acronym] = transition
+		t.order[transition.Name] = i
+	}
+	c.tables[key] = t
+	return t
+}
+
+// issue returns the issue sharing strings and transitions with the issues
+// compacted before. The fields of the issue must not be shared with other
+// data since they are updated in place.
+func (c *compactor) issue(issue Issue) Issue {
+	issue.Key = c.string(issue.Key)
+	issue.Summary = c.string(issue.Summary)
+	issue.Description = c.string(issue.Description)
+	issue.AcceptanceCriteria = c.string(issue.AcceptanceCriteria)
+	issue.Priority = c.string(issue.Priority)
+	issue.Status.Name = c.string(issue.Status.Name)
+	issue.Status.Acronym = c.string(issue.Status.Acronym)
+	issue.EpicKey = c.string(issue.EpicKey)
+	issue.OriginalEstimate = c.string(issue.OriginalEstimate)
+	issue.RemainingEstimate = c.string(issue.RemainingEstimate)
+	issue.EpicColor = c.string(issue.EpicColor)
+	issue.EpicStatus = c.string(issue.EpicStatus)
+	issue.Reporter = c.string(issue.Reporter)
+	for name, value := range issue.Fields {
+		switch v := value.(type) {
+		case string:
+			issue.Fields[name] = c.string(v)
+		case []string:
+			for i := range v {
+				v[i] = c.string(v[i])
+			}
+		}
+	}
+	if len(issue.Transitions) > 0 {
+		issue = c.table(issue.Transitions).apply(issue)
+	}
+	return issue
+}
+
+// compact deduplicates the strings and transition tables of freshly decoded
+// data.
+func (d *Data) compact() {
+	c := newCompactor()
+	d.rewriteIssues(c.issue)
+	if len(d.Transitions) > 0 {
+		d.Transitions = c.table(d.Transitions).transitions
+	}
+}
+
+// rewriteIssues replaces every issue of the data, including the issues of
+// other projects, with the result of f. The lists are copied rather than
+// updated in place since the data may be shared with the session cache.
+func (d *Data) rewriteIssues(f func(Issue) Issue) {
+	rewrite := func(issues Issues) Issues {
+		if issues == nil {
+			return nil
+		}
+		result := make(Issues, len(issues))
+		for i, issue := range issues {
+			result[i] = f(issue)
+		}
+		return result
+	}
+	rewriteByKey := func(issues map[string]Issue) map[string]Issue {
+		if issues == nil {
+			return nil
+		}
+		result := make(map[string]Issue, len(issues))
+		for key, issue := range issues {
+			result[key] = f(issue)
+		}
+		return result
+	}
+	d.Issues = rewrite(d.Issues)
+	d.IssueByKey = rewriteByKey(d.IssueByKey)
+	d.Initiatives = rewrite(d.Initiatives)
+	d.Epics = rewrite(d.Epics)
+	d.SprintIssues = rewrite(d.SprintIssues)
+	if d.Projects == nil {
+		return
+	}
+	projects := make(map[string]ProjectData, len(d.Projects))
+	for name, p := range d.Projects {
+		p.Issues = rewrite(p.Issues)
+		p.IssueByKey = rewriteByKey(p.IssueByKey)
+		p.Initiatives = rewrite(p.Initiatives)
+		p.Epics = rewrite(p.Epics)
+		p.SprintIssues = rewrite(p.SprintIssues)
+		projects[name] = p
+	}
+	d.Projects = projects
+}
+
+// unlistedIssues returns the issues by key which are not part of the list.
+// Issues are indexed by key whenever the list is loaded, so that only issues
+// which dropped out of the list since are stored separately.
+func unlistedIssues(issues Issues, byKey map[string]Issue) map[string]Issue {
+	listed := make(map[string]Issue, len(issues))
+	for _, issue := range issues {
+		listed[issue.Key] = issue
+	}
+	result := make(map[string]Issue)
+	for key, issue := range byKey {
+		if l, ok := listed[key]; ok && l.Updated.Equal(issue.Updated) {
+			continue
+		}
+		result[key] = issue
+	}
+	return result
+}
+
+// withListedIssues reverses unlistedIssues.
+func withListedIssues(issues Issues, unlisted map[string]Issue) map[string]Issue {
+	result := make(map[string]Issue, len(issues)+len(unlisted))
+	for key, issue := range unlisted {
+		result[key] = issue
+	}
+	for _, issue := range issues {
+		if _, ok := result[issue.Key]; !ok {
+			result[issue.Key] = issue
+		}
+	}
+	return result
+}
@@ -0,0 +1,66 @@
+package kong
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCachePayload(t *testing.T) {
+	data := newFixture(100, 5).data(t)
+	stale := Issue{Key: "KONG-999", Summary: "Dropped out of the list"}
+	data.IssueByKey[stale.Key] = stale
+	data.Projects = map[string]ProjectData{
+		"APE": {Issues: data.Epics, IssueByKey: map[string]Issue{data.Epics[0].Key: data.Epics[0]}},
+	}
+
+	p, bodies := newCachePayload(data)
+	if len(p.Workflows) != 1 {
+		t.Errorf("got %d workflows, want: %d", len(p.Workflows), 1)
+	}
+	if len(p.Data.IssueByKey) != 1 {
+		t.Errorf("got %d issues by key, want: %d", len(p.Data.IssueByKey), 1)
+	}
+	if len(data.Issues[0].Transitions) == 0 {
+		t.Fatal("got transitions of the data removed, want: data left unchanged")
+	}
+
+	if len(bodies) != len(data.Issues)+len(data.Epics) {
+		t.Errorf("got %d bodies, want: %d", len(bodies), len(data.Issues)+len(data.Epics))
+	}
+	got := p.data()
+	got.setBodies(bodies)
+	if diff := cmp.Diff(got.Issues, data.Issues); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	if diff := cmp.Diff(got.IssueByKey, data.IssueByKey); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	if diff := cmp.Diff(got.SprintIssues, data.SprintIssues); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	if diff := cmp.Diff(got.Projects["APE"].IssueByKey, withListedIssues(data.Epics, nil)); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	data := newFixture(10, 2).data(t)
+	encoded, err := encodeCache(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := decodeCache(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b := got.Issues[0], got.Issues[1]
+	if &a.Transitions[0] != &b.Transitions[0] {
+		t.Error("got transitions copied per issue, want: shared")
+	}
+	sprintIssue := got.SprintIssues[0]
+	issue := got.IssueByKey[sprintIssue.Key]
+	if &issue.Transitions[0] != &sprintIssue.Transitions[0] {
+		t.Error("got transitions copied per list, want: shared")
+	}
+}
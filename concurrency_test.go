@@ -0,0 +1,134 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// The tests in this file exercise data which is shared between goroutines and
+// are meant to be run with the race detector, see make race.
+
+func TestConcurrentLoaders(t *testing.T) {
+	shared := NewData()
+	shared.Issues = Issues{{Key: "KONG-1", Summary: "Cached"}}
+	shared.IssueByKey["KONG-1"] = shared.Issues[0]
+	shared.SprintsByName["Sprint 1"] = Sprint{ID: 1, Name: "Sprint 1"}
+
+	issues := Issues{{Key: "KONG-1", Summary: "Fetched"}, {Key: "KONG-2", Summary: "Fetched"}}
+	sprints := Sprints{{ID: 2, Name: "Sprint 2"}}
+	loaders := map[Section]sectionLoader{
+		SectionIssues: func(ctx context.Context) (func(d *Data), error) {
+			return func(d *Data) {
+				d.Issues = issues
+				d.IssueByKey = withIssuesByKey(d.IssueByKey, issues)
+			}, nil
+		},
+		SectionSprints: func(ctx context.Context) (func(d *Data), error) {
+			return func(d *Data) {
+				d.Sprints = sprints
+				d.SprintsByName = map[string]Sprint{"Sprint 2": sprints[0]}
+			}, nil
+		},
+	}
+
+	// readers of the shared data must not observe the loaders
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if got := shared.IssueByKey["KONG-1"].Summary; got != "Cached" {
+					t.Errorf("got %v, want: %v", got, "Cached")
+					return
+				}
+				_ = shared.SprintsByName["Sprint 1"]
+			}
+		}()
+	}
+
+	data := shared
+	if err := data.runLoaders(context.Background(), SectionAll, loaders); err != nil {
+		t.Fatal(err)
+	}
+	wg.Wait()
+
+	want := map[string]Issue{
+		"KONG-1": issues[0],
+		"KONG-2": issues[1],
+	}
+	if diff := cmp.Diff(data.IssueByKey, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	if diff := cmp.Diff(data.Sprints, sprints); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	if len(shared.IssueByKey) != 1 {
+		t.Errorf("got %d issues by key, want shared data to be unchanged", len(shared.IssueByKey))
+	}
+}
+
+func TestConcurrentLoadersError(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	loaders := map[Section]sectionLoader{
+		SectionIssues: func(ctx context.Context) (func(d *Data), error) {
+			return func(d *Data) {
+				d.Issues = Issues{{Key: "KONG-1"}}
+			}, nil
+		},
+		SectionEpics: func(ctx context.Context) (func(d *Data), error) {
+			return nil, errFetch
+		},
+	}
+	data := NewData()
+	if err := data.runLoaders(context.Background(), SectionAll, loaders); !errors.Is(err, errFetch) {
+		t.Errorf("got %v, want: %v", err, errFetch)
+	}
+	if len(data.Issues) != 0 {
+		t.Errorf("got %v, want no sections to be applied", data.Issues)
+	}
+}
+
+func TestConcurrentReadWriteData(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+	session.data.reset()
+
+	data := NewData()
+	data.Issues = Issues{{Key: "KONG-1", Description: "Body"}}
+	if err := data.WriteFile(); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := ReadData()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if err := got.LoadBodies(); err != nil {
+				t.Error(err)
+				return
+			}
+			if len(got.Issues) != 1 || got.Issues[0].Description != "Body" {
+				t.Errorf("got %v, want issue with body", got.Issues)
+				return
+			}
+			got.Project = fmt.Sprintf("KONG-%d", i)
+			if err := got.WriteFile(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -5,14 +5,41 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"path"
+	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v2"
 )
 
-var errConfigComponentEmpty = errors.New("component cannot be empty")
+var (
+	errConfigComponentEmpty  = errors.New("component cannot be empty")
+	errConfigAliasInvalid    = errors.New("status alias must be a single word")
+	errConfigAliasReserved   = errors.New("status alias is reserved")
+	errConfigAliasDuplicated = errors.New("status has more than one alias")
+	errConfigStandupSource   = errors.New("unknown standup source")
+	errConfigStandupJQL      = errors.New("standup source jql requires a query")
+	errConfigQuietHours      = errors.New("quiet hours must be between 0 and 23")
+	errConfigAuthType        = errors.New("unknown auth type, expected basic, token or pat")
+	errConfigToken           = errors.New("auth type requires a token")
+	errConfigSprintShape     = errors.New("unknown sprint shape, expected array or scalar")
+	errConfigEpicField       = errors.New("epic custom field is not configured")
+)
+
+var (
+	priorityActionPattern = regexp.MustCompile(`^p[0-9]+$`)
+	defaultPriorities     = []string{"Highest", "High", "Medium", "Low", "Lowest"}
+)
+
+// Standup data sources available to standup templates.
+const (
+	StandupSourceSprint = "sprint"
+	StandupSourceEpics  = "epics"
+	StandupSourceJQL    = "jql"
+	StandupSourceTeam   = "team"
+)
 
 // Config provides the configuration for the Jira client. The configuration is
 // used to authenticate the Jira client and customize Jira queries.
@@ -21,18 +48,219 @@ type Config struct {
 	Username string `yaml:"username"`
 	Password string `yaml:"password"`
 
-	Project      string       `yaml:"project"`
+	// AuthType is one of basic, token for Jira Cloud API tokens or pat for
+	// personal access tokens of Jira Server and Data Center. Token holds the
+	// API token or personal access token.
+	AuthType string `yaml:"authType"`
+	Token    string `yaml:"token"`
+
+	// Proxy overrides the proxy configured through HTTP_PROXY and HTTPS_PROXY.
+	Proxy string `yaml:"proxy"`
+	TLS   TLS    `yaml:"tls"`
+
+	// Project is the project issues are created in. It may be configured as
+	// list of projects if the sprint board aggregates several projects.
+	Project string `yaml:"project"`
+	// Projects lists additional projects the daemon syncs so that kong use
+	// can switch to them without waiting for a sync.
+	Projects     []string     `yaml:"projects"`
 	IssueType    string       `yaml:"issueType"`
+	SubtaskType  string       `yaml:"subtaskType"`
 	Labels       []string     `yaml:"labels"`
 	Components   []string     `yaml:"components"`
 	CustomFields CustomFields `yaml:"customFields"`
 
+	// ProjectCustomFields overrides custom fields by project key for projects
+	// whose issues use different custom fields than the ones above.
+	ProjectCustomFields map[string]CustomFields `yaml:"projectCustomFields"`
+
+	// Fields maps additional custom fields by name, for instance to show
+	// them in issue listings or to set them on issue creation.
+	Fields map[string]CustomField `yaml:"fields"`
+
+	// IssueTypes declares defaults by issue type name which are applied to
+	// created issues in addition to the labels and components above.
+	IssueTypes map[string]IssueTypeDefaults `yaml:"issueTypes"`
+
 	SprintKeyword  string `yaml:"sprintKeyword"`
 	SprintDuration int    `yaml:"sprintDuration"`
+	Timezone       string `yaml:"timezone"`
+
+	// SprintShape is array or scalar to override whether the sprint field is
+	// updated with an array of sprint IDs or a bare ID. It is detected from
+	// the field schema by default.
+	SprintShape string `yaml:"sprintShape"`
+
+	// SprintWorkingDays counts SprintDuration in working days which skip
+	// weekends and Holidays formatted as YYYY-MM-DD.
+	SprintWorkingDays bool     `yaml:"sprintWorkingDays"`
+	Holidays          []string `yaml:"holidays"`
+
+	ReleasedStatus string `yaml:"releasedStatus"`
+
+	// QuietHours slows down the daemon refresh, for instance overnight.
+	QuietHours QuietHours `yaml:"quietHours"`
+
+	// Team lists the display names of team members and TeamCapacity the
+	// number of story points per sprint above which a member is overloaded.
+	Team         []string `yaml:"team"`
+	TeamCapacity float64  `yaml:"teamCapacity"`
+
+	// Reviewers lists the display names of the review rotation of kong review
+	// assign, it defaults to the team. ReviewerField is the ID of the user
+	// custom field the reviewer is set in, reviewers are added as watchers
+	// if it is empty.
+	Reviewers     []string `yaml:"reviewers"`
+	ReviewerField string   `yaml:"reviewerField"`
+
+	// Editor is the command to edit files with, for instance code --wait. It
+	// defaults to $VISUAL, $EDITOR or the platform editor.
+	Editor string `yaml:"editor"`
+
+	// ASCII replaces emoji and other symbols in the output with plain ASCII
+	// for terminals and fonts which render them badly.
+	ASCII bool `yaml:"ascii"`
 
 	CopyCommand           string `yaml:"copyCommand"`
 	SprintStandupTemplate string `yaml:"sprintStandupTemplate"`
 	EpicStandupTemplate   string `yaml:"epicStandupTemplate"`
+	PullRequestTemplate   string `yaml:"pullRequestTemplate"`
+	WeekTemplate          string `yaml:"weekTemplate"`
+
+	// StandupTemplates declares named standup templates in addition to the
+	// sprint and epic standup templates.
+	StandupTemplates map[string]StandupTemplate `yaml:"standupTemplates"`
+
+	// StatusAliases maps user-defined sprint editor actions to status names,
+	// overriding the generated acronyms.
+	StatusAliases map[string]string `yaml:"statusAliases"`
+
+	// Aliases maps names to kong command lines with arguments, for instance
+	// wip: issue move --current ip, which are run as kong wip.
+	Aliases map[string]string `yaml:"aliases"`
+
+	// Priorities lists the Jira priority names from highest to lowest which
+	// the sprint editor actions p1, p2 and so on map to.
+	Priorities []string `yaml:"priorities"`
+
+	// DoneStatuses lists the status names which count as done in sprint
+	// listings, reports and progress. If empty, issues in Jira's done status
+	// category count as done.
+	DoneStatuses []string `yaml:"doneStatuses"`
+
+	// ReadOnly disables all commands which change Jira, for instance to demo
+	// Kong or to share it with stakeholders who only browse.
+	ReadOnly bool `yaml:"readOnly"`
+
+	// Celebrate prints the issues done today and the current streak after
+	// issues are moved to a done status.
+	Celebrate bool `yaml:"celebrate"`
+
+	Lint Lint `yaml:"lint"`
+
+	// Checklists maps status names to the definition of done which has to be
+	// confirmed before issues are moved to the status.
+	Checklists map[string]Checklist `yaml:"checklists"`
+
+	// Views declares named lists of issues shown with kong view.
+	Views map[string]View `yaml:"views"`
+
+	// EpicTemplates declares named sets of child issues created for an epic
+	// with kong epics scaffold.
+	EpicTemplates map[string]EpicTemplate `yaml:"epicTemplates"`
+
+	// Queries maps names to JQL queries which are run with kong search NAME.
+	Queries map[string]string `yaml:"queries"`
+
+	// IssuesJQL, EpicsJQL and SprintIssuesJQL replace the queries of the
+	// synced issues, epics and sprint issues. They are templates executed
+	// with {{.Project}}, {{.Projects}} of the sprint board and the display
+	// name of the user as {{.User}}, e.g. assignee = "{{.User}}". Configured
+	// labels are not added to a configured EpicsJQL.
+	IssuesJQL       string `yaml:"issuesJQL"`
+	EpicsJQL        string `yaml:"epicsJQL"`
+	SprintIssuesJQL string `yaml:"sprintIssuesJQL"`
+
+	// GitHubSync enables the daemon to mirror status changes of issues
+	// imported with kong import github.
+	GitHubSync GitHubSync `yaml:"githubSync"`
+
+	// boardProjects are the projects of the sprint board if project is
+	// configured as list, the first one is Project.
+	boardProjects []string
+}
+
+// UnmarshalYAML decodes the configuration and accepts a list of projects for
+// project, issues are created in the first one.
+func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type config Config
+	err := unmarshal((*config)(c))
+	var typeErr *yaml.TypeError
+	if err == nil || !errors.As(err, &typeErr) || len(typeErr.Errors) > 1 {
+		return err
+	}
+	var projects struct {
+		Project []string `yaml:"project"`
+	}
+	if unmarshal(&projects) != nil || len(projects.Project) == 0 {
+		return err
+	}
+	c.Project = projects.Project[0]
+	c.boardProjects = projects.Project
+	return nil
+}
+
+// MarshalYAML encodes the configuration and writes project as list if it has
+// been configured as one.
+func (c Config) MarshalYAML() (interface{}, error) {
+	type config Config
+	if len(c.boardProjects) < 2 {
+		return config(c), nil
+	}
+	b, err := yaml.Marshal(config(c))
+	if err != nil {
+		return nil, err
+	}
+	var fields yaml.MapSlice
+	if err := yaml.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	for i, field := range fields {
+		if field.Key == "project" {
+			fields[i].Value = c.boardProjects
+		}
+	}
+	return fields, nil
+}
+
+// BoardProjects returns the projects of the sprint board.
+func (c Config) BoardProjects() []string {
+	if len(c.boardProjects) > 0 {
+		return c.boardProjects
+	}
+	return []string{c.Project}
+}
+
+// projectCondition returns the JQL condition matching the issues of the
+// projects of the sprint board.
+func (c Config) projectCondition() string {
+	projects := c.BoardProjects()
+	if len(projects) == 1 {
+		return "project = " + projects[0]
+	}
+	return "project IN (" + strings.Join(projects, ", ") + ")"
+}
+
+// BrowseURL returns the URL to view the issue in the browser.
+func (c Config) BrowseURL(key string) string {
+	return strings.TrimSuffix(c.Endpoint, "/") + "/browse/" + key
+}
+
+// forProject returns the configuration with the custom fields of the given
+// project.
+func (c Config) forProject(project string) Config {
+	c.CustomFields = c.CustomFields.merge(c.ProjectCustomFields[project])
+	return c
 }
 
 // CustomFields provides configuration of custom fields to map fields like
@@ -42,24 +270,329 @@ type CustomFields struct {
 	Epics       string `yaml:"epics"`
 	Sprints     string `yaml:"sprints"`
 	StoryPoints string `yaml:"storyPoints"`
+	// custom fields for issue descriptions
+	AcceptanceCriteria string `yaml:"acceptanceCriteria"`
 	// custom fields for epic creation
 	EpicName   string `yaml:"epicName"`
 	ParentLink string `yaml:"parentLink"`
+	// custom fields for epic display
+	EpicColor  string `yaml:"epicColor"`
+	EpicStatus string `yaml:"epicStatus"`
+}
+
+// merge returns the custom fields overridden by the configured fields of
+// other.
+func (c CustomFields) merge(other CustomFields) CustomFields {
+	override := func(field *string, value string) {
+		if value != "" {
+			*field = value
+		}
+	}
+	override(&c.Epics, other.Epics)
+	override(&c.Sprints, other.Sprints)
+	override(&c.StoryPoints, other.StoryPoints)
+	override(&c.AcceptanceCriteria, other.AcceptanceCriteria)
+	override(&c.EpicName, other.EpicName)
+	override(&c.ParentLink, other.ParentLink)
+	override(&c.EpicColor, other.EpicColor)
+	override(&c.EpicStatus, other.EpicStatus)
+	return c
+}
+
+// names returns the display names of the configured custom fields by field
+// ID.
+func (c CustomFields) names() map[string]string {
+	names := map[string]string{
+		c.Epics:              "Epic Link",
+		c.Sprints:            "Sprint",
+		c.StoryPoints:        "Story Points",
+		c.AcceptanceCriteria: "Acceptance Criteria",
+		c.EpicName:           "Epic Name",
+		c.ParentLink:         "Parent Link",
+		c.EpicColor:          "Epic Color",
+		c.EpicStatus:         "Epic Status",
+	}
+	delete(names, "")
+	return names
+}
+
+// SyncedProjects returns the project in use followed by the additional
+// projects synced by the daemon.
+func (c Config) SyncedProjects() []string {
+	var projects []string
+	for _, project := range uniqueStrings(append([]string{c.Project}, c.Projects...)) {
+		if project != "" {
+			projects = append(projects, project)
+		}
+	}
+	return projects
+}
+
+// UseProject makes the project the default project. The previous project is
+// kept in Projects so that the daemon continues to sync it.
+func (c *Config) UseProject(project string) {
+	if c.Project == project && len(c.boardProjects) == 0 {
+		return
+	}
+	for _, previous := range c.BoardProjects() {
+		if previous != "" && previous != project && !contains(c.Projects, previous) {
+			c.Projects = append(c.Projects, previous)
+		}
+	}
+	c.Project = project
+	c.boardProjects = nil
+}
+
+// IssueTypeDefaults are set on created issues of an issue type. Fields maps
+// names of custom fields configured in Config.Fields to their value.
+type IssueTypeDefaults struct {
+	Labels     []string          `yaml:"labels"`
+	Components []string          `yaml:"components"`
+	Priority   string            `yaml:"priority"`
+	Fields     map[string]string `yaml:"fields"`
+}
+
+func (d IssueTypeDefaults) validate(config Config) error {
+	for _, component := range d.Components {
+		if component == "" {
+			return errConfigComponentEmpty
+		}
+	}
+	for name, value := range d.Fields {
+		field, err := config.writableField(name)
+		if err != nil {
+			return err
+		}
+		if _, err := field.encode(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TLS configures custom certificate authorities and client certificates for
+// mutual TLS.
+type TLS struct {
+	CAFile             string `yaml:"caFile"`
+	CertFile           string `yaml:"certFile"`
+	KeyFile            string `yaml:"keyFile"`
+	InsecureSkipVerify bool   `yaml:"insecureSkipVerify"`
+}
+
+// StandupTemplate is a named standup template rendered against the data of its
+// source which is one of sprint, epics, jql or team.
+type StandupTemplate struct {
+	Source   string `yaml:"source"`
+	JQL      string `yaml:"jql"`
+	Template string `yaml:"template"`
+}
+
+// QuietHours is a daily time range from Start to End hour in the board
+// timezone. The range wraps around midnight if Start is greater than End.
+type QuietHours struct {
+	Start int `yaml:"start"`
+	End   int `yaml:"end"`
+}
+
+// Contains reports whether the given time is within the quiet hours.
+func (q QuietHours) Contains(t time.Time) bool {
+	if q.Start == q.End {
+		return false
+	}
+	hour := t.Hour()
+	if q.Start < q.End {
+		return hour >= q.Start && hour < q.End
+	}
+	return hour >= q.Start || hour < q.End
+}
+
+// Lint configures optional checks which are performed on issues parsed from
+// the editor before they are created. A zero value disables the check.
+type Lint struct {
+	MaxSummaryLength   int      `yaml:"maxSummaryLength"`
+	RequireDescription bool     `yaml:"requireDescription"`
+	RequireStoryPoints bool     `yaml:"requireStoryPoints"`
+	BannedWords        []string `yaml:"bannedWords"`
 }
 
 // Validate ensures the configuration has a valid values.
 func (c Config) Validate() error {
+	switch c.AuthType {
+	case "", AuthBasic:
+	case AuthToken, AuthPAT:
+		if c.Token == "" {
+			return fmt.Errorf("Config.Validate: %w: %s", errConfigToken, c.AuthType)
+		}
+	default:
+		return fmt.Errorf("Config.Validate: %w: %s", errConfigAuthType, c.AuthType)
+	}
+	switch c.SprintShape {
+	case "", sprintShapeArray, sprintShapeScalar:
+	default:
+		return fmt.Errorf("Config.Validate: %w: %s", errConfigSprintShape, c.SprintShape)
+	}
 	for _, component := range c.Components {
 		if component == "" {
 			return fmt.Errorf("Config.Validate: %w", errConfigComponentEmpty)
 		}
 	}
+	if _, err := c.Location(); err != nil {
+		return fmt.Errorf("Config.Validate: %w", err)
+	}
+	statuses := make(map[string]string, len(c.StatusAliases))
+	for alias, status := range c.StatusAliases {
+		if alias == "" || strings.ContainsAny(alias, " \t") {
+			return fmt.Errorf("Config.Validate: %w: %q", errConfigAliasInvalid, alias)
+		}
+		if alias == backlogAcronym || alias == nextSprintAcronym || alias == assignAcronym || priorityActionPattern.MatchString(alias) {
+			return fmt.Errorf("Config.Validate: %w: %s", errConfigAliasReserved, alias)
+		}
+		if other, ok := statuses[status]; ok {
+			return fmt.Errorf("Config.Validate: %w: %s (%s, %s)", errConfigAliasDuplicated, status, other, alias)
+		}
+		statuses[status] = alias
+	}
+	for name, line := range c.Aliases {
+		if err := validateCommandAlias(name, line); err != nil {
+			return fmt.Errorf("Config.Validate: %w", err)
+		}
+	}
+	for name, standup := range c.StandupTemplates {
+		switch standup.Source {
+		case StandupSourceSprint, StandupSourceEpics, StandupSourceTeam:
+		case StandupSourceJQL:
+			if standup.JQL == "" {
+				return fmt.Errorf("Config.Validate: %w: %s", errConfigStandupJQL, name)
+			}
+		default:
+			return fmt.Errorf("Config.Validate: %w: %s (%s)", errConfigStandupSource, standup.Source, name)
+		}
+	}
+	for name, field := range c.Fields {
+		switch name {
+		case fieldEpic, fieldStoryPoints, fieldAcceptanceCriteria, fieldEpicName, fieldParentLink, fieldEpicColor, fieldEpicStatus:
+			return fmt.Errorf("Config.Validate: %w: %s", errFieldReserved, name)
+		}
+		if err := field.validate(); err != nil {
+			return fmt.Errorf("Config.Validate: %w (%s)", err, name)
+		}
+	}
+	for name, defaults := range c.IssueTypes {
+		if err := defaults.validate(c); err != nil {
+			return fmt.Errorf("Config.Validate: %w (%s)", err, name)
+		}
+	}
+	for name, view := range c.Views {
+		if err := view.validate(); err != nil {
+			return fmt.Errorf("Config.Validate: %w (%s)", err, name)
+		}
+	}
+	for status, checklist := range c.Checklists {
+		if err := checklist.validate(); err != nil {
+			return fmt.Errorf("Config.Validate: %w (%s)", err, status)
+		}
+	}
+	for name, template := range c.EpicTemplates {
+		if err := template.validate(); err != nil {
+			return fmt.Errorf("Config.Validate: %w (%s)", err, name)
+		}
+	}
+	if err := c.GitHubSync.validate(); err != nil {
+		return fmt.Errorf("Config.Validate: %w", err)
+	}
+	if err := c.validateJQLTemplates(); err != nil {
+		return fmt.Errorf("Config.Validate: %w", err)
+	}
+	for name, jql := range c.Queries {
+		if err := validateQuery(name, jql); err != nil {
+			return fmt.Errorf("Config.Validate: %w", err)
+		}
+	}
+	if c.QuietHours.Start < 0 || c.QuietHours.Start > 23 || c.QuietHours.End < 0 || c.QuietHours.End > 23 {
+		return fmt.Errorf("Config.Validate: %w", errConfigQuietHours)
+	}
+	for _, holiday := range c.Holidays {
+		if _, err := time.Parse(holidayLayout, holiday); err != nil {
+			return fmt.Errorf("Config.Validate: %w", err)
+		}
+	}
 	return nil
 }
 
+// DoneStatusNames returns the configured done statuses or the status names
+// issues commonly enter when work is done.
+func (c Config) DoneStatusNames() []string {
+	if len(c.DoneStatuses) > 0 {
+		return c.DoneStatuses
+	}
+	return doneStatuses
+}
+
+// PriorityNames returns the configured priorities or the default Jira
+// priorities.
+func (c Config) PriorityNames() []string {
+	if len(c.Priorities) > 0 {
+		return c.Priorities
+	}
+	return defaultPriorities
+}
+
+// PriorityByAction returns the priority name of a sprint editor action like
+// p1 or false if the action does not set a priority.
+func (c Config) PriorityByAction(action string) (string, bool) {
+	if !priorityActionPattern.MatchString(action) {
+		return "", false
+	}
+	index, _ := strconv.Atoi(action[1:])
+	priorities := c.PriorityNames()
+	if index < 1 || index > len(priorities) {
+		return "", false
+	}
+	return priorities[index-1], true
+}
+
+// StandupTemplate returns the standup template of the given name. The sprint
+// and epics standups fall back to the dedicated template settings.
+func (c Config) StandupTemplate(name string) (StandupTemplate, bool) {
+	if standup, ok := c.StandupTemplates[name]; ok {
+		return standup, true
+	}
+	switch name {
+	case StandupSourceSprint:
+		return StandupTemplate{
+			Source:   StandupSourceSprint,
+			Template: c.SprintStandupTemplate,
+		}, true
+	case StandupSourceEpics:
+		return StandupTemplate{
+			Source:   StandupSourceEpics,
+			Template: c.EpicStandupTemplate,
+		}, true
+	}
+	return StandupTemplate{}, false
+}
+
+// Calendar returns the calendar used to compute sprint boundaries.
+func (c Config) Calendar() Calendar {
+	return NewCalendar(c.SprintWorkingDays, c.Holidays)
+}
+
+// Location returns the timezone of the board which is used for sprint
+// boundaries and due dates. It defaults to the local timezone.
+func (c Config) Location() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(c.Timezone)
+}
+
 // Write ensures the configuration directory exists and writes the content of
 // Config into a file for subsequent retrieval.
 func (c Config) Write() (err error) {
+	session.mu.Lock()
+	session.config.reset()
+	session.mu.Unlock()
+
 	err = os.MkdirAll(c.dir(), os.ModePerm)
 	if err != nil {
 		return err
@@ -80,8 +613,15 @@ func (c Config) Write() (err error) {
 }
 
 // LoadConfig reads the current configuration from disk or returns an empty one
-// if the file does not exist yet.
+// if the file does not exist yet. The configuration is only decoded again if
+// the file changed since the last call.
 func LoadConfig() (Config, error) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.config.load(Config{}.filepath(), readConfig)
+}
+
+func readConfig() (Config, error) {
 	var config Config
 	if config.isMissing() {
 		return config, ErrConfigMissing
@@ -99,12 +639,22 @@ func LoadConfig() (Config, error) {
 	return config, nil
 }
 
+// dir returns the user configuration directory. An existing configuration in
+// ~/.config takes precedence for platforms where the directory differs.
 func (c Config) dir() string {
-	return path.Join(os.Getenv("HOME"), ".config")
+	home, _ := os.UserHomeDir()
+	legacy := filepath.Join(home, ".config")
+	if _, err := os.Stat(filepath.Join(legacy, "kong")); err == nil {
+		return legacy
+	}
+	if dir, err := os.UserConfigDir(); err == nil {
+		return dir
+	}
+	return legacy
 }
 
 func (c Config) filepath() string {
-	return path.Join(c.dir(), "kong")
+	return profilePath(filepath.Join(c.dir(), "kong"), CurrentProfile())
 }
 
 func (c Config) isMissing() bool {
@@ -0,0 +1,115 @@
+package kong
+
+import (
+	"errors"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestPriorityByAction(t *testing.T) {
+	tests := []struct {
+		name       string
+		priorities []string
+		action     string
+		want       string
+		ok         bool
+	}{
+		{name: "default", action: "p1", want: "Highest", ok: true},
+		{name: "configured", priorities: []string{"P0", "P1"}, action: "p2", want: "P1", ok: true},
+		{name: "out-of-range", priorities: []string{"P0", "P1"}, action: "p3"},
+		{name: "zero", action: "p0"},
+		{name: "status", action: "ip"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{Priorities: tt.priorities}
+			got, ok := config.PriorityByAction(tt.action)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("got %v %v, want: %v %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestConfigProjects(t *testing.T) {
+	tests := []struct {
+		name      string
+		yaml      string
+		project   string
+		condition string
+	}{
+		{
+			name:      "single",
+			yaml:      "project: KONG\nsprintKeyword: Kong\n",
+			project:   "KONG",
+			condition: "project = KONG",
+		},
+		{
+			name:      "list",
+			yaml:      "project: [KONG, APE]\nsprintKeyword: Kong\n",
+			project:   "KONG",
+			condition: "project IN (KONG, APE)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var config Config
+			if err := yaml.Unmarshal([]byte(tt.yaml), &config); err != nil {
+				t.Fatal(err)
+			}
+			if config.Project != tt.project || config.SprintKeyword != "Kong" {
+				t.Errorf("got %v %v, want: %v Kong", config.Project, config.SprintKeyword, tt.project)
+			}
+			if got := config.projectCondition(); got != tt.condition {
+				t.Errorf("got %v, want: %v", got, tt.condition)
+			}
+
+			// projects are written back in the configured shape
+			b, err := yaml.Marshal(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var decoded Config
+			if err := yaml.Unmarshal(b, &decoded); err != nil {
+				t.Fatal(err)
+			}
+			if got := decoded.projectCondition(); got != tt.condition {
+				t.Errorf("got %v, want: %v", got, tt.condition)
+			}
+		})
+	}
+}
+
+func TestConfigUnmarshalTypeError(t *testing.T) {
+	var config Config
+	err := yaml.Unmarshal([]byte("project: [KONG, APE]\nsprintDuration: two\n"), &config)
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) || len(typeErr.Errors) != 2 {
+		t.Errorf("got %v, want: type errors of project and sprintDuration", err)
+	}
+}
+
+func TestConfigForProject(t *testing.T) {
+	config := Config{
+		Project: "KONG",
+		CustomFields: CustomFields{
+			StoryPoints: "customfield_10001",
+			Sprints:     "customfield_10002",
+		},
+		ProjectCustomFields: map[string]CustomFields{
+			"APE": {StoryPoints: "customfield_20001"},
+		},
+	}
+	got := config.forProject("APE").CustomFields
+	want := CustomFields{
+		StoryPoints: "customfield_20001",
+		Sprints:     "customfield_10002",
+	}
+	if got != want {
+		t.Errorf("got %v, want: %v", got, want)
+	}
+	if got := config.forProject("KONG").CustomFields; got != config.CustomFields {
+		t.Errorf("got %v, want: %v", got, config.CustomFields)
+	}
+}
@@ -0,0 +1,281 @@
+package kong
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	errFieldID        = errors.New("custom field requires an id")
+	errFieldType      = errors.New("unknown custom field type, expected number, string, option or array")
+	errFieldDirection = errors.New("unknown custom field direction, expected read, write or both")
+	errFieldReserved  = errors.New("custom field name is reserved")
+	errFieldReadOnly  = errors.New("custom field is read-only")
+	errFieldUnknown   = errors.New("custom field is not configured")
+	errFieldValue     = errors.New("custom field value must be formatted as name=value")
+)
+
+// Types of custom field values.
+const (
+	FieldTypeNumber = "number"
+	FieldTypeString = "string"
+	FieldTypeOption = "option"
+	FieldTypeArray  = "array"
+)
+
+// Directions in which custom fields are mapped.
+const (
+	FieldDirectionRead  = "read"
+	FieldDirectionWrite = "write"
+	FieldDirectionBoth  = "both"
+)
+
+// Names of the custom fields configured through CustomFields. Sprints are not
+// part of the mapping since their shape depends on the field schema.
+const (
+	fieldEpic               = "epic"
+	fieldStoryPoints        = "storyPoints"
+	fieldAcceptanceCriteria = "acceptanceCriteria"
+	fieldEpicName           = "epicName"
+	fieldParentLink         = "parentLink"
+	fieldEpicColor          = "epicColor"
+	fieldEpicStatus         = "epicStatus"
+)
+
+// CustomField maps a Jira custom field to a named value. Values are read into
+// Issue.Fields when issues are parsed and written when issues are created,
+// unless the direction restricts the mapping to one of both. Default is set on
+// created issues unless a value is given explicitly.
+type CustomField struct {
+	ID        string `yaml:"id"`
+	Type      string `yaml:"type"`
+	Direction string `yaml:"direction"`
+	Default   string `yaml:"default"`
+}
+
+func (f CustomField) validate() error {
+	if f.ID == "" {
+		return errFieldID
+	}
+	switch f.Type {
+	case FieldTypeNumber, FieldTypeString, FieldTypeOption, FieldTypeArray:
+	default:
+		return fmt.Errorf("%w: %s", errFieldType, f.Type)
+	}
+	switch f.Direction {
+	case "", FieldDirectionRead, FieldDirectionWrite, FieldDirectionBoth:
+	default:
+		return fmt.Errorf("%w: %s", errFieldDirection, f.Direction)
+	}
+	if f.Default != "" {
+		if !f.writable() {
+			return errFieldReadOnly
+		}
+		if _, err := f.encode(f.Default); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f CustomField) readable() bool {
+	return f.Direction != FieldDirectionWrite
+}
+
+func (f CustomField) writable() bool {
+	return f.Direction != FieldDirectionRead
+}
+
+// decode converts the value of the field as returned by the Jira API into a
+// float64, string or []string depending on the field type.
+func (f CustomField) decode(raw any) (any, bool) {
+	switch f.Type {
+	case FieldTypeNumber:
+		value, ok := raw.(float64)
+		return value, ok
+	case FieldTypeString:
+		value, ok := raw.(string)
+		return value, ok
+	case FieldTypeOption:
+		return optionValue(raw)
+	case FieldTypeArray:
+		items, ok := raw.([]any)
+		if !ok {
+			return nil, false
+		}
+		values := make([]string, 0, len(items))
+		for _, item := range items {
+			if value, ok := optionValue(item); ok {
+				values = append(values, value)
+			}
+		}
+		return values, true
+	}
+	return nil, false
+}
+
+// encode converts the value into the shape the Jira API expects for the field
+// type. Arrays are written as plain strings.
+func (f CustomField) encode(value any) (any, error) {
+	switch f.Type {
+	case FieldTypeNumber:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case int:
+			return float64(v), nil
+		case string:
+			return strconv.ParseFloat(v, 64)
+		}
+	case FieldTypeString:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+		return fmt.Sprint(value), nil
+	case FieldTypeOption:
+		if s, ok := value.(string); ok {
+			return map[string]string{"value": s}, nil
+		}
+	case FieldTypeArray:
+		switch v := value.(type) {
+		case []string:
+			return v, nil
+		case string:
+			return []string{v}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %v (%s)", errFieldType, value, f.Type)
+}
+
+// optionValue returns the value of an option or the name of an object like a
+// component or version. Plain strings are returned as is.
+func optionValue(raw any) (string, bool) {
+	switch v := raw.(type) {
+	case string:
+		return v, true
+	case map[string]any:
+		if value, ok := v["value"].(string); ok {
+			return value, true
+		}
+		value, ok := v["name"].(string)
+		return value, ok
+	}
+	return "", false
+}
+
+// fieldMappings returns the configured custom fields by name including the
+// fields configured through CustomFields.
+func (c Config) fieldMappings() map[string]CustomField {
+	mappings := map[string]CustomField{
+		fieldEpic:               {ID: c.CustomFields.Epics, Type: FieldTypeString},
+		fieldStoryPoints:        {ID: c.CustomFields.StoryPoints, Type: FieldTypeNumber},
+		fieldAcceptanceCriteria: {ID: c.CustomFields.AcceptanceCriteria, Type: FieldTypeString},
+		fieldEpicName:           {ID: c.CustomFields.EpicName, Type: FieldTypeString},
+		fieldParentLink:         {ID: c.CustomFields.ParentLink, Type: FieldTypeString},
+		fieldEpicColor:          {ID: c.CustomFields.EpicColor, Type: FieldTypeString},
+		fieldEpicStatus:         {ID: c.CustomFields.EpicStatus, Type: FieldTypeOption},
+	}
+	for name, mapping := range mappings {
+		if mapping.ID == "" {
+			delete(mappings, name)
+		}
+	}
+	for name, field := range c.Fields {
+		mappings[name] = field
+	}
+	return mappings
+}
+
+// decodeFields returns the values of the readable custom fields by name.
+func (c Config) decodeFields(unknowns map[string]any) map[string]any {
+	values := make(map[string]any)
+	for name, field := range c.fieldMappings() {
+		if !field.readable() {
+			continue
+		}
+		if value, ok := field.decode(unknowns[field.ID]); ok {
+			values[name] = value
+		}
+	}
+	return values
+}
+
+// encodeFields returns the values by custom field ID for issue creation.
+// Values of unknown or read-only fields are skipped.
+func (c Config) encodeFields(values map[string]any) (map[string]any, error) {
+	mappings := c.fieldMappings()
+	unknowns := make(map[string]any, len(values))
+	for name, value := range values {
+		field, ok := mappings[name]
+		if !ok || !field.writable() {
+			continue
+		}
+		encoded, err := field.encode(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w (%s)", err, name)
+		}
+		unknowns[field.ID] = encoded
+	}
+	return unknowns, nil
+}
+
+// fieldDefaults returns the encoded default values by custom field ID for
+// issues of the given type. Defaults of the issue type take precedence.
+func (c Config) fieldDefaults(issueType string) map[string]any {
+	defaults := make(map[string]any)
+	for _, field := range c.Fields {
+		if field.Default == "" || !field.writable() {
+			continue
+		}
+		// defaults are validated together with the configuration
+		if value, err := field.encode(field.Default); err == nil {
+			defaults[field.ID] = value
+		}
+	}
+	for name, value := range c.IssueTypes[issueType].Fields {
+		field, err := c.writableField(name)
+		if err != nil {
+			continue
+		}
+		if value, err := field.encode(value); err == nil {
+			defaults[field.ID] = value
+		}
+	}
+	return defaults
+}
+
+// writableField returns the custom field configured in Fields by name unless
+// it is read-only.
+func (c Config) writableField(name string) (CustomField, error) {
+	field, ok := c.Fields[name]
+	if !ok {
+		return field, fmt.Errorf("%w: %s", errFieldUnknown, name)
+	}
+	if !field.writable() {
+		return field, fmt.Errorf("%w: %s", errFieldReadOnly, name)
+	}
+	return field, nil
+}
+
+// parseFieldValues parses values formatted as name=value, for instance
+// team=Platform, of writable custom fields configured in Fields.
+func (c Config) parseFieldValues(values []string) (map[string]any, error) {
+	fields := make(map[string]any, len(values))
+	for _, s := range values {
+		name, value, ok := strings.Cut(s, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%w: %s", errFieldValue, s)
+		}
+		field, err := c.writableField(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := field.encode(value); err != nil {
+			return nil, err
+		}
+		fields[name] = value
+	}
+	return fields, nil
+}
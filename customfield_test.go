@@ -0,0 +1,252 @@
+package kong
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDecodeFields(t *testing.T) {
+	config := Config{
+		CustomFields: CustomFields{
+			StoryPoints: "customfield_10001",
+			EpicStatus:  "customfield_10002",
+		},
+		Fields: map[string]CustomField{
+			"team":   {ID: "customfield_10003", Type: FieldTypeOption},
+			"tags":   {ID: "customfield_10004", Type: FieldTypeArray},
+			"review": {ID: "customfield_10005", Type: FieldTypeString, Direction: FieldDirectionWrite},
+		},
+	}
+	unknowns := map[string]any{
+		"customfield_10001": 3.0,
+		"customfield_10002": map[string]any{"value": "In Progress"},
+		"customfield_10003": map[string]any{"value": "Platform"},
+		"customfield_10004": []any{"a", map[string]any{"name": "b"}},
+		"customfield_10005": "ignored",
+	}
+	want := map[string]any{
+		fieldStoryPoints: 3.0,
+		fieldEpicStatus:  "In Progress",
+		"team":           "Platform",
+		"tags":           []string{"a", "b"},
+	}
+	if diff := cmp.Diff(config.decodeFields(unknowns), want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestEncodeFields(t *testing.T) {
+	config := Config{
+		CustomFields: CustomFields{
+			StoryPoints: "customfield_10001",
+		},
+		Fields: map[string]CustomField{
+			"team":     {ID: "customfield_10003", Type: FieldTypeOption},
+			"tags":     {ID: "customfield_10004", Type: FieldTypeArray},
+			"resolved": {ID: "customfield_10005", Type: FieldTypeString, Direction: FieldDirectionRead},
+		},
+	}
+	got, err := config.encodeFields(map[string]any{
+		fieldStoryPoints: 5.0,
+		fieldEpic:        "KONG-1",
+		"team":           "Platform",
+		"tags":           "a",
+		"resolved":       "ignored",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"customfield_10001": 5.0,
+		"customfield_10003": map[string]string{"value": "Platform"},
+		"customfield_10004": []string{"a"},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestConfigValidateFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields map[string]CustomField
+		types  map[string]IssueTypeDefaults
+		want   error
+	}{
+		{
+			name:   "valid",
+			fields: map[string]CustomField{"team": {ID: "customfield_10001", Type: FieldTypeOption}},
+		},
+		{
+			name:   "reserved",
+			fields: map[string]CustomField{fieldStoryPoints: {ID: "customfield_10001", Type: FieldTypeNumber}},
+			want:   errFieldReserved,
+		},
+		{
+			name:   "missing id",
+			fields: map[string]CustomField{"team": {Type: FieldTypeOption}},
+			want:   errFieldID,
+		},
+		{
+			name:   "unknown type",
+			fields: map[string]CustomField{"team": {ID: "customfield_10001", Type: "user"}},
+			want:   errFieldType,
+		},
+		{
+			name:   "unknown issue type field",
+			fields: map[string]CustomField{"team": {ID: "customfield_10001", Type: FieldTypeOption}},
+			types:  map[string]IssueTypeDefaults{"Bug": {Fields: map[string]string{"area": "Backend"}}},
+			want:   errFieldUnknown,
+		},
+		{
+			name:   "unknown direction",
+			fields: map[string]CustomField{"team": {ID: "customfield_10001", Type: FieldTypeOption, Direction: "up"}},
+			want:   errFieldDirection,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Config{Fields: tt.fields, IssueTypes: tt.types}.Validate()
+			if !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want: %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFieldValues(t *testing.T) {
+	config := Config{
+		Fields: map[string]CustomField{
+			"team":     {ID: "customfield_10001", Type: FieldTypeOption},
+			"size":     {ID: "customfield_10002", Type: FieldTypeNumber},
+			"resolved": {ID: "customfield_10003", Type: FieldTypeString, Direction: FieldDirectionRead},
+		},
+	}
+	tests := []struct {
+		name    string
+		values  []string
+		want    map[string]any
+		wantErr error
+	}{
+		{
+			name:   "option",
+			values: []string{"team=Platform Team"},
+			want:   map[string]any{"team": "Platform Team"},
+		},
+		{
+			name:    "missing value",
+			values:  []string{"team"},
+			wantErr: errFieldValue,
+		},
+		{
+			name:    "unknown",
+			values:  []string{"owner=me"},
+			wantErr: errFieldUnknown,
+		},
+		{
+			name:    "read-only",
+			values:  []string{"resolved=yes"},
+			wantErr: errFieldReadOnly,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := config.parseFieldValues(tt.values)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want: %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestNewIssueFieldDefaults(t *testing.T) {
+	j := Jira{
+		config: Config{
+			Fields: map[string]CustomField{
+				"team": {ID: "customfield_10001", Type: FieldTypeOption, Default: "Platform"},
+				"area": {ID: "customfield_10002", Type: FieldTypeOption, Default: "Backend"},
+			},
+		},
+	}
+	issue := j.newIssue("Story", "Summary", "", map[string]any{
+		"customfield_10002": map[string]string{"value": "Frontend"},
+	})
+	want := map[string]any{
+		"customfield_10001": map[string]string{"value": "Platform"},
+		"customfield_10002": map[string]string{"value": "Frontend"},
+	}
+	if diff := cmp.Diff(map[string]any(issue.Fields.Unknowns), want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestNewIssueTypeDefaults(t *testing.T) {
+	j := Jira{
+		config: Config{
+			Labels:     []string{"team"},
+			Components: []string{"backend"},
+			Fields: map[string]CustomField{
+				"team": {ID: "customfield_10001", Type: FieldTypeOption, Default: "Platform"},
+			},
+			IssueTypes: map[string]IssueTypeDefaults{
+				"Bug": {
+					Labels:     []string{"bug", "team"},
+					Components: []string{"triage"},
+					Priority:   "High",
+					Fields:     map[string]string{"team": "Support"},
+				},
+			},
+		},
+	}
+	type fields struct {
+		Labels     []string
+		Components []*jira.Component
+		Priority   *jira.Priority
+		Unknowns   map[string]any
+	}
+	tests := []struct {
+		issueType string
+		want      fields
+	}{
+		{
+			issueType: "Story",
+			want: fields{
+				Labels:     []string{"team"},
+				Components: []*jira.Component{{Name: "backend"}},
+				Unknowns:   map[string]any{"customfield_10001": map[string]string{"value": "Platform"}},
+			},
+		},
+		{
+			issueType: "Bug",
+			want: fields{
+				Labels:     []string{"team", "bug"},
+				Components: []*jira.Component{{Name: "backend"}, {Name: "triage"}},
+				Priority:   &jira.Priority{Name: "High"},
+				Unknowns:   map[string]any{"customfield_10001": map[string]string{"value": "Support"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.issueType, func(t *testing.T) {
+			issue := j.newIssue(tt.issueType, "Summary", "", nil)
+			got := fields{
+				Labels:     issue.Fields.Labels,
+				Components: issue.Fields.Components,
+				Priority:   issue.Fields.Priority,
+				Unknowns:   issue.Fields.Unknowns,
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
@@ -2,53 +2,497 @@ package kong
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
-	"path"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"text/tabwriter"
 	"time"
+
+	"github.com/gofrs/flock"
 )
 
-const refreshRate = 10 * time.Second
+const (
+	refreshRate = 10 * time.Second
+
+	// syncTimeout is the age after which a sync marker is considered left
+	// behind by a daemon that did not exit cleanly.
+	syncTimeout  = 2 * time.Minute
+	syncWaitTime = 15 * time.Second
+	syncPollRate = 250 * time.Millisecond
+
+	// daemonWaitTime bounds waiting for a daemon to come up or shut down
+	daemonWaitTime = 10 * time.Second
+)
+
+var (
+	errDaemonRunning    = errors.New("daemon is already running")
+	errDaemonNotRunning = errors.New("daemon is not running")
+	errDaemonStart      = errors.New("daemon did not start")
+	errDaemonStop       = errors.New("daemon did not stop")
+)
 
 // Daemon is an abstraction for the background process which refreshes the Jira
 // data. It exists to share access to the Jira client and data between methods.
-type Daemon struct{}
+type Daemon struct {
+	scheduler *scheduler
+	location  *time.Location
+	status    DaemonStatus
+
+	// githubSyncedAt is the time statuses were last mirrored to GitHub
+	githubSyncedAt time.Time
+
+	// refreshes receives requests to sync right away which are answered
+	// with the result of the sync
+	refreshes chan chan error
+
+	// mu guards the content of the data file served over the socket
+	mu    sync.Mutex
+	cache cachedFile[[]byte]
+}
 
 // NewDaemon returns a new instance of Daemon.
 func NewDaemon() (*Daemon, error) {
+	session.daemon = true
 	if _, err := LoadData(); err != nil {
 		return nil, err
 	}
-	return &Daemon{}, nil
+	config, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	location, err := config.Location()
+	if err != nil {
+		return nil, err
+	}
+	return &Daemon{
+		scheduler: newScheduler(config.QuietHours),
+		location:  location,
+		status:    DaemonStatus{PID: os.Getpid(), StartedAt: time.Now()},
+		refreshes: make(chan chan error),
+	}, nil
 }
 
 // Run executes Kong as background process to periodically fetch Jira data and
-// write it to disk for fast retrieval by the CLI.
+// write it to disk for fast retrieval by the CLI. The refresh interval adapts
+// to Jira response times, rate limits and the configured quiet hours. It
+// returns once the context is canceled.
 func (d *Daemon) Run(ctx context.Context) {
+	d.writeStatus()
+	var refreshed chan error
 	for {
-		if err := d.loop(ctx); err != nil {
+		startedAt := time.Now()
+		var delay time.Duration
+		schedule := func(err error) time.Duration {
+			delay = d.scheduler.next(time.Now().In(d.location), time.Since(startedAt), err)
+			return delay
+		}
+		err := d.loop(ctx, schedule)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
 			fmt.Fprint(os.Stderr, err.Error())
 		}
-		time.Sleep(refreshRate)
+		d.status.record(err, time.Now())
+		d.writeStatus()
+		if refreshed != nil {
+			refreshed <- err
+			refreshed = nil
+		}
+		// failed syncs are scheduled once the error is known
+		if delay == 0 {
+			schedule(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		case refreshed = <-d.refreshes:
+		}
 	}
 }
 
-func (d *Daemon) loop(ctx context.Context) error {
+func (d *Daemon) writeStatus() {
+	if err := d.status.writeFile(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+}
+
+// loop syncs the data once. Schedule computes the delay until the next sync,
+// it is called before the data is written so that the data records how long
+// it stays fresh.
+func (d *Daemon) loop(ctx context.Context, schedule func(err error) time.Duration) error {
 	// TODO: lock file during whole loop
 	data, err := LoadData()
 	if err != nil {
 		return err
 	}
-	if err := data.load(ctx); err != nil {
+	endSync, err := beginSync()
+	if err != nil {
+		return err
+	}
+	defer endSync()
+
+	// switch projects before the snapshot to not report the issues of
+	// another project as activity
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	data.Use(config.Project)
+
+	// issues created since the last sync are reported as created, the
+	// overlap covers issues which were not searchable yet at the last sync
+	prev := data.snapshot()
+	since := time.Unix(data.Timestamp, 0).Add(-syncOverlap)
+	if err := data.refresh(ctx, time.Now()); err != nil {
 		return err
 	}
+
+	// other projects failing to sync must not hold back the project in use
+	if err := data.loadProjects(ctx, config.SyncedProjects()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	if err := data.loadWorklogs(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	if config.GitHubSync.Enabled() && time.Since(d.githubSyncedAt) >= githubSyncRate {
+		if err := data.syncGitHub(ctx, config.GitHubSync); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		d.githubSyncedAt = time.Now()
+	}
+	data.recordActivity(prev, since, time.Now())
+	data.recordSnapshot(time.Now().In(d.location))
+	data.recordFlow(time.Now().In(d.location))
+	data.RefreshInterval = schedule(nil)
+
+	// kong use may have switched the project during the sync, user state is
+	// kept in the state file which the data file does not overwrite
+	if current, err := LoadConfig(); err == nil {
+		data.Use(current.Project)
+	}
 	// write file under file lock
-	return data.WriteFile()
+	if err := data.WriteFile(); err != nil {
+		return err
+	}
+	return pruneEpicChanges(time.Unix(data.Timestamp, 0))
+}
+
+// cached returns the content of the data file which is only read again if the
+// file changed since.
+func (d *Daemon) cached() ([]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.cache.load(cachePath(), func() ([]byte, error) {
+		unlock, err := lockCache()
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+		return os.ReadFile(cachePath())
+	})
+}
+
+// invalidate drops the content of the data file held by the daemon.
+func (d *Daemon) invalidate() {
+	d.mu.Lock()
+	d.cache.reset()
+	d.mu.Unlock()
 }
 
-func filepath() string {
+// refresh asks the daemon to sync right away and waits for the sync to finish.
+func (d *Daemon) refresh(ctx context.Context) error {
+	refreshed := make(chan error, 1)
+	select {
+	case d.refreshes <- refreshed:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-refreshed:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// cachePath returns the path of the data file written by the daemon. It
+// defaults to the user cache directory and falls back to the temporary
+// directory. Profiles other than the default profile use their own file.
+func cachePath() string {
 	if path := os.Getenv("KONG_CACHE"); path != "" {
-		return path
+		return profilePath(path, CurrentProfile())
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return profilePath(filepath.Join(dir, "kong"), CurrentProfile())
+}
+
+func syncFilepath() string {
+	return cachePath() + ".sync"
+}
+
+// beginSync marks a daemon sync as in progress so that CLI commands can wait
+// for it instead of fetching the same data. The returned function removes the
+// marker again.
+func beginSync() (func(), error) {
+	path := syncFilepath()
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		return nil, err
+	}
+	return func() {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			fmt.Fprint(os.Stderr, err)
+		}
+	}, nil
+}
+
+// syncInProgress reports whether the daemon is currently syncing.
+func syncInProgress(now time.Time) bool {
+	info, err := os.Stat(syncFilepath())
+	if err != nil {
+		return false
+	}
+	return info.ModTime().After(now.Add(-syncTimeout))
+}
+
+// waitForSync blocks until the in-progress daemon sync has finished or the
+// timeout has passed. It reports whether the sync has finished.
+func waitForSync(timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for syncInProgress(time.Now()) {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(syncPollRate)
+	}
+	return true
+}
+
+// DaemonStatus is written by the daemon after every refresh so that other
+// processes can report on it. Errors counts the refreshes which failed since
+// the last successful one.
+type DaemonStatus struct {
+	PID         int       `json:"pid"`
+	StartedAt   time.Time `json:"startedAt"`
+	LastRefresh time.Time `json:"lastRefresh,omitempty"`
+	LastError   string    `json:"lastError,omitempty"`
+	LastErrorAt time.Time `json:"lastErrorAt,omitempty"`
+	Errors      int       `json:"errors"`
+
+	// Running is determined from the daemon lock rather than the file.
+	Running bool `json:"-"`
+}
+
+func (s *DaemonStatus) record(err error, now time.Time) {
+	if err != nil {
+		s.LastError = err.Error()
+		s.LastErrorAt = now
+		s.Errors++
+		return
+	}
+	s.LastRefresh = now
+	s.Errors = 0
+}
+
+func daemonStatusPath() string {
+	return cachePath() + ".daemon"
+}
+
+func daemonLockPath() string {
+	return cachePath() + ".daemon.lock"
+}
+
+func daemonLogPath() string {
+	return cachePath() + ".log"
+}
+
+func (s DaemonStatus) writeFile() error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(daemonStatusPath(), b, 0o600)
+}
+
+// LockDaemon ensures that only one daemon runs per profile. The lock is held
+// until the returned function is called or the process exits.
+func LockDaemon() (func(), error) {
+	path := daemonLockPath()
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, err
+	}
+	lock := flock.New(path)
+	locked, err := lock.TryLock()
+	if err != nil {
+		return nil, fmt.Errorf("LockDaemon: %w", err)
+	}
+	if !locked {
+		status, _ := readDaemonStatus()
+		return nil, fmt.Errorf("%w (pid %d)", errDaemonRunning, status.PID)
+	}
+	return func() {
+		if err := lock.Unlock(); err != nil {
+			fmt.Fprint(os.Stderr, err)
+		}
+	}, nil
+}
+
+// daemonRunning reports whether a daemon holds the daemon lock.
+func daemonRunning() bool {
+	unlock, err := LockDaemon()
+	if err != nil {
+		return errors.Is(err, errDaemonRunning)
+	}
+	unlock()
+	return false
+}
+
+func readDaemonStatus() (DaemonStatus, error) {
+	var status DaemonStatus
+	b, err := os.ReadFile(daemonStatusPath())
+	if os.IsNotExist(err) {
+		return status, nil
+	}
+	if err != nil {
+		return status, err
+	}
+	if err := json.Unmarshal(b, &status); err != nil {
+		return status, fmt.Errorf("readDaemonStatus: %w", err)
+	}
+	return status, nil
+}
+
+// ReadDaemonStatus returns the status written by the most recent daemon.
+func ReadDaemonStatus() (DaemonStatus, error) {
+	status, err := readDaemonStatus()
+	status.Running = daemonRunning()
+	return status, err
+}
+
+// StartDaemon starts the daemon in the background using the profile of the
+// current process and waits until it holds the daemon lock. Its output is
+// appended to the log file next to the data file.
+func StartDaemon() error {
+	if daemonRunning() {
+		return errDaemonRunning
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("StartDaemon: %w", err)
+	}
+	log, err := os.OpenFile(daemonLogPath(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("StartDaemon: %w", err)
+	}
+	defer log.Close()
+
+	cmd := exec.Command(executable, "daemon", "--profile", CurrentProfile())
+	cmd.Stdout = log
+	cmd.Stderr = log
+	// ignored signals are inherited, the daemon must outlive the terminal
+	signal.Ignore(syscall.SIGHUP)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("StartDaemon: %w", err)
+	}
+	exited := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(exited)
+	}()
+	deadline := time.Now().Add(daemonWaitTime)
+	for !daemonRunning() {
+		select {
+		case <-exited:
+			return fmt.Errorf("%w, see %s", errDaemonStart, daemonLogPath())
+		case <-time.After(syncPollRate):
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w, see %s", errDaemonStart, daemonLogPath())
+		}
+	}
+	return nil
+}
+
+// StopDaemon asks the running daemon to shut down and waits until it has
+// released the daemon lock. Platforms without termination signals kill it.
+func StopDaemon() error {
+	status, err := ReadDaemonStatus()
+	if err != nil {
+		return err
+	}
+	if !status.Running {
+		return errDaemonNotRunning
+	}
+	process, err := os.FindProcess(status.PID)
+	if err != nil {
+		return fmt.Errorf("StopDaemon: %w", err)
+	}
+	if err := process.Signal(syscall.SIGTERM); err != nil {
+		if err := process.Kill(); err != nil {
+			return fmt.Errorf("StopDaemon: %w", err)
+		}
+	}
+	deadline := time.Now().Add(daemonWaitTime)
+	for daemonRunning() {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w (pid %d)", errDaemonStop, status.PID)
+		}
+		time.Sleep(syncPollRate)
+	}
+	return nil
+}
+
+// RestartDaemon stops the daemon if it is running and starts it again.
+func RestartDaemon() error {
+	if err := StopDaemon(); err != nil && !errors.Is(err, errDaemonNotRunning) {
+		return err
+	}
+	return StartDaemon()
+}
+
+// PrintDaemonStatus writes whether the daemon is running, the time of the
+// last successful refresh, failed refreshes and the age of the data file.
+func PrintDaemonStatus(output io.Writer, status DaemonStatus, now time.Time) error {
+	w := tabwriter.NewWriter(output, 1, 1, 1, ' ', 0)
+	if status.Running {
+		fmt.Fprintf(w, "Daemon\t-\trunning (pid %d, since %s)\n", status.PID, formatTimestamp(status.StartedAt))
+	} else {
+		fmt.Fprintf(w, "Daemon\t-\tstopped\n")
+	}
+	lastRefresh := "never"
+	if !status.LastRefresh.IsZero() {
+		lastRefresh = fmt.Sprintf("%s (%s ago)", formatTimestamp(status.LastRefresh), formatAge(now.Sub(status.LastRefresh)))
+	}
+	fmt.Fprintf(w, "Last refresh\t-\t%s\n", lastRefresh)
+	if status.Errors > 0 {
+		fmt.Fprintf(w, "Refresh errors\t-\t%d, last at %s: %s\n", status.Errors, formatTimestamp(status.LastErrorAt), firstLine(status.LastError))
+	} else {
+		fmt.Fprintf(w, "Refresh errors\t-\tnone\n")
+	}
+	cacheAge := "no data file"
+	if info, err := os.Stat(cachePath()); err == nil {
+		cacheAge = formatAge(now.Sub(info.ModTime()))
+	}
+	fmt.Fprintf(w, "Cache age\t-\t%s\n", cacheAge)
+	return w.Flush()
+}
+
+// formatAge formats the duration in hours and minutes, or seconds if the
+// duration is less than a minute.
+func formatAge(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
 	}
-	return path.Join(os.TempDir(), "kong")
+	return formatTimeSpent(d)
 }
@@ -0,0 +1,84 @@
+package kong
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWaitForSync(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+
+	if syncInProgress(time.Now()) {
+		t.Fatal("got sync in progress before sync started")
+	}
+	endSync, err := beginSync()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !syncInProgress(time.Now()) {
+		t.Fatal("got no sync in progress after sync started")
+	}
+	if syncInProgress(time.Now().Add(syncTimeout + time.Second)) {
+		t.Error("got sync in progress after sync timeout")
+	}
+	if waitForSync(0) {
+		t.Error("got finished sync, want: timeout")
+	}
+	endSync()
+	if !waitForSync(0) {
+		t.Error("got timeout, want: finished sync")
+	}
+}
+
+func TestLockDaemon(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+
+	unlock, err := LockDaemon()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !daemonRunning() {
+		t.Error("got daemon not running while locked")
+	}
+	if _, err := LockDaemon(); !errors.Is(err, errDaemonRunning) {
+		t.Errorf("got %v, want: %v", err, errDaemonRunning)
+	}
+	unlock()
+	if daemonRunning() {
+		t.Error("got daemon running after unlock")
+	}
+	if err := StopDaemon(); !errors.Is(err, errDaemonNotRunning) {
+		t.Errorf("got %v, want: %v", err, errDaemonNotRunning)
+	}
+}
+
+func TestPrintDaemonStatus(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, time.Local)
+	status := DaemonStatus{PID: 42, StartedAt: now.Add(-time.Hour)}
+	status.record(nil, now.Add(-90*time.Minute))
+	status.record(errors.New("503 Service Unavailable\nretry later"), now.Add(-time.Minute))
+	status.record(errors.New("503 Service Unavailable"), now.Add(-30*time.Second))
+	if err := status.writeFile(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadDaemonStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := PrintDaemonStatus(&buf, got, now); err != nil {
+		t.Fatal(err)
+	}
+	want := "Daemon         - stopped\n" +
+		"Last refresh   - 2024-03-04 08:30 (1h 30m ago)\n" +
+		"Refresh errors - 2, last at 2024-03-04 09:59: 503 Service Unavailable\n" +
+		"Cache age      - no data file\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want: %q", buf.String(), want)
+	}
+}
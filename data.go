@@ -1,41 +1,95 @@
 package kong
 
 import (
-	"bytes"
 	"context"
-	"encoding/gob"
+	"errors"
 	"fmt"
-	"io"
 	"os"
+	"sync"
 	"time"
 
-	"github.com/gofrs/flock"
 	"golang.org/x/sync/errgroup"
 )
 
 const (
-	expiry         = refreshRate * 2
-	backlogAcronym = "ice"
+	expiryFactor      = 2
+	backlogAcronym    = "ice"
+	nextSprintAcronym = "next"
 )
 
+// Section identifies a part of Data which can be loaded independently of the
+// others. Sections are combined into a set using bitwise OR.
+type Section int
+
+// Sections of data fetched from Jira.
+const (
+	SectionIssues Section = 1 << iota
+	SectionEpics
+	SectionInitiatives
+	SectionSprintIssues
+	SectionSprints
+
+	SectionAll = SectionIssues | SectionEpics | SectionInitiatives | SectionSprintIssues | SectionSprints
+)
+
+func (s Section) String() string {
+	switch s {
+	case SectionIssues:
+		return "issues"
+	case SectionEpics:
+		return "epics"
+	case SectionInitiatives:
+		return "initiatives"
+	case SectionSprintIssues:
+		return "sprint issues"
+	case SectionSprints:
+		return "sprints"
+	}
+	return fmt.Sprintf("Section(%d)", int(s))
+}
+
 // Data contains all Jira data into one type to easily access any relevant
 // information from the CLI but also to serialize and deserialize the data from
 // disk.
 type Data struct {
 	jira Jira
 
-	Timestamp        int64
-	Issues           Issues
-	IssueByKey       map[string]Issue
-	Initiatives      Issues
-	Epics            Issues
-	SprintIssues     Issues
-	BoardID          int
-	Sprints          Sprints
-	SprintsByName    map[string]Sprint
-	ActiveSprint     Sprint
-	Transitions      []Transition
-	LastIssueCreated string
+	// bodiesPending is set if the issue bodies were skipped when reading the
+	// data file, see LoadBodies.
+	bodiesPending bool
+
+	// timings records the time of each loader of a blocking load
+	timings *loadTimings
+
+	Timestamp         int64
+	FullSyncTimestamp int64
+	Issues            Issues
+	IssueByKey        map[string]Issue
+	Initiatives       Issues
+	Epics             Issues
+	EpicProgress      map[string]Progress
+	Worklogs          Worklogs
+	SprintIssues      Issues
+	BoardID           int
+	NoBoard           bool
+	Sprints           Sprints
+	SprintsByName     map[string]Sprint
+	ActiveSprint      Sprint
+	Transitions       []Transition
+	User              User
+	Activity          Activity
+	Snapshots         []Snapshot
+	Flow              []FlowSnapshot
+	RefreshInterval   time.Duration
+
+	// State is read from the state file rather than the data file, the
+	// daemon writes the data without it.
+	State
+
+	// Project is the project the sections above belong to, the sections of
+	// other projects are kept in Projects.
+	Project  string
+	Projects map[string]ProjectData
 }
 
 // NewData returns a new instance of Data.
@@ -46,52 +100,35 @@ func NewData() Data {
 	}
 }
 
-// Stale indicates if the data read from disk is out of date.
+// Stale indicates if the data read from disk is out of date. The expiry is
+// based on the refresh interval the daemon scheduled when writing the data.
 func (d Data) Stale() bool {
 	timestamp := time.Unix(d.Timestamp, 0)
+	expiry := expiryFactor * maxDuration(refreshRate, d.RefreshInterval)
 	return timestamp.Before(time.Now().Add(-expiry))
 }
 
 // LoadData parses the Jira state from disk or returns an error if it is out of
 // date.
 func LoadData() (Data, error) {
-	var err error
-	data := NewData()
+	data, err := ReadData()
 
-	if data.isMissing() {
+	// wait for the daemon to finish its sync rather than fetching twice
+	if (err == ErrDataMissing || err == nil && data.Stale()) && syncInProgress(time.Now()) {
+		fmt.Fprintln(os.Stderr, "Waiting for daemon to finish syncing...")
+		if waitForSync(syncWaitTime) {
+			data, err = ReadData()
+		}
+	}
+	if err == ErrDataMissing {
 		printDaemonWarning()
 		if err := data.initJira(); err != nil {
 			return data, err
 		}
 		return data, nil
 	}
-
-	// read file under file lock
-	path := filepath()
-	flock := flock.New(path)
-	if err = flock.Lock(); err != nil {
-		return data, nil
-	}
-	defer func() {
-		err = flock.Unlock()
-	}()
-
-	b, err := os.ReadFile(path)
 	if err != nil {
-		return data, fmt.Errorf("ReadFile: %w", err)
-	}
-	decoder := gob.NewDecoder(bytes.NewBuffer(b))
-	if err = decoder.Decode(&data); err != nil {
-		if err == io.ErrUnexpectedEOF {
-			// file corrupt? Deleting
-			fmt.Fprintln(os.Stderr, "file potentially corrupt, deleting", path)
-			if err := os.Remove(path); err != nil {
-				return Data{}, err
-			}
-			printDaemonWarning()
-			return Data{}, nil
-		}
-		return data, fmt.Errorf("gob.Decode(%s): %w", path, err)
+		return data, err
 	}
 
 	// report if data is stale but return current data anyway
@@ -104,55 +141,188 @@ func LoadData() (Data, error) {
 	return data, nil
 }
 
-// LoadDataBlocking will fetch all necessary data by synchronously calling the
-// Jira API.
+// ReadData parses the Jira state from disk without contacting Jira, even if
+// the data is stale. It returns ErrDataMissing if there is no data on disk. The
+// data is only decoded again if the file changed since the last call. The
+// state is read from the state file, also if there is no data on disk.
+func ReadData() (Data, error) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	data, err := session.data.load(cachePath(), readData)
+	state, stateErr := session.state.load(statePath(), readState)
+	if stateErr != nil {
+		return data, stateErr
+	}
+	data.State = state
+	data.applyEpicChanges()
+	data.applyLoggedWorklogs()
+	return data, err
+}
+
+func readData() (Data, error) {
+	data := NewData()
+	if data.isMissing() {
+		return data, ErrDataMissing
+	}
+
+	// prefer the data the daemon holds in memory over decoding the file
+	if data, err := readDaemonData(); err == nil {
+		return data, nil
+	}
+
+	// read file under file lock
+	path := cachePath()
+	unlock, err := lockCache()
+	if err != nil {
+		return data, nil
+	}
+	defer unlock()
+
+	f, err := os.Open(path)
+	if err != nil {
+		return data, fmt.Errorf("Open: %w", err)
+	}
+	data, err = readCache(f, false)
+	f.Close()
+	if errors.Is(err, errCacheCorrupt) {
+		// keep the corrupt file around for inspection and start over
+		fmt.Fprintln(os.Stderr, "file potentially corrupt, moving to", path+".corrupt")
+		if err := os.Rename(path, path+".corrupt"); err != nil {
+			return NewData(), err
+		}
+		return NewData(), ErrDataMissing
+	}
+	if err != nil {
+		return data, fmt.Errorf("readData(%s): %w", path, err)
+	}
+	return data, nil
+}
+
+// LoadDataBlocking will fetch the given sections of data by synchronously
+// calling the Jira API.
 //
-// This method should be called when all data is needed to perform operations,
-// for instance to use the editor when the daemon is not running.
-func LoadDataBlocking(ctx context.Context) (Data, error) {
+// This method should be called when data is needed to perform operations, for
+// instance to use the editor when the daemon is not running.
+func LoadDataBlocking(ctx context.Context, sections Section) (Data, error) {
 	data, err := LoadData()
 	if err != nil {
 		return data, err
 	}
-	return data, data.load(ctx)
+	return data, data.load(ctx, sections)
 }
 
-func (d *Data) load(ctx context.Context) error {
+func (d *Data) load(ctx context.Context, sections Section) error {
 	defer func(startedAt time.Time) {
 		fmt.Println("load time", time.Since(startedAt))
 	}(time.Now())
-	if err := d.initJira(); err != nil {
+	if err := d.connect(); err != nil {
 		return err
 	}
+	d.timings = &loadTimings{}
+	return d.loadSections(ctx, sections)
+}
 
-	loaders := []func(ctx context.Context) error{
-		d.loadIssues,
-		d.loadEpics,
-		d.loadInitiatives,
-		d.loadBoardID,
-		d.loadSprintIssues,
-		d.loadSprints,
+// connect initializes the Jira client for the project in use.
+func (d *Data) connect() error {
+	// the data is written back after fetching, fetched issues keep the
+	// bodies they were fetched with
+	if err := d.LoadBodies(); err != nil {
+		return err
+	}
+	if err := d.initJira(); err != nil {
+		return err
 	}
+	jira, err := d.jira.refreshSelf(time.Now())
+	if err != nil {
+		return err
+	}
+	d.jira = jira
+	d.User = d.jira.self
 
-	// load data concurrently
-	g, _ := errgroup.WithContext(ctx)
-	for _, f := range loaders {
-		f := f
-		g.Go(func() error {
-			return f(ctx)
-		})
+	// the project may have been switched since the Jira client was created
+	config, err := LoadConfig()
+	if err != nil {
+		return err
 	}
+	d.Use(config.Project)
+	d.jira = d.jira.withProject(d.Project)
+	return nil
+}
 
-	// wait until all loaders have finished
-	if err := g.Wait(); err != nil {
+// sectionLoader fetches a section and returns the update of the data. Loaders
+// run concurrently and must not write to the data, the updates are applied
+// one after another once all loaders have finished.
+type sectionLoader func(ctx context.Context) (func(d *Data), error)
+
+// loadSections fetches the given sections of the project of the Jira client.
+func (d *Data) loadSections(ctx context.Context, sections Section) error {
+	loaders := map[Section]sectionLoader{
+		SectionIssues:       d.fetchIssues,
+		SectionEpics:        d.fetchEpics,
+		SectionInitiatives:  d.fetchInitiatives,
+		SectionSprintIssues: d.fetchSprintIssues,
+		SectionSprints:      d.fetchSprints,
+	}
+	if err := d.runLoaders(ctx, sections, loaders); err != nil {
 		return err
 	}
 
-	// refresh timestamp
-	d.Timestamp = time.Now().Unix()
+	// only refresh timestamp if no section is left out of date
+	if sections == SectionAll {
+		d.Timestamp = time.Now().Unix()
+	}
 	return nil
 }
 
+// snapshot returns all currently known issues by key to compute the activity
+// after the next sync.
+func (d Data) snapshot() map[string]Issue {
+	issues := make(map[string]Issue, len(d.IssueByKey)+len(d.SprintIssues)+len(d.Epics))
+	for _, list := range []Issues{d.Issues, d.SprintIssues, d.Epics} {
+		for _, issue := range list {
+			issues[issue.Key] = issue
+		}
+	}
+	return issues
+}
+
+// LookupIssue returns the cached issue, epic or initiative with the key and
+// otherwise fetches it from Jira.
+func (d Data) LookupIssue(ctx context.Context, key string) (Issue, error) {
+	if issue, ok := d.snapshot()[key]; ok {
+		return issue, nil
+	}
+	if issue, ok := d.Initiatives.find(key); ok {
+		return issue, nil
+	}
+	j, err := NewJira()
+	if err != nil {
+		return Issue{}, err
+	}
+	issues, err := j.ListIssuesByKey(ctx, []string{key})
+	if err != nil {
+		return Issue{}, err
+	}
+	if len(issues) == 0 {
+		return Issue{}, fmt.Errorf("%w: %s", errUnknownIssue, key)
+	}
+	return issues[0], nil
+}
+
+// recordActivity appends the events observed since the given snapshot, taken
+// at the last sync, to the activity stream.
+func (d *Data) recordActivity(prev map[string]Issue, since, now time.Time) {
+	// the first sync has nothing to compare against
+	if len(prev) == 0 {
+		return
+	}
+	var issues Issues
+	for _, list := range []Issues{d.Issues, d.SprintIssues, d.Epics} {
+		issues = append(issues, list...)
+	}
+	d.Activity = d.Activity.add(diffIssues(prev, issues, since, now)...)
+}
+
 func (d *Data) initJira() error {
 	jira, err := NewJira()
 	if err != nil {
@@ -162,69 +332,195 @@ func (d *Data) initJira() error {
 	return nil
 }
 
-func (d *Data) loadIssues(ctx context.Context) error {
-	issues, err := d.jira.ListIssues(ctx, d.jira.config.Project)
-	if err != nil {
+// runLoaders fetches the given sections concurrently and applies the updates
+// if all of them succeeded.
+func (d *Data) runLoaders(ctx context.Context, sections Section, loaders map[Section]sectionLoader) error {
+	var (
+		mu      sync.Mutex
+		updates []func(d *Data)
+	)
+	g, ctx := errgroup.WithContext(ctx)
+	for section, load := range loaders {
+		if sections&section == 0 {
+			continue
+		}
+		section, load := section, load
+		g.Go(func() error {
+			startedAt := time.Now()
+			update, err := load(ctx)
+			d.timings.record(section.String(), startedAt)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			updates = append(updates, update)
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	// wait until all loaders have finished
+	if err := g.Wait(); err != nil {
 		return err
 	}
-	for _, issue := range issues {
-		d.IssueByKey[issue.Key] = issue
+	for _, update := range updates {
+		update(d)
 	}
-	d.Issues = issues
 	return nil
 }
 
-func (d *Data) loadEpics(ctx context.Context) error {
-	epics, err := d.jira.ListEpics(ctx, d.jira.config.Project)
+// loadSection fetches a single section and applies it.
+func (d *Data) loadSection(ctx context.Context, load sectionLoader) error {
+	update, err := load(ctx)
 	if err != nil {
 		return err
 	}
-	d.Epics = epics
+	update(d)
 	return nil
 }
 
-func (d *Data) loadInitiatives(ctx context.Context) error {
-	initiatives, err := d.jira.ListInitiatives(ctx, d.jira.config.Project)
+func (d *Data) fetchIssues(ctx context.Context) (func(d *Data), error) {
+	issues, err := d.jira.ListIssues(ctx, d.jira.config.Project)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	d.Initiatives = initiatives
-	return nil
+	return func(d *Data) {
+		d.Issues = issues
+		d.IssueByKey = withIssuesByKey(d.IssueByKey, issues)
+	}, nil
 }
 
-func (d *Data) loadBoardID(ctx context.Context) error {
-	boardID, err := d.jira.GetBoardID(d.jira.config.Project)
+func (d *Data) fetchEpics(ctx context.Context) (func(d *Data), error) {
+	epics, err := d.jira.ListEpics(ctx, d.jira.config.Project)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	d.BoardID = boardID
-	return nil
+
+	// aggregate child issues to show the progress of each epic, archived
+	// epics are not listed
+	var keys []string
+	for _, epic := range d.WithoutArchived(epics) {
+		keys = append(keys, epic.Key)
+	}
+	children, err := d.jira.ListEpicChildren(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+	progress := NewProgress(children)
+	return func(d *Data) {
+		d.Epics = epics
+		d.EpicProgress = progress
+	}, nil
 }
 
-func (d *Data) loadSprintIssues(ctx context.Context) error {
+func (d *Data) fetchInitiatives(ctx context.Context) (func(d *Data), error) {
+	initiatives, err := d.jira.ListInitiatives(ctx, d.jira.config.Project)
+	if err != nil {
+		return nil, err
+	}
+	return func(d *Data) {
+		d.Initiatives = initiatives
+	}, nil
+}
+
+func (d *Data) fetchSprintIssues(ctx context.Context) (func(d *Data), error) {
 	issues, err := d.jira.ListSprintIssues(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	d.SprintIssues = issues
-	return nil
+	return func(d *Data) {
+		d.SprintIssues = issues
+	}, nil
 }
 
-func (d *Data) loadSprints(ctx context.Context) error {
-	if d.BoardID == 0 {
-		if err := d.loadBoardID(ctx); err != nil {
-			return nil
+func (d *Data) fetchSprints(ctx context.Context) (func(d *Data), error) {
+	boardID := d.BoardID
+	if boardID == 0 {
+		startedAt := time.Now()
+		id, err := d.jira.GetBoardID(d.jira.config.Project)
+		d.timings.record("board", startedAt)
+		if errors.Is(err, ErrNoBoard) {
+			// keep syncing the other sections, the sprint commands report
+			// the missing board
+			return func(d *Data) {
+				d.NoBoard = true
+				d.Sprints = nil
+			}, nil
 		}
+		if err != nil {
+			return nil, err
+		}
+		boardID = id
 	}
-	sprints, err := d.jira.ListSprints(d.BoardID)
+	sprints, err := d.jira.ListSprints(boardID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	for _, sprint := range sprints {
-		d.SprintsByName[sprint.Name] = sprint
+	return func(d *Data) {
+		d.BoardID = boardID
+		d.NoBoard = false
+		d.Sprints = sprints
+		sprintsByName := make(map[string]Sprint, len(d.SprintsByName)+len(sprints))
+		for name, sprint := range d.SprintsByName {
+			sprintsByName[name] = sprint
+		}
+		for _, sprint := range sprints {
+			sprintsByName[sprint.Name] = sprint
+		}
+		d.SprintsByName = sprintsByName
+	}, nil
+}
+
+// withIssuesByKey returns a copy of the index with the given issues added.
+// The index is copied rather than updated since the data may be shared with
+// the session cache and read concurrently.
+func withIssuesByKey(byKey map[string]Issue, issues Issues) map[string]Issue {
+	result := make(map[string]Issue, len(byKey)+len(issues))
+	for key, issue := range byKey {
+		result[key] = issue
+	}
+	for _, issue := range issues {
+		result[issue.Key] = issue
+	}
+	return result
+}
+
+// applyEpicChanges moves the cached issues to the epics they were moved to
+// since the data was synced.
+func (d *Data) applyEpicChanges() {
+	synced := time.Unix(d.Timestamp, 0)
+	for key, change := range d.EpicChanges {
+		if !change.At.Before(synced) {
+			d.moveToEpic(key, change.EpicKey)
+		}
+	}
+}
+
+// moveToEpic moves the cached issue to the epic. An empty epic key removes
+// the issue from its epic.
+func (d *Data) moveToEpic(key, epicKey string) {
+	move := func(issues Issues) (Issues, bool) {
+		moved := make(Issues, len(issues))
+		var found bool
+		for i, issue := range issues {
+			if issue.Key == key {
+				issue.EpicKey = epicKey
+				found = true
+			}
+			moved[i] = issue
+		}
+		return moved, found
+	}
+	if issues, ok := move(d.Issues); ok {
+		d.Issues = issues
+	}
+	if issues, ok := move(d.SprintIssues); ok {
+		d.SprintIssues = issues
+	}
+	if issue, ok := d.IssueByKey[key]; ok {
+		issue.EpicKey = epicKey
+		d.IssueByKey = withIssuesByKey(d.IssueByKey, Issues{issue})
 	}
-	d.Sprints = sprints
-	return nil
 }
 
 // GetIssues returns a list of issues. If the data on disk is out of date it
@@ -233,7 +529,7 @@ func (d Data) GetIssues(ctx context.Context) (Issues, error) {
 	if !d.Stale() {
 		return d.Issues, nil
 	}
-	if err := d.loadIssues(ctx); err != nil {
+	if err := d.loadSection(ctx, d.fetchIssues); err != nil {
 		return nil, err
 	}
 	return d.Issues, nil
@@ -245,7 +541,7 @@ func (d Data) GetEpics(ctx context.Context) (Issues, error) {
 	if !d.Stale() {
 		return d.Epics, nil
 	}
-	if err := d.loadEpics(ctx); err != nil {
+	if err := d.loadSection(ctx, d.fetchEpics); err != nil {
 		return nil, err
 	}
 	return d.Epics, nil
@@ -257,7 +553,7 @@ func (d Data) GetInitiatives(ctx context.Context) (Issues, error) {
 	if !d.Stale() {
 		return d.Initiatives, nil
 	}
-	if err := d.loadInitiatives(ctx); err != nil {
+	if err := d.loadSection(ctx, d.fetchInitiatives); err != nil {
 		return nil, err
 	}
 	return d.Initiatives, nil
@@ -269,7 +565,7 @@ func (d Data) GetSprintIssues(ctx context.Context) (Issues, error) {
 	if !d.Stale() {
 		return d.SprintIssues, nil
 	}
-	if err := d.loadSprintIssues(ctx); err != nil {
+	if err := d.loadSection(ctx, d.fetchSprintIssues); err != nil {
 		return nil, err
 	}
 	return d.SprintIssues, nil
@@ -279,41 +575,102 @@ func (d Data) GetSprintIssues(ctx context.Context) (Issues, error) {
 // date it will request the latest issues from Jira.
 func (d Data) GetSprints(ctx context.Context) (Sprints, error) {
 	if !d.Stale() {
-		return d.Sprints, nil
+		return d.Sprints, d.CheckBoard()
 	}
-	if err := d.loadSprints(ctx); err != nil {
+	if err := d.loadSection(ctx, d.fetchSprints); err != nil {
 		return nil, err
 	}
-	return d.Sprints, nil
+	return d.Sprints, d.CheckBoard()
+}
+
+// CheckBoard returns an error if the last sync found no agile board for the
+// project, in which case there are no sprints.
+func (d Data) CheckBoard() error {
+	if d.NoBoard {
+		return boardError(d.Project)
+	}
+	return nil
+}
+
+// ArchiveEpics hides the given epics from listings and editor templates
+// without changing them in Jira.
+func (d *Data) ArchiveEpics(keys ...string) error {
+	for _, key := range keys {
+		if !d.Epics.contains(key) {
+			return fmt.Errorf("%w: %s", errUnknownIssue, key)
+		}
+	}
+	if d.ArchivedEpics == nil {
+		d.ArchivedEpics = make(map[string]bool, len(keys))
+	}
+	for _, key := range keys {
+		d.ArchivedEpics[key] = true
+	}
+	return nil
+}
+
+// UnarchiveEpics reveals previously archived epics again.
+func (d *Data) UnarchiveEpics(keys ...string) {
+	for _, key := range keys {
+		delete(d.ArchivedEpics, key)
+	}
+}
+
+// WithoutArchived returns the epics which have not been archived.
+func (d Data) WithoutArchived(epics Issues) Issues {
+	result := make(Issues, 0, len(epics))
+	for _, epic := range epics {
+		if !d.ArchivedEpics[epic.Key] {
+			result = append(result, epic)
+		}
+	}
+	return result
+}
+
+// has reports whether the data on disk contains all of the given sections,
+// regardless of whether it is out of date.
+func (d Data) has(sections Section) bool {
+	lengths := map[Section]int{
+		SectionIssues:       len(d.Issues),
+		SectionEpics:        len(d.Epics),
+		SectionInitiatives:  len(d.Initiatives),
+		SectionSprintIssues: len(d.SprintIssues),
+		SectionSprints:      len(d.Sprints),
+	}
+	for section, n := range lengths {
+		if sections&section != 0 && n == 0 {
+			return false
+		}
+	}
+	return true
 }
 
 func (d Data) isMissing() bool {
-	_, err := os.Stat(filepath())
+	_, err := os.Stat(cachePath())
 	return os.IsNotExist(err)
 }
 
 func (d Data) WriteFile() error {
-	path := filepath()
-	flock := flock.New(path)
-	err := flock.Lock()
-	if err != nil {
+	// keep the bodies of issues in the file which were not read
+	if err := d.LoadBodies(); err != nil {
 		return err
 	}
-	defer func() {
-		err = flock.Unlock()
-		if err != nil {
-			fmt.Fprint(os.Stderr, err)
-		}
-	}()
-	// create or overwrite file at /tmp/kong
-	file, err := os.Create(path)
+	session.mu.Lock()
+	session.data.reset()
+	session.mu.Unlock()
+
+	unlock, err := lockCache()
 	if err != nil {
 		return err
 	}
-	encoder := gob.NewEncoder(file)
-	err = encoder.Encode(d)
+	err = writeCache(cachePath(), d)
+	unlock()
 	if err != nil {
 		return err
 	}
-	return file.Close()
+
+	// the daemon must not serve the data it read before, even if the file
+	// looks unchanged due to the resolution of the modification time
+	invalidateDaemon()
+	return nil
 }
@@ -0,0 +1,192 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestArchiveEpics(t *testing.T) {
+	data := NewData()
+	data.Epics = Issues{{Key: "KONG-1"}, {Key: "KONG-2"}, {Key: "KONG-3"}}
+
+	if err := data.ArchiveEpics("KONG-1", "KONG-3"); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(data.WithoutArchived(data.Epics), Issues{{Key: "KONG-2"}}); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+
+	data.UnarchiveEpics("KONG-3")
+	if diff := cmp.Diff(data.WithoutArchived(data.Epics), Issues{{Key: "KONG-2"}, {Key: "KONG-3"}}); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+
+	if err := data.ArchiveEpics("KONG-4"); !errors.Is(err, errUnknownIssue) {
+		t.Errorf("got %v, want: %v", err, errUnknownIssue)
+	}
+}
+
+func TestApplyEpicChanges(t *testing.T) {
+	synced := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	data := NewData()
+	data.Timestamp = synced.Unix()
+	data.Issues = Issues{{Key: "KONG-1", EpicKey: "KONG-10"}, {Key: "KONG-2", EpicKey: "KONG-10"}}
+	data.SprintIssues = Issues{{Key: "KONG-1", EpicKey: "KONG-10"}}
+	data.IssueByKey = map[string]Issue{"KONG-1": {Key: "KONG-1", EpicKey: "KONG-10"}}
+	data.EpicChanges = map[string]EpicChange{
+		"KONG-1": {EpicKey: "KONG-11", At: synced.Add(time.Minute)},
+
+		// the sync already picked up changes made before it started
+		"KONG-2": {EpicKey: "", At: synced.Add(-time.Minute)},
+	}
+	issues := data.Issues
+
+	data.applyEpicChanges()
+	want := Issue{Key: "KONG-1", EpicKey: "KONG-11"}
+	for _, got := range []Issue{data.Issues[0], data.SprintIssues[0], data.IssueByKey["KONG-1"]} {
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("diff: %s", diff)
+		}
+	}
+	if got := data.Issues[1].EpicKey; got != "KONG-10" {
+		t.Errorf("got %v, want: %v", got, "KONG-10")
+	}
+	// the issues may be shared with the session cache
+	if issues[0].EpicKey != "KONG-10" {
+		t.Errorf("got %v, want: %v", issues[0].EpicKey, "KONG-10")
+	}
+}
+
+func TestReadDataEpicChanges(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+	session.data.reset()
+	session.state.reset()
+
+	data := NewData()
+	data.Timestamp = time.Now().Add(-time.Minute).Unix()
+	data.Issues = Issues{{Key: "KONG-1", EpicKey: "KONG-10"}}
+	if err := data.WriteFile(); err != nil {
+		t.Fatal(err)
+	}
+	if err := recordEpicChange("KONG-1", "", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Issues[0].EpicKey != "" {
+		t.Errorf("got %v, want issue removed from epic", got.Issues[0].EpicKey)
+	}
+
+	// a sync started after the change includes it
+	if err := pruneEpicChanges(time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	state, err := readState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.EpicChanges) != 0 {
+		t.Errorf("got %v, want no epic changes", state.EpicChanges)
+	}
+}
+
+func TestReadDataAfterWrite(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+
+	for _, project := range []string{"KONG", "GORILLA"} {
+		data := NewData()
+		data.Project = project
+		if err := data.WriteFile(); err != nil {
+			t.Fatal(err)
+		}
+		got, err := ReadData()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Project != project {
+			t.Errorf("got %v, want: %v", got.Project, project)
+		}
+	}
+}
+
+func TestFetchSprintsWithoutBoard(t *testing.T) {
+	client := newTestJira(t, func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"values": []}`)),
+		}, nil
+	})
+	data := NewData()
+	data.Project = "KONG"
+	data.Sprints = Sprints{{ID: 1, Name: "Sprint 1"}}
+	data.jira = Jira{client: client, config: Config{Project: "KONG"}}
+
+	if err := data.loadSection(context.Background(), data.fetchSprints); err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Sprints) != 0 {
+		t.Errorf("got %v, want no sprints", data.Sprints)
+	}
+	err := data.CheckBoard()
+	if !errors.Is(err, ErrNoBoard) {
+		t.Errorf("got %v, want: %v", err, ErrNoBoard)
+	}
+	if want := "project KONG has no agile board, sprint features disabled"; err.Error() != want {
+		t.Errorf("got %q, want: %q", err, want)
+	}
+	if err := data.jira.CreateSprint("Sprint", 1, 1, data.BoardID); !errors.Is(err, ErrNoBoard) {
+		t.Errorf("got %v, want: %v", err, ErrNoBoard)
+	}
+}
+
+func BenchmarkReadData(b *testing.B) {
+	b.Setenv("KONG_CACHE", filepath.Join(b.TempDir(), "kong"))
+
+	data := NewData()
+	for i := 1; i <= 500; i++ {
+		issue := Issue{Key: fmt.Sprintf("KONG-%d", i), Summary: "Add command to list issues"}
+		data.Issues = append(data.Issues, issue)
+		data.IssueByKey[issue.Key] = issue
+	}
+	if err := data.WriteFile(); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// measure decoding as on startup rather than the cached data
+		session.data.reset()
+		if _, err := ReadData(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkDataCache measures encoding and decoding the data file of a large
+// board without the file system.
+func BenchmarkDataCache(b *testing.B) {
+	data := newFixture(fixtureIssues, fixtureEpics).data(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := encodeCache(data)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.SetBytes(int64(len(encoded)))
+		if _, err := decodeCache(encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
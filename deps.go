@@ -0,0 +1,149 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+const blocksLinkType = "Blocks"
+
+// Dependencies is a graph of issues connected through "blocks" and "is
+// blocked by" links.
+type Dependencies struct {
+	Root      string
+	issues    map[string]Issue
+	blocks    map[string][]string
+	blockedBy map[string][]string
+}
+
+func newDependencies(root string) Dependencies {
+	return Dependencies{
+		Root:      root,
+		issues:    make(map[string]Issue),
+		blocks:    make(map[string][]string),
+		blockedBy: make(map[string][]string),
+	}
+}
+
+func (d Dependencies) addBlock(blocker, blocked string) {
+	for _, key := range d.blocks[blocker] {
+		if key == blocked {
+			return
+		}
+	}
+	d.blocks[blocker] = append(d.blocks[blocker], blocked)
+	d.blockedBy[blocked] = append(d.blockedBy[blocked], blocker)
+}
+
+// ListDependencies walks the blocking links of the issue transitively in both
+// directions.
+func (j Jira) ListDependencies(ctx context.Context, key string) (Dependencies, error) {
+	deps := newDependencies(key)
+	queue := []string{key}
+	for len(queue) > 0 {
+		key := queue[0]
+		queue = queue[1:]
+		if _, ok := deps.issues[key]; ok {
+			continue
+		}
+		issue, resp, err := j.client.Issue.GetWithContext(ctx, key, &jira.GetQueryOptions{
+			Fields: "summary,status,issuelinks",
+		})
+		if err != nil {
+			return deps, fmt.Errorf("ListDependencies: %w", parseResponseError(resp))
+		}
+		deps.issues[key] = Issue{
+			Key:     issue.Key,
+			Summary: issue.Fields.Summary,
+			Status:  NewStatus(*issue).withDoneStatuses(j.config.DoneStatuses),
+		}
+		for _, link := range issue.Fields.IssueLinks {
+			if link.Type.Name != blocksLinkType {
+				continue
+			}
+			if link.OutwardIssue != nil {
+				deps.addBlock(key, link.OutwardIssue.Key)
+				queue = append(queue, link.OutwardIssue.Key)
+			}
+			if link.InwardIssue != nil {
+				deps.addBlock(link.InwardIssue.Key, key)
+				queue = append(queue, link.InwardIssue.Key)
+			}
+		}
+	}
+	return deps, nil
+}
+
+// PrintTree writes the issues blocking the root issue and the issues blocked
+// by the root issue as a tree.
+func (d Dependencies) PrintTree(w io.Writer) {
+	fmt.Fprintln(w, d.label(d.Root))
+	var children []dependencyEdge
+	for _, key := range d.blockedBy[d.Root] {
+		children = append(children, dependencyEdge{key, "is blocked by", d.blockedBy})
+	}
+	for _, key := range d.blocks[d.Root] {
+		children = append(children, dependencyEdge{key, "blocks", d.blocks})
+	}
+	visited := map[string]bool{d.Root: true}
+	for i, child := range children {
+		d.printEdge(w, child, "", i == len(children)-1, visited)
+	}
+}
+
+type dependencyEdge struct {
+	key      string
+	relation string
+	next     map[string][]string
+}
+
+func (d Dependencies) printEdge(w io.Writer, edge dependencyEdge, indent string, last bool, visited map[string]bool) {
+	branch, childIndent := Plain("├── "), indent+Plain("│   ")
+	if last {
+		branch, childIndent = Plain("└── "), indent+"    "
+	}
+	if visited[edge.key] {
+		fmt.Fprintf(w, "%s%s%s %s (see above)\n", indent, branch, edge.relation, edge.key)
+		return
+	}
+	visited[edge.key] = true
+	fmt.Fprintf(w, "%s%s%s %s\n", indent, branch, edge.relation, d.label(edge.key))
+
+	keys := edge.next[edge.key]
+	for i, key := range keys {
+		child := dependencyEdge{key, edge.relation, edge.next}
+		d.printEdge(w, child, childIndent, i == len(keys)-1, visited)
+	}
+}
+
+// PrintDOT writes the dependency graph in the Graphviz DOT language.
+func (d Dependencies) PrintDOT(w io.Writer) {
+	keys := make([]string, 0, len(d.issues))
+	for key := range d.issues {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintln(w, "digraph dependencies {")
+	for _, key := range keys {
+		fmt.Fprintf(w, "\t%q [label=%q];\n", key, d.label(key))
+	}
+	for _, key := range keys {
+		for _, blocked := range d.blocks[key] {
+			fmt.Fprintf(w, "\t%q -> %q;\n", key, blocked)
+		}
+	}
+	fmt.Fprintln(w, "}")
+}
+
+func (d Dependencies) label(key string) string {
+	issue, ok := d.issues[key]
+	if !ok {
+		return key
+	}
+	return fmt.Sprintf("%s - %s - %s", issue.Key, issue.Status.Name, issue.Summary)
+}
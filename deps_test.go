@@ -0,0 +1,60 @@
+package kong
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func testDependencies() Dependencies {
+	deps := newDependencies("KONG-1")
+	for _, issue := range []Issue{
+		{Key: "KONG-1", Summary: "Release", Status: Status{Name: "To Do"}},
+		{Key: "KONG-2", Summary: "Migrate", Status: Status{Name: "In Progress"}},
+		{Key: "KONG-3", Summary: "Schema", Status: Status{Name: "Done"}},
+		{Key: "KONG-4", Summary: "Announce", Status: Status{Name: "To Do"}},
+	} {
+		deps.issues[issue.Key] = issue
+	}
+	deps.addBlock("KONG-2", "KONG-1")
+	deps.addBlock("KONG-3", "KONG-2")
+	deps.addBlock("KONG-3", "KONG-1")
+	deps.addBlock("KONG-1", "KONG-4")
+	return deps
+}
+
+func TestDependenciesPrintTree(t *testing.T) {
+	var buf bytes.Buffer
+	testDependencies().PrintTree(&buf)
+
+	want := `KONG-1 - To Do - Release
+├── is blocked by KONG-2 - In Progress - Migrate
+│   └── is blocked by KONG-3 - Done - Schema
+├── is blocked by KONG-3 (see above)
+└── blocks KONG-4 - To Do - Announce
+`
+	if diff := cmp.Diff(buf.String(), want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestDependenciesPrintDOT(t *testing.T) {
+	var buf bytes.Buffer
+	testDependencies().PrintDOT(&buf)
+
+	want := `digraph dependencies {
+	"KONG-1" [label="KONG-1 - To Do - Release"];
+	"KONG-2" [label="KONG-2 - In Progress - Migrate"];
+	"KONG-3" [label="KONG-3 - Done - Schema"];
+	"KONG-4" [label="KONG-4 - To Do - Announce"];
+	"KONG-1" -> "KONG-4";
+	"KONG-2" -> "KONG-1";
+	"KONG-3" -> "KONG-2";
+	"KONG-3" -> "KONG-1";
+}
+`
+	if diff := cmp.Diff(buf.String(), want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
@@ -0,0 +1,48 @@
+package kong
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// fieldChange is the change of a single issue field made in the editor.
+type fieldChange struct {
+	field         string
+	before, after string
+}
+
+// diffIssue returns the changes of the fields which can be edited in the edit
+// issue editor. Values are quoted to reveal changes in whitespace.
+func diffIssue(before, after Issue) []fieldChange {
+	var changes []fieldChange
+	if before.Summary != after.Summary {
+		changes = append(changes, fieldChange{
+			field:  "summary",
+			before: strconv.Quote(before.Summary),
+			after:  strconv.Quote(after.Summary),
+		})
+	}
+	if before.SprintID != after.SprintID {
+		changes = append(changes, fieldChange{
+			field:  "sprintID",
+			before: sprintID(before.SprintID).String(),
+			after:  sprintID(after.SprintID).String(),
+		})
+	}
+	return changes
+}
+
+// printChanges writes one line per changed field with the old value in red
+// and the new value in green if the output is a terminal.
+func printChanges(output io.Writer, changes []fieldChange) {
+	color := colorEnabled(output)
+	for _, change := range changes {
+		before, after := change.before, change.after
+		if color {
+			before = fmt.Sprintf("\x1b[31m%s\x1b[0m", before)
+			after = fmt.Sprintf("\x1b[32m%s\x1b[0m", after)
+		}
+		fmt.Fprintf(output, "%s: %s %s %s\n", change.field, before, Plain("→"), after)
+	}
+}
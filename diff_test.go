@@ -0,0 +1,47 @@
+package kong
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffIssue(t *testing.T) {
+	tests := []struct {
+		name  string
+		after Issue
+		want  string
+	}{
+		{
+			name:  "unchanged",
+			after: Issue{Summary: "Add diff", SprintID: 1},
+			want:  "",
+		},
+		{
+			name:  "whitespace",
+			after: Issue{Summary: "Add diff ", SprintID: 1},
+			want:  "summary: \"Add diff\" → \"Add diff \"\n",
+		},
+		{
+			name:  "sprint",
+			after: Issue{Summary: "Add diff", SprintID: 2},
+			want:  "sprintID: 1 → 2\n",
+		},
+		{
+			name:  "sprint-cleared",
+			after: Issue{Summary: "Add diff"},
+			want:  "sprintID: 1 → none\n",
+		},
+	}
+	before := Issue{Summary: "Add diff", SprintID: 1}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			printChanges(&buf, diffIssue(before, tt.after))
+			if diff := cmp.Diff(buf.String(), tt.want); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package kong
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// noneValue clears a field in the edit issue editor.
+const noneValue = "none"
+
+var (
+	errInvalidSprintID   = errors.New("sprintID must be a sprint ID or none")
+	errFieldNotClearable = errors.New("field cannot be cleared")
+)
+
+// issueEdit is the YAML document of the edit issue editor.
+type issueEdit struct {
+	Summary  string   `yaml:"summary"`
+	SprintID sprintID `yaml:"sprintID"`
+}
+
+func newIssueEdit(issue Issue) issueEdit {
+	return issueEdit{
+		Summary:  issue.Summary,
+		SprintID: sprintID(issue.SprintID),
+	}
+}
+
+// update returns the issue with the edited fields and the fields which were
+// set to none and have to be cleared.
+func (e issueEdit) update(original Issue) (Issue, []Field) {
+	issue := original
+	issue.Summary = e.Summary
+	issue.SprintID = int(e.SprintID)
+
+	var clear []Field
+	if original.SprintID != 0 && e.SprintID == 0 {
+		clear = append(clear, FieldSprint)
+	}
+	return issue, clear
+}
+
+// sprintID is the ID of a sprint which is written as none if the issue is not
+// part of a sprint.
+type sprintID int
+
+func (s sprintID) String() string {
+	if s == 0 {
+		return noneValue
+	}
+	return strconv.Itoa(int(s))
+}
+
+func (s sprintID) MarshalYAML() (interface{}, error) {
+	if s == 0 {
+		return noneValue, nil
+	}
+	return int(s), nil
+}
+
+func (s *sprintID) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var value string
+	if err := unmarshal(&value); err != nil {
+		return err
+	}
+	if value == "" || value == noneValue {
+		*s = 0
+		return nil
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil || id < 0 {
+		return fmt.Errorf("%w: %s", errInvalidSprintID, value)
+	}
+	*s = sprintID(id)
+	return nil
+}
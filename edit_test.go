@@ -0,0 +1,72 @@
+package kong
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v2"
+)
+
+func TestIssueEditUpdate(t *testing.T) {
+	original := Issue{Key: "KONG-1", Summary: "Add edit", SprintID: 7}
+	tests := []struct {
+		name      string
+		yaml      string
+		want      Issue
+		wantClear []Field
+		wantErr   error
+	}{
+		{
+			name: "unchanged",
+			yaml: "summary: Add edit\nsprintID: 7\n",
+			want: original,
+		},
+		{
+			name: "moved",
+			yaml: "summary: Add edit\nsprintID: 8\n",
+			want: Issue{Key: "KONG-1", Summary: "Add edit", SprintID: 8},
+		},
+		{
+			name:      "cleared",
+			yaml:      "summary: Add edit\nsprintID: none\n",
+			want:      Issue{Key: "KONG-1", Summary: "Add edit"},
+			wantClear: []Field{FieldSprint},
+		},
+		{
+			name:    "invalid",
+			yaml:    "summary: Add edit\nsprintID: next\n",
+			wantErr: errInvalidSprintID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var edit issueEdit
+			err := yaml.Unmarshal([]byte(tt.yaml), &edit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want: %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got, clear := edit.update(original)
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+			if diff := cmp.Diff(clear, tt.wantClear); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestIssueEditMarshal(t *testing.T) {
+	b, err := yaml.Marshal(newIssueEdit(Issue{Summary: "Add edit"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "summary: Add edit\nsprintID: none\n"
+	if diff := cmp.Diff(string(b), want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
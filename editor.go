@@ -5,12 +5,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 	"text/tabwriter"
-	"text/template"
 	"time"
 
 	"github.com/andygrunwald/go-jira"
@@ -21,20 +23,45 @@ var (
 	errMissingColumn     = errors.New("missing column")
 	errParentMismatch    = errors.New("epic or initiative does not exist")
 	errSprintMismatch    = errors.New("sprint does not exist")
+	errSprintClosed      = errors.New("sprint has been closed")
+	errSprintAmbiguous   = errors.New("sprint key is the ID of another sprint")
+	errNoTerminal        = errors.New("no terminal to open the editor, use --input to provide the content")
+	errInputRejected     = errors.New("input rejected")
+	errMissingEpic       = errors.New("issue must be indented below an epic")
 	errUnknownIssue      = errors.New("issue does not exist")
 	errUnknownTransition = errors.New("transition does not exist")
+	errUnknownStandup    = errors.New("standup template does not exist")
+	errEditorInvalid     = errors.New("editor command is invalid")
 )
 
+// errorAnnotation prefixes the comments added above rows which Jira rejected.
+const errorAnnotation = "# error: "
+
 // Editor provides any functionality that processes user input by providing an
 // editor which creates files and parses back the content the user provided.
 type Editor struct {
 	jira   Jira
 	data   Data
 	config Config
+	input  *editorInput
+
+	// estimate is the original estimate set on created issues.
+	estimate string
+	// fields are custom field values by name set on created issues.
+	fields map[string]any
+}
+
+// editorInput replaces the interactive editor with content read from a file
+// or stdin. It is shared between copies of Editor to detect when the input
+// has been rejected and the editor would have been reopened.
+type editorInput struct {
+	path string
+	read bool
 }
 
-// NewEditor returns a new instance of Editor.
-func NewEditor(ctx context.Context) (Editor, error) {
+// NewEditor returns a new instance of Editor. If the data on disk does not
+// contain the given sections they are requested from Jira.
+func NewEditor(ctx context.Context, sections Section) (Editor, error) {
 	var (
 		editor Editor
 		err    error
@@ -47,20 +74,83 @@ func NewEditor(ctx context.Context) (Editor, error) {
 	}
 	editor.config = editor.jira.config
 
-	// load data from file or synchronously by calling Jira API directly
+	// load data from file and only call the Jira API directly if the cache
+	// lacks the data, staleness is handled once the changes are submitted
 	editor.data, err = LoadData()
 	if err != nil {
 		return editor, err
 	}
-	if editor.data.Stale() {
-		editor.data, err = LoadDataBlocking(ctx)
+	if !editor.data.has(sections) {
+		editor.data, err = LoadDataBlocking(ctx, sections)
 		if err != nil {
 			return editor, err
 		}
 	}
+
+	// descriptions are part of the editor templates
+	if err := editor.data.LoadBodies(); err != nil {
+		return editor, err
+	}
+
+	// archived epics are not offered as parents
+	editor.data.Epics = editor.data.WithoutArchived(editor.data.Epics)
 	return editor, nil
 }
 
+// SetInput makes the editor read the content from the file at the given path
+// instead of opening an interactive editor. The path - reads from stdin.
+func (e *Editor) SetInput(path string) {
+	if path == "" {
+		e.input = nil
+		return
+	}
+	e.input = &editorInput{path: path}
+}
+
+// confirm asks the user to confirm the prompt. Content given with --input is
+// taken as confirmed since nobody is there to answer and stdin may already
+// have been read as the content.
+func (e Editor) confirm(prompt string) (bool, error) {
+	if e.input != nil {
+		return true, nil
+	}
+	return Confirm(prompt)
+}
+
+// SetEstimate sets the original estimate, a Jira duration like 2d, of the
+// issues created by the editor. Epics are not estimated.
+func (e *Editor) SetEstimate(estimate string) error {
+	if estimate != "" {
+		if err := ValidateEstimate(estimate); err != nil {
+			return err
+		}
+	}
+	e.estimate = estimate
+	return nil
+}
+
+// SetProject makes the editor create issues in the given project, for instance
+// another project of the sprint board, using the custom fields configured for
+// the project.
+func (e *Editor) SetProject(project string) {
+	if project == "" {
+		return
+	}
+	e.jira = e.jira.withProject(project)
+	e.config = e.jira.config
+}
+
+// SetFields sets custom field values formatted as name=value on the issues and
+// epics created by the editor, overriding configured defaults.
+func (e *Editor) SetFields(values []string) error {
+	fields, err := e.config.parseFieldValues(values)
+	if err != nil {
+		return err
+	}
+	e.fields = fields
+	return nil
+}
+
 func (e Editor) createFile(template, filename string) (string, func(), error) {
 	f, err := os.CreateTemp(os.TempDir(), filename)
 	if err != nil {
@@ -84,14 +174,18 @@ func (e Editor) createFile(template, filename string) (string, func(), error) {
 	return f.Name(), cleanup, nil
 }
 
-// OpenNewIssueEditor creates a new file create Jira issues in batches.
-func (e Editor) OpenNewIssueEditor(ctx context.Context) error {
+// OpenNewIssueEditor creates a new file create Jira issues in batches. If
+// openBrowser is set the created issues are opened in the browser.
+func (e Editor) OpenNewIssueEditor(ctx context.Context, openBrowser bool) error {
 	filename, cleanup, err := e.createFile(e.issueTemplate(), "kong-new-issues")
 	if err != nil {
 		return err
 	}
 	defer cleanup()
 
+	// issues created before a retry of the failed rows
+	var created []string
+
 	for {
 		if err := e.open(ctx, filename, true); err != nil {
 			return err
@@ -105,32 +199,59 @@ func (e Editor) OpenNewIssueEditor(ctx context.Context) error {
 
 		// abort on empty input
 		if len(lines) == 0 {
+			if len(created) > 0 {
+				return e.shareIssues(ctx, created, openBrowser)
+			}
 			return nil
 		}
 
-		columns, err := e.parseColumns(lines, 5)
+		rows, err := e.parseNewIssueRows(lines)
 		if err != nil {
 			fmt.Println(err)
 			time.Sleep(2 * time.Second)
 			continue
 		}
-
-		issues, err := e.parseIssues(columns, e.config.IssueType)
+		issues := newIssues(rows)
+		ok, err := e.confirmLint(issues)
 		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if err := e.verifySprints(ctx, issues); err != nil {
 			fmt.Println(err)
 			time.Sleep(2 * time.Second)
 			continue
 		}
-		return e.jira.CreateIssues(ctx, issues)
+		keys, err := e.jira.createNewIssueRows(ctx, rows)
+		created = append(created, createdKeys(rows, keys)...)
+
+		// keep failed rows annotated with the errors to retry them
+		var createErr CreateIssuesError
+		if errors.As(err, &createErr) {
+			content := replaceParentRows(string(b), rows, keys, createErr)
+			if err := e.annotateFile(filename, content, createErr); err != nil {
+				return err
+			}
+			time.Sleep(2 * time.Second)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		return e.shareIssues(ctx, created, openBrowser)
 	}
 }
 
+// OpenEditIssueEditor opens the issue as YAML and updates it after the user
+// confirmed the changed fields.
 func (e Editor) OpenEditIssueEditor(ctx context.Context, key string) error {
-	issue, ok := e.data.IssueByKey[key]
+	original, ok := e.data.IssueByKey[key]
 	if !ok {
 		return fmt.Errorf("%w: %s", errUnknownIssue, key)
 	}
-	b, err := yaml.Marshal(issue)
+	b, err := yaml.Marshal(newIssueEdit(original))
 	if err != nil {
 		return err
 	}
@@ -148,24 +269,69 @@ func (e Editor) OpenEditIssueEditor(ctx context.Context, key string) error {
 		if err != nil {
 			return err
 		}
-		var issue Issue
-		if err := yaml.Unmarshal(b, &issue); err != nil {
+		var edit issueEdit
+		if err := yaml.Unmarshal(b, &edit); err != nil {
 			fmt.Println(err)
 			time.Sleep(2 * time.Second)
 			continue
 		}
-		return e.jira.UpdateIssue(ctx, key, issue)
+		issue, clear := edit.update(original)
+		changes := diffIssue(original, issue)
+		if len(changes) == 0 {
+			fmt.Println("No changes")
+			return nil
+		}
+		printChanges(os.Stdout, changes)
+		ok, err := e.confirm("Update issue?")
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		return e.jira.UpdateIssue(ctx, key, issue, clear...)
 	}
 }
 
-// OpenEpicEditor creates a new file create Jira epics in batches.
-func (e Editor) OpenEpicEditor(ctx context.Context) error {
-	filename, cleanup, err := e.createFile(e.epicTemplate(), "kong-new-epics")
+// OpenCommentEditor opens a new file to write a comment which is added to the
+// issue. An empty comment aborts.
+func (e Editor) OpenCommentEditor(ctx context.Context, key string) error {
+	filename, cleanup, err := e.createFile(e.commentTemplate(key), "kong-comment")
 	if err != nil {
 		return err
 	}
 	defer cleanup()
 
+	if err := e.open(ctx, filename, true); err != nil {
+		return err
+	}
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	body := commentBody(string(b))
+	if body == "" {
+		return nil
+	}
+	if err := e.jira.AddComment(ctx, key, body); err != nil {
+		return err
+	}
+	fmt.Printf("%s - Comment added\n", key)
+	return nil
+}
+
+// OpenEpicEditor creates a new file create Jira epics in batches. If
+// openBrowser is set the created epics are opened in the browser.
+func (e Editor) OpenEpicEditor(ctx context.Context, openBrowser bool) error {
+	filename, cleanup, err := e.createFile(e.epicTemplate(false), "kong-new-epics")
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	// issues created before a retry of the failed rows
+	var created []string
+
 	for {
 		if err := e.open(ctx, filename, true); err != nil {
 			return err
@@ -179,6 +345,9 @@ func (e Editor) OpenEpicEditor(ctx context.Context) error {
 
 		// abort on empty input
 		if len(lines) == 0 {
+			if len(created) > 0 {
+				return e.shareIssues(ctx, created, openBrowser)
+			}
 			return nil
 		}
 
@@ -195,20 +364,87 @@ func (e Editor) OpenEpicEditor(ctx context.Context) error {
 			time.Sleep(2 * time.Second)
 			continue
 		}
-		return e.jira.CreateIssues(ctx, epics)
+		ok, err := e.confirmLint(epics)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if err := e.verifySprints(ctx, epics); err != nil {
+			fmt.Println(err)
+			time.Sleep(2 * time.Second)
+			continue
+		}
+		keys, err := e.jira.CreateIssues(ctx, epics)
+		created = appendKeys(created, keys)
+
+		// keep failed rows annotated with the errors to retry them
+		var createErr CreateIssuesError
+		if errors.As(err, &createErr) {
+			if err := e.annotateFile(filename, string(b), createErr); err != nil {
+				return err
+			}
+			time.Sleep(2 * time.Second)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		return e.shareIssues(ctx, created, openBrowser)
 	}
 }
 
-// OpenSprintEditor creates a new file to edit the sprint board issue progress.
-func (e Editor) OpenSprintEditor(ctx context.Context, includeDone bool) error {
-	filename, cleanup, err := e.createFile(e.sprintTemplate(includeDone), "kong-sprint")
+// issueAssignee is an assignee change of an issue in the sprint editor.
+type issueAssignee struct {
+	issueKey string
+	user     string
+}
+
+// issuePriority is a priority change of an issue in the sprint editor.
+type issuePriority struct {
+	issueKey string
+	priority string
+}
+
+// epicPlan is a new epic together with the new issues of the epic.
+type epicPlan struct {
+	epic *jira.Issue
+	// key is the key of the epic created by a previous attempt if epic is nil
+	key    string
+	issues []*jira.Issue
+}
+
+// epicPlanRows returns the rows of the plans in the order of the editor file
+// with each epic followed by its issues.
+func epicPlanRows(plans []epicPlan) []newIssueRow {
+	var rows []newIssueRow
+	for _, plan := range plans {
+		parent := len(rows)
+		rows = append(rows, newIssueRow{issue: plan.epic, key: plan.key, parent: -1})
+		for _, issue := range plan.issues {
+			rows = append(rows, newIssueRow{issue: issue, parent: parent})
+		}
+	}
+	return rows
+}
+
+// OpenEpicWithIssuesEditor creates a new file to plan epics together with
+// their issues. Each epic is created before its issues so that the issues are
+// linked to the new epic. If openBrowser is set the created epics and issues
+// are opened in the browser.
+func (e Editor) OpenEpicWithIssuesEditor(ctx context.Context, openBrowser bool) error {
+	filename, cleanup, err := e.createFile(e.epicTemplate(true), "kong-new-epics")
 	if err != nil {
 		return err
 	}
 	defer cleanup()
 
+	// issues created before a retry of the failed rows
+	var created []string
+
 	for {
-		if err := e.open(ctx, filename, false); err != nil {
+		if err := e.open(ctx, filename, true); err != nil {
 			return err
 		}
 		b, err := os.ReadFile(filename)
@@ -220,83 +456,400 @@ func (e Editor) OpenSprintEditor(ctx context.Context, includeDone bool) error {
 
 		// abort on empty input
 		if len(lines) == 0 {
+			if len(created) > 0 {
+				return e.shareIssues(ctx, created, openBrowser)
+			}
 			return nil
 		}
 
-		columns, err := e.parseActionColumns(lines)
+		plans, err := e.parseEpicPlans(lines)
+		if err != nil {
+			fmt.Println(err)
+			time.Sleep(2 * time.Second)
+			continue
+		}
+		rows := epicPlanRows(plans)
+		issues := newIssues(rows)
+		ok, err := e.confirmLint(issues)
 		if err != nil {
 			return err
 		}
-
-		var (
-			issueTransitions    []issueTransition
-			moveIssuesToBacklog []string
-		)
-
-		for _, row := range columns {
-			action := row[0]
-			key := row[1]
-
-			issue, ok := e.data.IssueByKey[key]
-			if !ok {
-				return fmt.Errorf("%w: %s", errUnknownIssue, key)
+		if !ok {
+			continue
+		}
+		if err := e.verifySprints(ctx, issues); err != nil {
+			fmt.Println(err)
+			time.Sleep(2 * time.Second)
+			continue
+		}
+		keys, err := e.jira.createEpicPlanRows(ctx, rows)
+		created = append(created, createdKeys(rows, keys)...)
+
+		// keep failed rows annotated with the errors to retry them, created
+		// epics are kept by key so that their issues are retried in them
+		var createErr CreateIssuesError
+		if errors.As(err, &createErr) {
+			content := replaceParentRows(string(b), rows, keys, createErr)
+			if err := e.annotateFile(filename, content, createErr); err != nil {
+				return err
 			}
+			time.Sleep(2 * time.Second)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		return e.shareIssues(ctx, created, openBrowser)
+	}
+}
 
-			// skip issues without transition to apply
-			if action == issue.Status.Acronym {
+// parseEpicPlans parses lines defining epics with the columns of the epic
+// editor followed by indented lines defining issues of the epic without the
+// epic column.
+func (e Editor) parseEpicPlans(lines []string) ([]epicPlan, error) {
+	var plans []epicPlan
+	for _, line := range lines {
+		indented := strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+		if !indented {
+			if parentKeyPattern.MatchString(line) {
+				plans = append(plans, epicPlan{key: line})
 				continue
 			}
+			columns, err := e.parseColumns([]string{line}, 5)
+			if err != nil {
+				return nil, err
+			}
+			epic, err := e.parseIssue(columns[0], "Epic")
+			if err != nil {
+				return nil, err
+			}
+			plans = append(plans, epicPlan{epic: epic})
+			continue
+		}
+		if len(plans) == 0 {
+			return nil, fmt.Errorf("%w: %s", errMissingEpic, strings.TrimSpace(line))
+		}
+		columns, err := e.parseColumns([]string{strings.TrimSpace(line)}, 4)
+		if err != nil {
+			return nil, err
+		}
+		// the epic is linked once it has been created
+		issue, err := e.parseIssue(append([]string{"0"}, columns[0]...), e.config.IssueType)
+		if err != nil {
+			return nil, err
+		}
+		plan := &plans[len(plans)-1]
+		plan.issues = append(plan.issues, issue)
+	}
+	return plans, nil
+}
 
-			if action == backlogAcronym {
-				moveIssuesToBacklog = append(moveIssuesToBacklog, key)
+// createEpicPlanRows creates the rows of epic plans one after another so that
+// the issues are created in the order of the file, each after its epic. It
+// returns the keys of the created issues by row together with a
+// CreateIssuesError by row.
+func (j Jira) createEpicPlanRows(ctx context.Context, rows []newIssueRow) ([]string, error) {
+	field := j.config.CustomFields.Epics
+	keys := make([]string, len(rows))
+	for _, row := range rows {
+		if row.parent >= 0 && field == "" {
+			return keys, errConfigEpicField
+		}
+	}
+	var lastIssueCreated string
+	errs := make(CreateIssuesError)
+	for i, row := range rows {
+		if row.issue == nil {
+			keys[i] = row.key
+			continue
+		}
+		if row.parent >= 0 {
+			if keys[row.parent] == "" {
+				errs[i] = errParentNotCreated
 				continue
 			}
+			row.issue.Fields.Unknowns[field] = keys[row.parent]
+		}
+		key, err := j.CreateIssue(ctx, row.issue)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		keys[i] = key
+		lastIssueCreated = key
+	}
+	if err := setLastIssueCreated(lastIssueCreated); err != nil {
+		return keys, err
+	}
+	if len(errs) > 0 {
+		return keys, errs
+	}
+	return keys, nil
+}
 
-			// look up transition based on action specified as acronym
-			transition, ok := issue.TransitionsByAcronym[action]
-			if !ok {
-				return errUnknownTransition
-			}
+// OpenSprintEditor creates a new file to edit the sprint board issue progress.
+func (e Editor) OpenSprintEditor(ctx context.Context, includeDone bool) error {
+	filename, cleanup, err := e.createFile(e.sprintTemplate(includeDone), "kong-sprint")
+	if err != nil {
+		return err
+	}
+	defer cleanup()
 
-			// construct tuple to perform issue transitions
-			issueTransitions = append(issueTransitions, issueTransition{
-				issueKey:   key,
-				transition: transition,
-			})
+	for {
+		if err := e.open(ctx, filename, false); err != nil {
+			return err
 		}
-		if err := e.jira.MoveIssuesToBacklog(ctx, moveIssuesToBacklog); err != nil {
+		b, err := os.ReadFile(filename)
+		if err != nil {
 			return err
 		}
-		return e.jira.TransitionIssues(ctx, issueTransitions)
-	}
-}
 
-// OpenStandupEditor creates a new file to edit the sprint board issue progress.
-func (e Editor) OpenStandupEditor(ctx context.Context, standupType string) error {
-	var buf bytes.Buffer
+		lines := e.parseLines(string(b))
 
-	switch standupType {
-	case "sprint":
-		text := e.config.SprintStandupTemplate
-		tmpl, err := template.New("standup").Parse(text)
+		// abort on empty input
+		if len(lines) == 0 {
+			return nil
+		}
+
+		columns, err := e.parseActionColumns(lines)
 		if err != nil {
 			return err
 		}
-		if err := tmpl.Execute(&buf, e.data.SprintIssues); err != nil {
+
+		edit, err := e.parseSprintEdit(columns)
+		if err != nil {
 			return err
 		}
-	case "epics":
-		text := e.config.EpicStandupTemplate
-		tmpl, err := template.New("standup").Parse(text)
+
+		// report every invalid action before changing any issue
+		if len(edit.invalidActions) > 0 {
+			return edit.invalidActions
+		}
+		ok, err := e.jira.confirmChecklists(edit.transitions, e.confirm)
 		if err != nil {
 			return err
 		}
-		if err := tmpl.Execute(&buf, e.data.Epics); err != nil {
+		if !ok {
+			continue
+		}
+		assignees := make([]*jira.User, len(edit.assignees))
+		for i, a := range edit.assignees {
+			user, err := e.jira.resolveAssignee(ctx, a.user)
+			if err != nil {
+				return fmt.Errorf("%s: %w", a.issueKey, err)
+			}
+			assignees[i] = user
+		}
+		if err := e.jira.MoveIssuesToBacklog(ctx, edit.backlog); err != nil {
+			return err
+		}
+		for _, issue := range edit.renamed {
+			if err := e.jira.UpdateIssue(ctx, issue.Key, issue); err != nil {
+				return err
+			}
+		}
+		for _, p := range edit.priorities {
+			if err := e.jira.SetPriority(ctx, p.issueKey, p.priority); err != nil {
+				return err
+			}
+		}
+		for i, a := range edit.assignees {
+			if err := e.jira.assign(ctx, a.issueKey, assignees[i]); err != nil {
+				return err
+			}
+		}
+		if err := e.jira.TransitionIssues(ctx, edit.transitions); err != nil {
 			return err
 		}
+		return e.jira.commentChecklists(ctx, edit.transitions)
 	}
+}
+
+// sprintEdit holds the changes of the rows of the sprint editor.
+type sprintEdit struct {
+	transitions    []issueTransition
+	backlog        []string
+	priorities     []issuePriority
+	assignees      []issueAssignee
+	renamed        []Issue
+	invalidActions InvalidActionsError
+}
 
-	filename, cleanup, err := e.createFile(buf.String(), "kong-standup")
+// parseSprintEdit returns the changes of the rows of the sprint editor. Each
+// row consists of the action, the key and the summary of the issue.
+func (e Editor) parseSprintEdit(columns [][]string) (sprintEdit, error) {
+	var edit sprintEdit
+	for _, row := range columns {
+		action := row[0]
+		key := row[1]
+
+		issue, ok := e.data.IssueByKey[key]
+		if !ok {
+			return edit, fmt.Errorf("%w: %s", errUnknownIssue, key)
+		}
+
+		// the column after the key names the assignee instead of the summary,
+		// the summary left behind after it is ignored
+		if action == assignAcronym {
+			var user string
+			if len(row) > 2 {
+				user = row[2]
+			}
+			edit.assignees = append(edit.assignees, issueAssignee{
+				issueKey: key,
+				user:     user,
+			})
+			continue
+		}
+
+		// rename issues whose summary has been edited
+		if summary := strings.Join(row[2:], " "); summary != "" && summary != strings.Join(strings.Fields(issue.Summary), " ") {
+			edit.renamed = append(edit.renamed, Issue{
+				Key:     key,
+				Summary: summary,
+			})
+		}
+
+		// skip issues without transition to apply
+		if action == issue.Status.Acronym {
+			continue
+		}
+
+		if action == backlogAcronym {
+			edit.backlog = append(edit.backlog, key)
+			continue
+		}
+
+		if priority, ok := e.config.PriorityByAction(action); ok {
+			edit.priorities = append(edit.priorities, issuePriority{
+				issueKey: key,
+				priority: priority,
+			})
+			continue
+		}
+
+		// look up transition based on action specified as acronym
+		transition, ok := issue.TransitionsByAcronym[action]
+		if !ok {
+			edit.invalidActions = append(edit.invalidActions, newInvalidAction(issue, action))
+			continue
+		}
+
+		// construct tuple to perform issue transitions
+		edit.transitions = append(edit.transitions, issueTransition{
+			issueKey:   key,
+			transition: transition,
+		})
+	}
+	return edit, nil
+}
+
+// OpenRolloverEditor creates a new file to preview moving incomplete issues of
+// the active sprint into the next sprint or the backlog. If startNextSprint is
+// set the active sprint is closed and the next sprint is started.
+func (e Editor) OpenRolloverEditor(ctx context.Context, startNextSprint bool) error {
+	if err := e.data.CheckBoard(); err != nil {
+		return err
+	}
+	activeSprint, err := e.data.Sprints.ActiveSprint()
+	if err != nil {
+		return err
+	}
+	nextSprint, err := e.data.Sprints.NextSprint()
+	if err != nil {
+		return err
+	}
+
+	template := e.rolloverTemplate(activeSprint, nextSprint)
+	filename, cleanup, err := e.createFile(template, "kong-rollover")
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if err := e.open(ctx, filename, false); err != nil {
+		return err
+	}
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	lines := e.parseLines(string(b))
+
+	// abort on empty input
+	if len(lines) == 0 {
+		return nil
+	}
+
+	columns, err := e.parseActionColumns(lines)
+	if err != nil {
+		return err
+	}
+	moveIssuesToSprint, moveIssuesToBacklog, err := parseRollover(columns)
+	if err != nil {
+		return err
+	}
+
+	if err := e.jira.MoveIssuesToSprint(ctx, nextSprint, moveIssuesToSprint); err != nil {
+		return err
+	}
+	if err := e.jira.MoveIssuesToBacklog(ctx, moveIssuesToBacklog); err != nil {
+		return err
+	}
+
+	// note the rollover on every issue which has been carried over
+	comment := fmt.Sprintf("Rolled over from %s to %s", activeSprint.Name, nextSprint.Name)
+	for _, key := range moveIssuesToSprint {
+		if err := e.jira.AddComment(ctx, key, comment); err != nil {
+			return err
+		}
+	}
+
+	if !startNextSprint {
+		return nil
+	}
+	if err := e.jira.UpdateSprintState(ctx, activeSprint, "closed"); err != nil {
+		return err
+	}
+	return e.jira.UpdateSprintState(ctx, nextSprint, "active")
+}
+
+// parseRollover returns the keys of the issues to move into the next sprint
+// and into the backlog.
+func parseRollover(columns [][]string) (toSprint, toBacklog []string, err error) {
+	for _, row := range columns {
+		action := row[0]
+		key := row[1]
+		switch action {
+		case nextSprintAcronym:
+			toSprint = append(toSprint, key)
+		case backlogAcronym:
+			toBacklog = append(toBacklog, key)
+		default:
+			return nil, nil, fmt.Errorf("%w: %s", errUnknownTransition, action)
+		}
+	}
+	return toSprint, toBacklog, nil
+}
+
+// OpenStandupEditor renders the standup template of the given name into a new
+// file for editing before copying it.
+func (e Editor) OpenStandupEditor(ctx context.Context, standupType string) error {
+	standup, ok := e.config.StandupTemplate(standupType)
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnknownStandup, standupType)
+	}
+	data, err := e.standupData(ctx, standup)
+	if err != nil {
+		return err
+	}
+	text, err := renderTemplate(standupType, standup.Template, data)
+	if err != nil {
+		return err
+	}
+
+	filename, cleanup, err := e.createFile(text, "kong-standup")
 	if err != nil {
 		return err
 	}
@@ -310,19 +863,143 @@ func (e Editor) OpenStandupEditor(ctx context.Context, standupType string) error
 	if err != nil {
 		return err
 	}
+	if err := saveStandup(standupType, b, time.Now()); err != nil {
+		return err
+	}
 
-	cmd := exec.Command(e.config.CopyCommand)
-	cmd.Stdin = bytes.NewBuffer(b)
-	return cmd.Run()
+	return copyToClipboard(ctx, e.config.CopyCommand, b)
+}
+
+// shareIssues copies the browse URLs of the given issues to the clipboard and
+// optionally opens them in the browser.
+func (e Editor) shareIssues(ctx context.Context, keys []string, openBrowser bool) error {
+	urls := make([]string, len(keys))
+	for i, key := range keys {
+		urls[i] = e.jira.BrowseURL(key)
+	}
+	text := strings.Join(urls, "\n") + "\n"
+	if err := copyToClipboard(ctx, e.config.CopyCommand, []byte(text)); err != nil {
+		return err
+	}
+	if !openBrowser {
+		return nil
+	}
+	for _, url := range urls {
+		if err := openURL(ctx, url); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// annotateFile rewrites the editor file so that it only contains the rows
+// which failed, each preceded by a comment with the errors Jira returned.
+func (e Editor) annotateFile(filename, content string, errs CreateIssuesError) error {
+	annotated, messages := annotateErrors(content, errs, e.config.CustomFields.names())
+	for _, message := range messages {
+		fmt.Println(message)
+	}
+	return os.WriteFile(filename, []byte(annotated), 0o600)
+}
+
+// annotateErrors removes the rows of created issues from the content and adds
+// the errors above the rows of failed issues. Annotations of previous attempts
+// are replaced. It returns the annotated content and the errors prefixed with
+// the line of the row in the given content.
+func annotateErrors(content string, errs CreateIssuesError, names map[string]string) (string, []string) {
+	var (
+		lines    []string
+		messages []string
+		row      int
+
+		// parent is the row referencing an existing parent issue which is
+		// kept if one of its subtasks failed
+		parent string
+	)
+	for i, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, errorAnnotation) {
+			continue
+		}
+		if strings.HasPrefix(line, "#") || line == "" {
+			lines = append(lines, line)
+			continue
+		}
+		err, failed := errs[row]
+		row++
+		indented := strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+		if !indented {
+			parent = ""
+		}
+		if !failed {
+			if !indented && parentKeyPattern.MatchString(line) {
+				parent = line
+			}
+			continue
+		}
+		if indented && parent != "" {
+			lines = append(lines, parent)
+			parent = ""
+		}
+		for _, message := range errorMessages(err, names) {
+			lines = append(lines, errorAnnotation+message)
+			messages = append(messages, fmt.Sprintf("line %d: %s", i+1, message))
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n"), messages
+}
+
+func errorMessages(err error, names map[string]string) []string {
+	var respErr *ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.Messages(names)
+	}
+	return []string{err.Error()}
+}
+
+// appendKeys appends the keys of created issues, skipping failed issues.
+func appendKeys(created, keys []string) []string {
+	for _, key := range keys {
+		if key != "" {
+			created = append(created, key)
+		}
+	}
+	return created
+}
+
+func (e Editor) standupData(ctx context.Context, standup StandupTemplate) (any, error) {
+	switch standup.Source {
+	case StandupSourceSprint:
+		return e.data.SprintIssues, nil
+	case StandupSourceEpics:
+		return e.data.Epics, nil
+	case StandupSourceJQL:
+		return e.jira.search(ctx, standup.JQL)
+	case StandupSourceTeam:
+		return e.jira.ListTeam(ctx)
+	}
+	return nil, fmt.Errorf("%w: %s", errConfigStandupSource, standup.Source)
 }
 
 func (e Editor) open(ctx context.Context, filename string, lastLine bool) error {
-	args := []string{filename}
+	if e.input != nil {
+		return e.readInput(filename)
+	}
+	if !isTerminal(os.Stdin) {
+		return errNoTerminal
+	}
+
+	fields, err := editorFields(editorCommand(e.config.Editor))
+	if err != nil || len(fields) == 0 {
+		return fmt.Errorf("%w: %s", errEditorInvalid, editorCommand(e.config.Editor))
+	}
+	line := 0
 	if lastLine {
-		args = append(args, "-c", "norm! G")
+		line = lineCount(filename)
 	}
+	name, args := fields[0], editorArgs(fields[0], fields[1:], filename, line)
 
-	cmd := exec.CommandContext(ctx, "vim", args...)
+	cmd := exec.CommandContext(ctx, name, args...)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -330,6 +1007,98 @@ func (e Editor) open(ctx context.Context, filename string, lastLine bool) error
 	return cmd.Run()
 }
 
+// readInput writes the input into the editor file. Editor sessions reopen the
+// editor when the content is invalid which is an error for non-interactive
+// input.
+func (e Editor) readInput(filename string) error {
+	if e.input.read {
+		return errInputRejected
+	}
+	e.input.read = true
+
+	var (
+		b   []byte
+		err error
+	)
+	if e.input.path == "-" {
+		b, err = io.ReadAll(os.Stdin)
+	} else {
+		b, err = os.ReadFile(e.input.path)
+	}
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, b, 0o600)
+}
+
+// editorCommand returns the editor configured in the configuration, through
+// $VISUAL or $EDITOR and falls back to the platform default.
+func editorCommand(configured string) string {
+	if editor := strings.TrimSpace(configured); editor != "" {
+		return editor
+	}
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(env)); editor != "" {
+			return editor
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vim"
+}
+
+// editorFields splits the editor command into the executable and its
+// arguments. A command which names an executable as a whole is kept, so that
+// paths with spaces work without quotes. Otherwise the editor may be
+// configured with arguments, e.g. code --wait, and quoted paths.
+func editorFields(command string) ([]string, error) {
+	if _, err := exec.LookPath(command); err == nil {
+		return []string{command}, nil
+	}
+	return splitCommandLine(command)
+}
+
+// editorArgs returns the arguments to open the file with the editor. If line
+// is set, the cursor is placed on the line for editors which support it.
+func editorArgs(name string, args []string, filename string, line int) []string {
+	args = append([]string(nil), args...)
+	if line == 0 {
+		return append(args, filename)
+	}
+	base := strings.TrimSuffix(filepath.Base(name), ".exe")
+	switch {
+	case base == "vi" || strings.HasSuffix(base, "vim"):
+		return append(args, filename, "-c", "norm! G")
+	case base == "nano", base == "micro", base == "kak", base == "joe", base == "mg",
+		strings.HasPrefix(base, "emacs"):
+		return append(args, fmt.Sprintf("+%d", line), filename)
+	case base == "code", base == "codium", base == "cursor":
+		return append(args, "--goto", fmt.Sprintf("%s:%d", filename, line))
+	case base == "subl", base == "hx", base == "helix":
+		return append(args, fmt.Sprintf("%s:%d", filename, line))
+	}
+	return append(args, filename)
+}
+
+// lineCount returns the number of lines of the file or 0 if it cannot be
+// read.
+func lineCount(filename string) int {
+	b, err := os.ReadFile(filename)
+	if err != nil || len(b) == 0 {
+		return 0
+	}
+	return bytes.Count(bytes.TrimSuffix(b, []byte("\n")), []byte("\n")) + 1
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
 func (e Editor) parseLines(s string) []string {
 	lines := make([]string, 0)
 	for _, line := range strings.Split(s, "\n") {
@@ -355,7 +1124,7 @@ func (e Editor) parseActionColumns(lines []string) ([][]string, error) {
 	columns := make([][]string, len(lines))
 	for i, line := range lines {
 		columns[i] = strings.Fields(line)
-		if len(columns[i]) < 3 {
+		if len(columns[i]) < 2 {
 			return nil, errMissingColumn
 		}
 	}
@@ -375,99 +1144,161 @@ func (e Editor) parseIssues(columns [][]string, issueType string) ([]*jira.Issue
 }
 
 func (e Editor) parseIssue(columns []string, issueType string) (*jira.Issue, error) {
-	parentIndex, err := strconv.Atoi(columns[0])
-	if err != nil {
-		return nil, err
+	// handle issue and epic creations differently
+	parents := e.data.Epics
+	if issueType == "Epic" {
+		parents = e.data.Initiatives
 	}
-	sprintIndex, err := strconv.Atoi(columns[1])
+	sprintID, err := strconv.Atoi(strings.TrimSpace(columns[1]))
 	if err != nil {
 		return nil, err
 	}
-
-	summary := columns[2]
+	variables := NewVariables(e.data, e.jira)
+	summary := variables.Expand(columns[2])
 
 	storyPoints, err := strconv.ParseFloat(columns[3], 64)
 	if err != nil {
 		return nil, err
 	}
 
-	description := columns[4]
-
-	// handle issue and epic creations differently
-	parents := e.data.Epics
-	if issueType == "Epic" {
-		parents = e.data.Initiatives
-	}
+	description := variables.Expand(columns[4])
 
-	// verify parent index matches available parents
-	if parentIndex < 0 || parentIndex > len(parents) {
-		return nil, errParentMismatch
+	parent, hasParent, err := resolveParent(columns[0], parents)
+	if err != nil {
+		return nil, err
 	}
-
-	// verify sprint index matches available sprints
-	if sprintIndex < 0 || sprintIndex > len(e.data.Sprints) {
-		return nil, errSprintMismatch
+	sprint, hasSprint, err := resolveSprint(sprintID, e.data.Sprints)
+	if err != nil {
+		return nil, err
 	}
 
 	// map all custom fields
-	unknowns := make(map[string]any, 1)
-	unknowns[e.config.CustomFields.StoryPoints] = storyPoints
+	fields := map[string]any{fieldStoryPoints: storyPoints}
+	for name, value := range e.fields {
+		fields[name] = value
+	}
 
 	// setting epic or sprint to 0 means unassigned
-	if parentIndex != 0 && issueType == e.config.IssueType {
-		epic := e.data.Epics[parentIndex-1]
-		unknowns[e.config.CustomFields.Epics] = epic.Key
+	if hasParent && issueType == e.config.IssueType {
+		fields[fieldEpic] = parent.Key
 	}
 
 	// issues and epics have both different custom fields to set
-	if parentIndex != 0 && issueType == "Epic" {
-		initiative := e.data.Initiatives[parentIndex-1]
-		unknowns[e.config.CustomFields.EpicName] = summary
-		unknowns[e.config.CustomFields.ParentLink] = initiative.Key
+	if hasParent && issueType == "Epic" {
+		fields[fieldEpicName] = summary
+		fields[fieldParentLink] = parent.Key
+	}
+	unknowns, err := e.config.encodeFields(fields)
+	if err != nil {
+		return nil, err
 	}
 
 	var dueDate time.Time
-	if sprintIndex != 0 {
-		sprint := e.data.Sprints[sprintIndex-1]
+	if hasSprint {
 		unknowns[e.config.CustomFields.Sprints] = sprint.ID
 
-		// set issue due date to end of sprint if defined
+		// set issue due date to end of sprint in the board timezone if defined
 		if !sprint.EndDate.IsZero() {
-			dueDate = sprint.EndDate
+			loc, err := e.config.Location()
+			if err != nil {
+				return nil, err
+			}
+			dueDate = sprint.EndDate.In(loc)
 		}
 	}
 
-	// convert configured components
-	components := make([]*jira.Component, len(e.config.Components))
-	for i, component := range e.config.Components {
-		components[i] = &jira.Component{
-			Name: component,
+	issue := e.jira.newIssue(issueType, summary, description, unknowns)
+	if !dueDate.IsZero() {
+		issue.Fields.Duedate = jira.Date(dueDate)
+	}
+	if e.estimate != "" && issueType == e.config.IssueType {
+		issue.Fields.TimeTracking = &jira.TimeTracking{
+			OriginalEstimate:  e.estimate,
+			RemainingEstimate: e.estimate,
 		}
 	}
 
-	issue := jira.Issue{
-		Fields: &jira.IssueFields{
-			Project: jira.Project{
-				Key: e.config.Project,
-			},
-			Assignee: e.jira.user,
-			Reporter: e.jira.user,
-			Type: jira.IssueType{
-				Name: issueType,
-			},
-			Summary:     summary,
-			Description: description,
-			Unknowns:    unknowns,
-			Components:  components,
-			Labels:      e.config.Labels,
-		},
+	return issue, nil
+}
+
+// verifySprints refreshes stale sprints right before submitting and verifies
+// that the sprints the issues are added to have not been closed in the
+// meantime.
+func (e Editor) verifySprints(ctx context.Context, issues []*jira.Issue) error {
+	if !e.data.Stale() {
+		return nil
 	}
 
-	if !dueDate.IsZero() {
-		issue.Fields.Duedate = jira.Date(dueDate)
+	// the data lacks a Jira client if it was fresh when the editor opened
+	data := e.data
+	if data.jira.client == nil {
+		data.jira = e.jira
+	}
+	sprints, err := data.GetSprints(ctx)
+	if err != nil {
+		return err
+	}
+	open := make(map[int]struct{}, len(sprints))
+	for _, sprint := range sprints {
+		open[sprint.ID] = struct{}{}
+	}
+	for _, issue := range issues {
+		id, ok := issue.Fields.Unknowns[e.config.CustomFields.Sprints].(int)
+		if !ok {
+			continue
+		}
+		if _, ok := open[id]; !ok {
+			return fmt.Errorf("%w: %s", errSprintClosed, issue.Fields.Summary)
+		}
+	}
+	return nil
+}
+
+// resolveParent returns the parent referenced by key or by its ID in the
+// template. Keys are resolved first since they remain stable if the list of
+// parents changes. The ID 0 means unassigned.
+func resolveParent(s string, parents Issues) (Issue, bool, error) {
+	s = strings.TrimSpace(s)
+	for _, parent := range parents {
+		if strings.EqualFold(parent.Key, s) {
+			return parent, true, nil
+		}
+	}
+	index, err := strconv.Atoi(s)
+	if err != nil || index < 0 || index > len(parents) {
+		return Issue{}, false, fmt.Errorf("%w: %s", errParentMismatch, s)
 	}
+	if index == 0 {
+		return Issue{}, false, nil
+	}
+	return parents[index-1], true, nil
+}
 
-	return &issue, nil
+// resolveSprint returns the sprint referenced by sprint ID or by its ID in
+// the template. The ID 0 means unassigned. A number which references one
+// sprint by sprint ID and another by its ID in the template is rejected since
+// either could be meant.
+func resolveSprint(id int, sprints Sprints) (Sprint, bool, error) {
+	if id == 0 {
+		return Sprint{}, false, nil
+	}
+	var byIndex *Sprint
+	if id > 0 && id <= len(sprints) {
+		byIndex = &sprints[id-1]
+	}
+	for _, sprint := range sprints {
+		if sprint.ID != id {
+			continue
+		}
+		if byIndex != nil && byIndex.ID != sprint.ID {
+			return Sprint{}, false, fmt.Errorf("%w: %d is %s by key and %s by ID", errSprintAmbiguous, id, sprint.Name, byIndex.Name)
+		}
+		return sprint, true, nil
+	}
+	if byIndex == nil {
+		return Sprint{}, false, fmt.Errorf("%w: %d", errSprintMismatch, id)
+	}
+	return *byIndex, true, nil
 }
 
 func (e Editor) issueTemplate() string {
@@ -481,26 +1312,26 @@ func (e Editor) issueTemplate() string {
 	// Epics template
 	fmt.Fprint(w, "# Epics\n")
 	fmt.Fprint(w, "#\n")
-	fmt.Fprint(w, "# ID\t|\tKey\t|\tPriority\t|\tSummary\n")
-	fmt.Fprintf(w, "# --\t|\t%s\t|\t--------\t|\t%s\n", keyBorder, summaryBorder)
-	fmt.Fprintf(w, "# %d\t|\t%s\t|\t%s\t|\t%s\n", 0, "", "", "Unassigned")
-	fmt.Fprintf(w, "# --\t|\t%s\t|\t--------\t|\t%s\n", keyBorder, summaryBorder)
+	fmt.Fprint(w, "# ID\t|\tKey\t|\tStatus\t|\tColor\t|\tPriority\t|\tSummary\n")
+	fmt.Fprintf(w, "# --\t|\t%s\t|\t------\t|\t-----\t|\t--------\t|\t%s\n", keyBorder, summaryBorder)
+	fmt.Fprintf(w, "# %d\t|\t%s\t|\t%s\t|\t%s\t|\t%s\t|\t%s\n", 0, "", "", "", "", "Unassigned")
+	fmt.Fprintf(w, "# --\t|\t%s\t|\t------\t|\t-----\t|\t--------\t|\t%s\n", keyBorder, summaryBorder)
 
 	for i, epic := range e.data.Epics {
-		fmt.Fprintf(w, "# %d\t|\t%s\t|\t%s\t|\t%s\n", i+1, epic.Key, epic.Priority, epic.Summary)
+		fmt.Fprintf(w, "# %d\t|\t%s\t|\t%s\t|\t%s\t|\t%s\t|\t%s\n", i+1, epic.Key, epic.StatusName(), epic.ColorName(), epic.Priority, epic.Summary)
 	}
 
-	fmt.Fprintf(w, "# --\t|\t%s\t|\t--------\t|\t%s\n", keyBorder, summaryBorder)
+	fmt.Fprintf(w, "# --\t|\t%s\t|\t------\t|\t-----\t|\t--------\t|\t%s\n", keyBorder, summaryBorder)
 	fmt.Fprint(w, "#\n#\n")
 
 	// Sprints template
 	fmt.Fprint(w, "# Sprints\n")
 	fmt.Fprint(w, "#\n")
-	fmt.Fprint(w, "# ID\t|\tName\n")
-	fmt.Fprint(w, "# --\t|\t----\n")
-	fmt.Fprint(w, "# 0\t|\tUnassigned\n")
+	fmt.Fprint(w, "# ID\t|\tKey\t|\tName\n")
+	fmt.Fprint(w, "# --\t|\t---\t|\t----\n")
+	fmt.Fprint(w, "# 0\t|\t\t|\tUnassigned\n")
 	for i, sprint := range e.data.Sprints {
-		fmt.Fprintf(w, "# %d\t|\t%s\n", i+1, sprint.Name)
+		fmt.Fprintf(w, "# %d\t|\t%d\t|\t%s\n", i+1, sprint.ID, sprint.Name)
 	}
 
 	fmt.Fprint(w, "#\n")
@@ -509,13 +1340,20 @@ func (e Editor) issueTemplate() string {
 	fmt.Fprint(w, "# New Issues\n")
 	fmt.Fprint(w, "#\n")
 	fmt.Fprint(w, "# Epic, Sprint, Summary, Story Points, Description\n")
+	fmt.Fprint(w, "#\n")
+	fmt.Fprint(w, "# The epic and sprint are referenced by key or ID\n")
+	fmt.Fprint(w, "#\n")
+	fmt.Fprint(w, "# Indented rows are subtasks of the issue above with the columns\n")
+	fmt.Fprint(w, "# Summary, Story Points, Description. A row with only an issue key\n")
+	fmt.Fprint(w, "# adds the subtasks below to an existing issue.\n")
+	fmt.Fprint(w, "# Variables: {{sprint}}, {{today}}, {{me}}, {{branch}}\n")
 	fmt.Fprint(w, "\n")
 
 	w.Flush()
 	return b.String()
 }
 
-func (e Editor) epicTemplate() string {
+func (e Editor) epicTemplate(withIssues bool) string {
 	var b bytes.Buffer
 	w := tabwriter.NewWriter(&b, 1, 1, 1, ' ', 0)
 
@@ -541,11 +1379,11 @@ func (e Editor) epicTemplate() string {
 	// Sprints template
 	fmt.Fprint(w, "# Sprints\n")
 	fmt.Fprint(w, "#\n")
-	fmt.Fprint(w, "# ID\t|\tName\n")
-	fmt.Fprint(w, "# --\t|\t----\n")
-	fmt.Fprint(w, "# 0\t|\tUnassigned\n")
+	fmt.Fprint(w, "# ID\t|\tKey\t|\tName\n")
+	fmt.Fprint(w, "# --\t|\t---\t|\t----\n")
+	fmt.Fprint(w, "# 0\t|\t\t|\tUnassigned\n")
 	for i, sprint := range e.data.Sprints {
-		fmt.Fprintf(w, "# %d\t|\t%s\n", i+1, sprint.Name)
+		fmt.Fprintf(w, "# %d\t|\t%d\t|\t%s\n", i+1, sprint.ID, sprint.Name)
 	}
 
 	fmt.Fprint(w, "#\n")
@@ -554,6 +1392,14 @@ func (e Editor) epicTemplate() string {
 	fmt.Fprint(w, "# New Epics\n")
 	fmt.Fprint(w, "#\n")
 	fmt.Fprint(w, "# Initiative, Sprint, Summary, Story Points, Description\n")
+	fmt.Fprint(w, "#\n")
+	fmt.Fprint(w, "# The initiative and sprint are referenced by key or ID\n")
+	if withIssues {
+		fmt.Fprint(w, "#\n")
+		fmt.Fprint(w, "# Indented lines below an epic create issues of the epic:\n")
+		fmt.Fprint(w, "#   Sprint, Summary, Story Points, Description\n")
+	}
+	fmt.Fprint(w, "# Variables: {{sprint}}, {{today}}, {{me}}, {{branch}}\n")
 	fmt.Fprint(w, "\n")
 
 	w.Flush()
@@ -573,7 +1419,7 @@ func (e Editor) sprintTemplate(includeDone bool) string {
 	}
 	fmt.Fprint(w, "\n")
 
-	fmt.Fprintf(w, "# Update the status of any sprint issues\n")
+	fmt.Fprintf(w, "# Update the status of any sprint issues or edit their summary\n")
 	fmt.Fprint(w, "#\n")
 	fmt.Fprint(w, "# Commands:\n")
 	fmt.Fprint(w, "#\n")
@@ -583,11 +1429,54 @@ func (e Editor) sprintTemplate(includeDone bool) string {
 	}
 	fmt.Fprint(w, "#\n")
 	fmt.Fprintf(w, "# %s\t<key> =\tMove into backlog\n", backlogAcronym)
+	fmt.Fprintf(w, "# %s\t<key> <user> =\tAssign to user, %s or %s\n", assignAcronym, assignSelf, assignNone)
+	fmt.Fprint(w, "#\n")
+	for i, priority := range e.config.PriorityNames() {
+		fmt.Fprintf(w, "# p%d\t<key> =\tSet priority to %s\n", i+1, priority)
+	}
+	fmt.Fprint(w, "#\n")
+	fmt.Fprint(w, "# Commands can be customized with statusAliases and priorities in the configuration.\n")
 
 	w.Flush()
 	return b.String()
 }
 
+func (e Editor) rolloverTemplate(activeSprint, nextSprint Sprint) string {
+	var b bytes.Buffer
+	w := tabwriter.NewWriter(&b, 1, 1, 1, ' ', 0)
+
+	// List incomplete issues to be carried over
+	for _, issue := range e.data.SprintIssues.Sort() {
+		if issue.Status.IsDone {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", nextSprintAcronym, issue.Key, issue.Summary)
+	}
+	fmt.Fprint(w, "\n")
+
+	fmt.Fprintf(w, "# Roll over incomplete issues of %s\n", activeSprint.Name)
+	fmt.Fprint(w, "#\n")
+	fmt.Fprint(w, "# Commands:\n")
+	fmt.Fprint(w, "#\n")
+	fmt.Fprintf(w, "# %s\t<key> =\tMove into %s\n", nextSprintAcronym, nextSprint.Name)
+	fmt.Fprintf(w, "# %s\t<key> =\tMove into backlog\n", backlogAcronym)
+
+	w.Flush()
+	return b.String()
+}
+
+func (e Editor) commentTemplate(key string) string {
+	var b bytes.Buffer
+	header := key
+	if issue, ok := e.data.snapshot()[key]; ok {
+		header += " " + issue.Summary
+	}
+	fmt.Fprintf(&b, "# Comment on %s\n", header)
+	fmt.Fprintln(&b, "# Lines starting with # are ignored, an empty comment aborts")
+	fmt.Fprintln(&b)
+	return b.String()
+}
+
 func (e Editor) editIssueTemplate(key string, yaml []byte) string {
 	var b bytes.Buffer
 	fmt.Fprintf(&b, "# %s\n", key)
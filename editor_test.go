@@ -0,0 +1,556 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAnnotateErrors(t *testing.T) {
+	content := "# Issues\n" +
+		"# error: outdated\n" +
+		"0,0,Created,1,\n" +
+		"0,0,Rejected,x,\n" +
+		"0,0,Failed,1,\n"
+	errs := CreateIssuesError{
+		1: &ResponseError{
+			Errors: map[string]string{
+				"customfield_10002": "Field 'customfield_10002' must be a number",
+			},
+		},
+		2: errors.New("timeout"),
+	}
+	names := map[string]string{"customfield_10002": "Story Points"}
+
+	got, messages := annotateErrors(content, errs, names)
+	want := "# Issues\n" +
+		"# error: Field 'Story Points' must be a number\n" +
+		"0,0,Rejected,x,\n" +
+		"# error: timeout\n" +
+		"0,0,Failed,1,\n"
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	wantMessages := []string{
+		"line 4: Field 'Story Points' must be a number",
+		"line 5: timeout",
+	}
+	if diff := cmp.Diff(messages, wantMessages); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestResolveSprint(t *testing.T) {
+	sprints := Sprints{{ID: 2, Name: "Komodo"}, {ID: 418, Name: "Gorilla"}, {ID: 3, Name: "Macaque"}}
+	tests := []struct {
+		id   int
+		want string
+		err  error
+	}{
+		{id: 0, want: ""},
+		{id: 418, want: "Gorilla"},
+		{id: 2, err: errSprintAmbiguous},
+		{id: 1, want: "Komodo"},
+		{id: 3, want: "Macaque"},
+		{id: 4, err: errSprintMismatch},
+	}
+	for _, tt := range tests {
+		sprint, _, err := resolveSprint(tt.id, sprints)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("got %v, want: %v", err, tt.err)
+		}
+		if sprint.Name != tt.want {
+			t.Errorf("got %v, want: %v", sprint.Name, tt.want)
+		}
+	}
+}
+
+func TestResolveParent(t *testing.T) {
+	epics := Issues{{Key: "KONG-1"}, {Key: "KONG-2"}}
+	tests := []struct {
+		s    string
+		want string
+		err  error
+	}{
+		{s: "0", want: ""},
+		{s: "KONG-2", want: "KONG-2"},
+		{s: "kong-1", want: "KONG-1"},
+		{s: "2", want: "KONG-2"},
+		{s: "KONG-3", err: errParentMismatch},
+		{s: "3", err: errParentMismatch},
+	}
+	for _, tt := range tests {
+		parent, _, err := resolveParent(tt.s, epics)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("got %v, want: %v", err, tt.err)
+		}
+		if parent.Key != tt.want {
+			t.Errorf("got %v, want: %v", parent.Key, tt.want)
+		}
+	}
+}
+
+func TestParseEpicPlans(t *testing.T) {
+	editor := Editor{
+		config: Config{
+			IssueType: "Story",
+			CustomFields: CustomFields{
+				Epics: "customfield_10008",
+			},
+		},
+	}
+	lines := []string{
+		"0,0,Epic A,0,",
+		"  0,Issue A1,1,",
+		"\t0,Issue A2,2,",
+		"0,0,Epic B,0,",
+	}
+	plans, err := editor.parseEpicPlans(lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, plan := range plans {
+		got = append(got, plan.epic.Fields.Summary)
+		for _, issue := range plan.issues {
+			got = append(got, "  "+issue.Fields.Summary)
+		}
+	}
+	want := []string{"Epic A", "  Issue A1", "  Issue A2", "Epic B"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+
+	_, err = editor.parseEpicPlans([]string{"  0,Issue,1,"})
+	if !errors.Is(err, errMissingEpic) {
+		t.Errorf("got %v, want: %v", err, errMissingEpic)
+	}
+}
+
+func TestCreateEpicPlanRows(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+	session.data.reset()
+	session.state.reset()
+
+	var (
+		created []string
+		next    = 10
+	)
+	client := newTestJira(t, func(req *http.Request) (*http.Response, error) {
+		var issue jira.Issue
+		if err := json.NewDecoder(req.Body).Decode(&issue); err != nil {
+			return nil, err
+		}
+		if issue.Fields.Summary == "Rejected" {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Body:       io.NopCloser(strings.NewReader(`{"errorMessages": ["rejected"]}`)),
+			}, nil
+		}
+		epic, _ := issue.Fields.Unknowns["customfield_10008"].(string)
+		created = append(created, issue.Fields.Summary+" "+epic)
+		key := fmt.Sprintf("KONG-%d", next)
+		next++
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       io.NopCloser(strings.NewReader(`{"key": "` + key + `"}`)),
+		}, nil
+	})
+	config := Config{
+		IssueType:    "Story",
+		CustomFields: CustomFields{Epics: "customfield_10008"},
+	}
+	editor := Editor{jira: Jira{client: client, config: config}, config: config}
+	content := "# New Epics\n" +
+		"0,0,Epic A,0,\n" +
+		"  0,Issue A1,1,\n" +
+		"  0,Rejected,1,\n" +
+		"  0,Issue A2,1,\n" +
+		"0,0,Rejected,0,\n" +
+		"  0,Orphan,1,\n"
+	plans, err := editor.parseEpicPlans(editor.parseLines(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := epicPlanRows(plans)
+
+	keys, err := editor.jira.createEpicPlanRows(context.Background(), rows)
+	var createErr CreateIssuesError
+	if !errors.As(err, &createErr) {
+		t.Fatalf("got %v, want: CreateIssuesError", err)
+	}
+	if !errors.Is(createErr[5], errParentNotCreated) {
+		t.Errorf("got %v, want: %v", createErr[5], errParentNotCreated)
+	}
+	// issues are created in the order of the file within their epic
+	want := []string{"Epic A ", "Issue A1 KONG-10", "Issue A2 KONG-10"}
+	if diff := cmp.Diff(created, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	if diff := cmp.Diff(createdKeys(rows, keys), []string{"KONG-10", "KONG-11", "KONG-12"}); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+
+	// the created epic is kept by key to retry its failed issue
+	annotated, _ := annotateErrors(replaceParentRows(content, rows, keys, createErr), createErr, nil)
+	wantContent := "# New Epics\n" +
+		"KONG-10\n" +
+		"# error: rejected\n" +
+		"  0,Rejected,1,\n" +
+		"# error: rejected\n" +
+		"0,0,Rejected,0,\n" +
+		"# error: parent issue was not created\n" +
+		"  0,Orphan,1,\n"
+	if diff := cmp.Diff(annotated, wantContent); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	plans, err = editor.parseEpicPlans(editor.parseLines(annotated))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plans[0].key != "KONG-10" || plans[0].epic != nil {
+		t.Errorf("got %v, want: existing epic KONG-10", plans[0].key)
+	}
+
+	// issues cannot be linked to their epic without the epic field
+	editor.jira.config.CustomFields.Epics = ""
+	if _, err := editor.jira.createEpicPlanRows(context.Background(), rows); !errors.Is(err, errConfigEpicField) {
+		t.Errorf("got %v, want: %v", err, errConfigEpicField)
+	}
+}
+
+func TestParseSprintEditRename(t *testing.T) {
+	done := Transition{ID: "31", Name: "Done", Acronym: "d"}
+	editor := Editor{
+		data: Data{
+			IssueByKey: map[string]Issue{
+				"KONG-1": {
+					Key:                  "KONG-1",
+					Summary:              "Add  socket",
+					Status:               Status{Name: "In Progress", Acronym: "i"},
+					TransitionsByAcronym: map[string]Transition{"d": done},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name        string
+		line        string
+		renamed     []Issue
+		transitions []issueTransition
+	}{
+		{
+			name: "unchanged",
+			line: "i KONG-1 Add socket",
+		},
+		{
+			name: "without-summary",
+			line: "i KONG-1",
+		},
+		{
+			name:    "renamed",
+			line:    "i KONG-1 Add unix socket",
+			renamed: []Issue{{Key: "KONG-1", Summary: "Add unix socket"}},
+		},
+		{
+			name:        "transitioned",
+			line:        "d KONG-1 Add socket",
+			transitions: []issueTransition{{issueKey: "KONG-1", transition: done}},
+		},
+		{
+			name:        "renamed-and-transitioned",
+			line:        "d KONG-1 Add unix socket",
+			renamed:     []Issue{{Key: "KONG-1", Summary: "Add unix socket"}},
+			transitions: []issueTransition{{issueKey: "KONG-1", transition: done}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, err := editor.parseActionColumns([]string{tt.line})
+			if err != nil {
+				t.Fatal(err)
+			}
+			edit, err := editor.parseSprintEdit(columns)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(edit.renamed, tt.renamed); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+			if diff := cmp.Diff(edit.transitions, tt.transitions, cmp.AllowUnexported(issueTransition{})); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestParseSprintEditAssign(t *testing.T) {
+	editor := Editor{
+		data: Data{
+			IssueByKey: map[string]Issue{"KONG-1": {Key: "KONG-1", Summary: "Add socket"}},
+		},
+	}
+	tests := []struct {
+		line string
+		want []issueAssignee
+	}{
+		{line: "@ KONG-1 me", want: []issueAssignee{{issueKey: "KONG-1", user: "me"}}},
+		{line: "@ KONG-1 jdoe Add socket", want: []issueAssignee{{issueKey: "KONG-1", user: "jdoe"}}},
+		{line: "@ KONG-1", want: []issueAssignee{{issueKey: "KONG-1"}}},
+	}
+	for _, tt := range tests {
+		columns, err := editor.parseActionColumns([]string{tt.line})
+		if err != nil {
+			t.Fatal(err)
+		}
+		edit, err := editor.parseSprintEdit(columns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(edit.assignees, tt.want, cmp.AllowUnexported(issueAssignee{})); diff != "" {
+			t.Errorf("%s: diff: %s", tt.line, diff)
+		}
+		if len(edit.renamed) > 0 {
+			t.Errorf("%s: got %v, want: no rename", tt.line, edit.renamed)
+		}
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "nano")
+	if got, want := editorCommand(""), "nano"; got != want {
+		t.Errorf("got %v, want: %v", got, want)
+	}
+	if got, want := editorCommand("code --wait"), "code --wait"; got != want {
+		t.Errorf("got %v, want: %v", got, want)
+	}
+	t.Setenv("VISUAL", "emacs")
+	if got, want := editorCommand(""), "emacs"; got != want {
+		t.Errorf("got %v, want: %v", got, want)
+	}
+}
+
+func TestEditorFields(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("executables are looked up by extension")
+	}
+	path := filepath.Join(t.TempDir(), "Sublime Text", "subl")
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, nil, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		command string
+		want    []string
+	}{
+		{
+			name:    "path-with-spaces",
+			command: path,
+			want:    []string{path},
+		},
+		{
+			name:    "quoted-path-with-arguments",
+			command: `"` + path + `" --wait`,
+			want:    []string{path, "--wait"},
+		},
+		{
+			name:    "arguments",
+			command: "code --wait",
+			want:    []string{"code", "--wait"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := editorFields(tt.command)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestEditorArgs(t *testing.T) {
+	tests := []struct {
+		name   string
+		editor []string
+		line   int
+		want   []string
+	}{
+		{
+			name:   "vim",
+			editor: []string{"/usr/bin/nvim"},
+			line:   12,
+			want:   []string{"issues.txt", "-c", "norm! G"},
+		},
+		{
+			name:   "nano",
+			editor: []string{"nano"},
+			line:   12,
+			want:   []string{"+12", "issues.txt"},
+		},
+		{
+			name:   "emacsclient",
+			editor: []string{"emacsclient", "-t"},
+			line:   12,
+			want:   []string{"-t", "+12", "issues.txt"},
+		},
+		{
+			name:   "vscode",
+			editor: []string{"code", "--wait"},
+			line:   12,
+			want:   []string{"--wait", "--goto", "issues.txt:12"},
+		},
+		{
+			name:   "unknown",
+			editor: []string{"ed"},
+			line:   12,
+			want:   []string{"issues.txt"},
+		},
+		{
+			name:   "first line",
+			editor: []string{"nano"},
+			want:   []string{"issues.txt"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := editorArgs(tt.editor[0], tt.editor[1:], "issues.txt", tt.line)
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestLineCount(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "issues.txt")
+	if err := os.WriteFile(filename, []byte("# header\n\nKONG-1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := lineCount(filename), 3; got != want {
+		t.Errorf("got %v, want: %v", got, want)
+	}
+}
+
+func TestVerifySprintsWithFreshData(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+	session.data.reset()
+	data := NewData()
+	data.Timestamp = time.Now().Unix()
+	data.BoardID = 1
+	data.Sprints = Sprints{{ID: 5, Name: "Sprint 5"}, {ID: 6, Name: "Sprint 6"}}
+	if err := data.WriteFile(); err != nil {
+		t.Fatal(err)
+	}
+	client := newTestJira(t, func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"values": [{"id": 6, "name": "Sprint 6", "state": "active"}], "isLast": true}`)),
+		}, nil
+	})
+
+	// fresh data is loaded without a Jira client
+	loaded, err := LoadData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := Config{CustomFields: CustomFields{Sprints: "customfield_10001"}}
+	editor := Editor{
+		jira:   Jira{client: client, config: config},
+		data:   loaded,
+		config: config,
+	}
+
+	// the data turns stale while the editor is open
+	editor.data.Timestamp = time.Now().Add(-time.Hour).Unix()
+	issues := []*jira.Issue{{Fields: &jira.IssueFields{
+		Summary:  "Closed",
+		Unknowns: map[string]any{"customfield_10001": 5},
+	}}}
+	if err := editor.verifySprints(context.Background(), issues); !errors.Is(err, errSprintClosed) {
+		t.Errorf("got %v, want: %v", err, errSprintClosed)
+	}
+	issues[0].Fields.Unknowns["customfield_10001"] = 6
+	if err := editor.verifySprints(context.Background(), issues); err != nil {
+		t.Errorf("got %v, want: nil", err)
+	}
+}
+
+func TestEditorConfirmWithInput(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() { os.Stdin = stdin })
+	w.Close()
+
+	var editor Editor
+	editor.SetInput("-")
+	ok, err := editor.confirm("Update issue?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Errorf("got %v, want: %v", ok, true)
+	}
+	editor.SetInput("")
+	if _, err := editor.confirm("Update issue?"); !errors.Is(err, errNoAnswer) {
+		t.Errorf("got %v, want: %v", err, errNoAnswer)
+	}
+}
+
+func TestParseRollover(t *testing.T) {
+	editor := Editor{
+		data: Data{
+			SprintIssues: Issues{
+				{Key: "KONG-1", Summary: "Add socket", Status: Status{Name: "In Progress"}},
+				{Key: "KONG-2", Summary: "Add daemon", Status: Status{Name: "Done", IsDone: true}},
+				{Key: "KONG-3", Summary: "Add fallback", Status: Status{Name: "To Do"}},
+			},
+		},
+	}
+	template := editor.rolloverTemplate(Sprint{Name: "Sprint 1"}, Sprint{Name: "Sprint 2"})
+
+	// move KONG-3 into the backlog instead of the next sprint
+	content := strings.Replace(template, "next KONG-3", "ice  KONG-3", 1)
+	columns, err := editor.parseActionColumns(editor.parseLines(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	toSprint, toBacklog, err := parseRollover(columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(toSprint, []string{"KONG-1"}); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	if diff := cmp.Diff(toBacklog, []string{"KONG-3"}); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+
+	_, _, err = parseRollover([][]string{{"d", "KONG-1"}})
+	if !errors.Is(err, errUnknownTransition) {
+		t.Errorf("got %v, want: %v", err, errUnknownTransition)
+	}
+}
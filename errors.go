@@ -17,6 +17,18 @@ var ErrDataMissing = errors.New("data file missing")
 // sprints.
 var ErrNoActiveSprint = errors.New("no active sprint")
 
+// ErrNoFutureSprint is returned when there is no future sprint in a list of
+// sprints.
+var ErrNoFutureSprint = errors.New("no future sprint")
+
+// ErrNoBoard is returned when the project has no agile board to read sprints
+// from.
+var ErrNoBoard = errors.New("no agile board, sprint features disabled")
+
+// ErrReadOnly is returned when a command which changes Jira is run in
+// read-only mode.
+var ErrReadOnly = errors.New("command disabled in read-only mode")
+
 // ErrCreateSprint is used to wrap the Jira API response returned on sprint
 // creation failure.
 type ErrCreateSprint string
@@ -25,6 +37,11 @@ func (e ErrCreateSprint) Error() string {
 	return string(e)
 }
 
+// boardError reports that the project has no agile board.
+func boardError(project string) error {
+	return fmt.Errorf("project %s has %w", project, ErrNoBoard)
+}
+
 func printDaemonWarning() {
 	fmt.Fprintln(os.Stderr, "Warning: daemon not running, check logs. Performing slow request.")
 }
@@ -0,0 +1,47 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+var errInvalidEstimate = errors.New("invalid estimate, expected a duration like 2d or 1w 2d 4h")
+
+// estimatePattern matches the durations Jira accepts for time tracking.
+var estimatePattern = regexp.MustCompile(`^[0-9]+[wdhm]( [0-9]+[wdhm])*$`)
+
+// ValidateEstimate returns an error if the estimate is not a Jira duration
+// like 2d or 1w 2d 4h.
+func ValidateEstimate(estimate string) error {
+	if !estimatePattern.MatchString(estimate) {
+		return fmt.Errorf("%w: %q", errInvalidEstimate, estimate)
+	}
+	return nil
+}
+
+// SetRemainingEstimate changes the remaining estimate of the issue while
+// keeping its original estimate.
+func (j Jira) SetRemainingEstimate(ctx context.Context, key, estimate string) error {
+	if err := ValidateEstimate(estimate); err != nil {
+		return fmt.Errorf("SetRemainingEstimate: %w", err)
+	}
+	data := map[string]interface{}{
+		"update": map[string][]map[string]interface{}{
+			"timetracking": {
+				{
+					"edit": map[string]string{
+						"remainingEstimate": estimate,
+					},
+				},
+			},
+		},
+	}
+	resp, err := j.client.Issue.UpdateIssueWithContext(ctx, key, data)
+	if err != nil {
+		return fmt.Errorf("SetRemainingEstimate: %w", parseResponseError(resp))
+	}
+	fmt.Printf("%s - Remaining estimate changed to %s\n", key, estimate)
+	return nil
+}
@@ -0,0 +1,26 @@
+package kong
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateEstimate(t *testing.T) {
+	tests := []struct {
+		estimate string
+		want     error
+	}{
+		{estimate: "2d", want: nil},
+		{estimate: "1w 2d 4h 30m", want: nil},
+		{estimate: "", want: errInvalidEstimate},
+		{estimate: "2 days", want: errInvalidEstimate},
+		{estimate: "4h2m", want: errInvalidEstimate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.estimate, func(t *testing.T) {
+			if err := ValidateEstimate(tt.estimate); !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want: %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package kong
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// markdownEscaper escapes the characters of issue summaries which Markdown
+// would interpret as formatting or links.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"[", `\[`,
+	"]", `\]`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+)
+
+// vimPlugin completes issue keys with <C-x><C-u> in commit messages and
+// notes, matching either the key or the summary.
+const vimPlugin = `
+function! KongComplete(findstart, base) abort
+  if a:findstart
+    let line = getline('.')
+    let start = col('.') - 1
+    while start > 0 && line[start - 1] =~# '[A-Za-z0-9-]'
+      let start -= 1
+    endwhile
+    return start
+  endif
+  return filter(copy(g:kong_issues), 'stridx(v:val.word, toupper(a:base)) == 0 || stridx(tolower(v:val.menu), tolower(a:base)) >= 0')
+endfunction
+
+augroup kong
+  autocmd!
+  autocmd FileType gitcommit,markdown,org,text setlocal completefunc=KongComplete
+augroup END
+`
+
+// ExportVim writes the cached issues and epics as Vim completion items to
+// g:kong_issues so that issue keys can be completed offline. If plugin is set
+// a completion function for commit messages and notes is written as well.
+func (d Data) ExportVim(output io.Writer, plugin bool) error {
+	issues := d.snapshot()
+	keys := make([]string, 0, len(issues))
+	for key := range issues {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("\" Generated by kong export vim\n")
+	b.WriteString("let g:kong_issues = [\n")
+	for _, key := range keys {
+		fmt.Fprintf(&b, "      \\ {'word': %s, 'menu': %s},\n", vimString(key), vimString(issues[key].Summary))
+	}
+	b.WriteString("      \\ ]\n")
+	if plugin {
+		b.WriteString(vimPlugin)
+	}
+	_, err := io.WriteString(output, b.String())
+	return err
+}
+
+// vimString quotes s as a literal Vim string in which only single quotes are
+// escaped by doubling them.
+func vimString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// ExportBoard writes the sprint issues as Markdown kanban board with a
+// section for each status in the order of the workflow. Each issue links to
+// Jira.
+func (d Data) ExportBoard(output io.Writer, config Config) error {
+	if err := d.CheckBoard(); err != nil {
+		return err
+	}
+	title := "Sprint"
+	if sprint, err := d.Sprints.ActiveSprint(); err == nil {
+		title = sprint.Name
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s\n", title)
+	byStatus := make(map[string]Issues)
+	for _, issue := range d.SprintIssues {
+		byStatus[issue.Status.Name] = append(byStatus[issue.Status.Name], issue)
+	}
+	for _, status := range boardColumns(d.SprintIssues) {
+		fmt.Fprintf(&b, "\n### %s\n\n", status)
+		for _, issue := range byStatus[status] {
+			fmt.Fprintf(&b, "- [%s](%s) %s", issue.Key, config.BrowseURL(issue.Key), markdownEscaper.Replace(issue.Summary))
+			if issue.StoryPoints > 0 {
+				fmt.Fprintf(&b, " (%s pts)", formatValue(issue.StoryPoints))
+			}
+			b.WriteString("\n")
+		}
+	}
+	_, err := io.WriteString(output, b.String())
+	return err
+}
+
+// boardColumns returns the statuses of the workflow of the issues in order,
+// including statuses without issues.
+func boardColumns(issues Issues) []string {
+	order := make(map[string]int)
+	for _, issue := range issues {
+		for status, i := range issue.OrderByTransitionStatus {
+			if _, ok := order[status]; !ok {
+				order[status] = i
+			}
+		}
+	}
+	for _, issue := range issues {
+		if _, ok := order[issue.Status.Name]; !ok {
+			order[issue.Status.Name] = len(order)
+		}
+	}
+	columns := make([]string, 0, len(order))
+	for status := range order {
+		columns = append(columns, status)
+	}
+	sort.Slice(columns, func(i, j int) bool {
+		if order[columns[i]] != order[columns[j]] {
+			return order[columns[i]] < order[columns[j]]
+		}
+		return columns[i] < columns[j]
+	})
+	return columns
+}
+
+// ExportCSV writes all cached issues and epics sorted by key as
+// comma-separated values, see Issues.PrintCSV.
+func (d Data) ExportCSV(output io.Writer) error {
+	snapshot := d.snapshot()
+	issues := make(Issues, 0, len(snapshot))
+	for _, issue := range snapshot {
+		issues = append(issues, issue)
+	}
+	sort.Slice(issues, func(i, j int) bool {
+		return issues[i].Key < issues[j].Key
+	})
+	return issues.PrintCSV(output, d.Sprints)
+}
@@ -0,0 +1,80 @@
+package kong
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExportVim(t *testing.T) {
+	data := NewData()
+	data.Issues = Issues{{Key: "KONG-2", Summary: "Don't block on GetSelf"}}
+	data.Epics = Issues{{Key: "KONG-1", Summary: "Vim plugin"}}
+
+	var buf bytes.Buffer
+	if err := data.ExportVim(&buf, false); err != nil {
+		t.Fatal(err)
+	}
+	want := "\" Generated by kong export vim\n" +
+		"let g:kong_issues = [\n" +
+		"      \\ {'word': 'KONG-1', 'menu': 'Vim plugin'},\n" +
+		"      \\ {'word': 'KONG-2', 'menu': 'Don''t block on GetSelf'},\n" +
+		"      \\ ]\n"
+	if diff := cmp.Diff(buf.String(), want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestExportBoard(t *testing.T) {
+	order := map[string]int{"To Do": 0, "In Progress": 1, "Done": 2}
+	data := NewData()
+	data.Sprints = Sprints{{ID: 1, Name: "Sprint 12", State: "active"}}
+	data.SprintIssues = Issues{
+		{Key: "KONG-1", Summary: "Fix [flaky] test", Status: Status{Name: "In Progress"}, StoryPoints: 3, OrderByTransitionStatus: order},
+		{Key: "KONG-2", Summary: "Write docs", Status: Status{Name: "To Do"}, OrderByTransitionStatus: order},
+		{Key: "KONG-3", Summary: "Review", Status: Status{Name: "Blocked"}, OrderByTransitionStatus: order},
+	}
+	config := Config{Endpoint: "https://jira.example.com/"}
+
+	var buf bytes.Buffer
+	if err := data.ExportBoard(&buf, config); err != nil {
+		t.Fatal(err)
+	}
+	want := "## Sprint 12\n" +
+		"\n### To Do\n\n" +
+		"- [KONG-2](https://jira.example.com/browse/KONG-2) Write docs\n" +
+		"\n### In Progress\n\n" +
+		"- [KONG-1](https://jira.example.com/browse/KONG-1) Fix \\[flaky\\] test (3 pts)\n" +
+		"\n### Done\n\n" +
+		"\n### Blocked\n\n" +
+		"- [KONG-3](https://jira.example.com/browse/KONG-3) Review\n"
+	if diff := cmp.Diff(buf.String(), want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+
+	data.NoBoard = true
+	if err := data.ExportBoard(&buf, config); !errors.Is(err, ErrNoBoard) {
+		t.Errorf("got %v, want: %v", err, ErrNoBoard)
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	data := NewData()
+	data.Issues = Issues{{Key: "KONG-2", Summary: "Issue", SprintID: 1}}
+	data.SprintIssues = Issues{{Key: "KONG-2", Summary: "Issue", SprintID: 1}}
+	data.Epics = Issues{{Key: "KONG-1", Summary: "Epic"}}
+	data.Sprints = Sprints{{ID: 1, Name: "Sprint 1"}}
+
+	var buf bytes.Buffer
+	if err := data.ExportCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "Key,Summary,Status,Priority,Story Points,Epic,Sprint,Labels,Created,Updated\n" +
+		"KONG-1,Epic,,,0,,,,,\n" +
+		"KONG-2,Issue,,,0,,Sprint 1,,,\n"
+	if diff := cmp.Diff(buf.String(), want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
@@ -0,0 +1,435 @@
+package kong
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	errFilterSyntax = errors.New("invalid filter")
+	errFilterField  = errors.New("unknown filter field")
+	errFilterType   = errors.New("mismatched types in filter")
+)
+
+// filterFields are the issue fields available in filter expressions.
+var filterFields = map[string]func(Issue) any{
+	"key":         func(i Issue) any { return i.Key },
+	"summary":     func(i Issue) any { return i.Summary },
+	"description": func(i Issue) any { return i.Description },
+	"status":      func(i Issue) any { return i.Status.Name },
+	"priority":    func(i Issue) any { return i.Priority },
+	"points":      func(i Issue) any { return i.StoryPoints },
+	"estimate":    func(i Issue) any { return i.OriginalEstimate },
+	"remaining":   func(i Issue) any { return i.RemainingEstimate },
+	"comments":    func(i Issue) any { return float64(i.Comments) },
+	"sprint":      func(i Issue) any { return float64(i.SprintID) },
+	"done":        func(i Issue) any { return i.Status.IsDone },
+	"reporter":    func(i Issue) any { return i.Reporter },
+	"created":     func(i Issue) any { return formatTimestamp(i.Created) },
+	"updated":     func(i Issue) any { return formatTimestamp(i.Updated) },
+	"age":         func(i Issue) any { return i.Age(time.Now()) },
+}
+
+// timestampLayout formats timestamps in filters and columns so that they
+// compare and sort in chronological order, for instance created < "2024-01".
+const timestampLayout = "2006-01-02 15:04"
+
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format(timestampLayout)
+}
+
+// filterOperators are ordered so that longer operators are matched first.
+var filterOperators = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "~"}
+
+// Filter is an expression evaluated against cached issues to slice lists
+// without a JQL round trip, for instance:
+//
+//	points > 3 && status == "In Progress"
+//
+// Fields are compared to numbers, strings and booleans using ==, !=, <, <=,
+// >, >= and ~ which matches if a string contains another string regardless
+// of case. Conditions are combined with &&, || and ! and grouped with
+// parentheses.
+type Filter struct {
+	expr filterNode
+
+	// bodies is set if the filter refers to the description, since issue
+	// bodies are read from the data file on demand
+	bodies bool
+}
+
+// ParseFilter parses the given filter expression. Since fields have a static
+// type, type errors are reported here rather than when matching issues.
+func ParseFilter(s string) (Filter, error) {
+	tokens, err := lexFilter(s)
+	if err != nil {
+		return Filter{}, err
+	}
+	p := &filterParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return Filter{}, err
+	}
+	if t := p.peek(); t.kind != filterTokenEOF {
+		return Filter{}, fmt.Errorf("%w: unexpected %q at %d", errFilterSyntax, t.text, t.pos)
+	}
+	value, err := expr.eval(Issue{})
+	if err != nil {
+		return Filter{}, err
+	}
+	if _, ok := value.(bool); !ok {
+		return Filter{}, fmt.Errorf("%w: expression is not a condition", errFilterType)
+	}
+	filter := Filter{expr: expr}
+	for _, t := range tokens {
+		if t.kind == filterTokenIdent && t.text == "description" {
+			filter.bodies = true
+		}
+	}
+	return filter, nil
+}
+
+// Match reports whether the issue matches the filter.
+func (f Filter) Match(issue Issue) bool {
+	value, err := f.expr.eval(issue)
+	if err != nil {
+		return false
+	}
+	match, _ := value.(bool)
+	return match
+}
+
+// Filter returns the issues matching the given filter.
+func (i Issues) Filter(filter Filter) Issues {
+	if filter.bodies {
+		i = i.withBodies()
+	}
+	result := make(Issues, 0, len(i))
+	for _, issue := range i {
+		if filter.Match(issue) {
+			result = append(result, issue)
+		}
+	}
+	return result
+}
+
+type filterTokenKind int
+
+const (
+	filterTokenEOF filterTokenKind = iota
+	filterTokenIdent
+	filterTokenNumber
+	filterTokenString
+	filterTokenOperator
+	filterTokenLParen
+	filterTokenRParen
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+	pos  int
+}
+
+func lexFilter(s string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, filterToken{filterTokenLParen, "(", i})
+			i++
+		case r == ')':
+			tokens = append(tokens, filterToken{filterTokenRParen, ")", i})
+			i++
+		case r == '"':
+			j := i + 1
+			for j < len(runes) && runes[j] != '"' {
+				j++
+			}
+			if j == len(runes) {
+				return nil, fmt.Errorf("%w: unterminated string at %d", errFilterSyntax, i)
+			}
+			tokens = append(tokens, filterToken{filterTokenString, string(runes[i+1 : j]), i})
+			i = j + 1
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, filterToken{filterTokenNumber, string(runes[i:j]), i})
+			i = j
+		case unicode.IsLetter(r):
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || runes[j] == '_') {
+				j++
+			}
+			tokens = append(tokens, filterToken{filterTokenIdent, string(runes[i:j]), i})
+			i = j
+		default:
+			op, ok := matchOperator(string(runes[i:]))
+			if !ok {
+				return nil, fmt.Errorf("%w: unexpected %q at %d", errFilterSyntax, r, i)
+			}
+			tokens = append(tokens, filterToken{filterTokenOperator, op, i})
+			i += len(op)
+		}
+	}
+	return append(tokens, filterToken{kind: filterTokenEOF, pos: len(runes)}), nil
+}
+
+func matchOperator(s string) (string, bool) {
+	for _, op := range filterOperators {
+		if strings.HasPrefix(s, op) {
+			return op, true
+		}
+	}
+	return "", false
+}
+
+// filterParser is a recursive descent parser with the precedence from lowest
+// to highest: ||, &&, !, comparisons.
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() filterToken {
+	t := p.tokens[p.pos]
+	if t.kind != filterTokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *filterParser) isOperator(ops ...string) bool {
+	t := p.peek()
+	if t.kind != filterTokenOperator {
+		return false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("||") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("&&") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseNot() (filterNode, error) {
+	if p.isOperator("!") {
+		p.next()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (filterNode, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if !p.isOperator("==", "!=", "<", "<=", ">", ">=", "~") {
+		return left, nil
+	}
+	op := p.next().text
+	right, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	return binaryNode{op: op, left: left, right: right}, nil
+}
+
+func (p *filterParser) parsePrimary() (filterNode, error) {
+	t := p.next()
+	switch t.kind {
+	case filterTokenIdent:
+		switch t.text {
+		case "true", "false":
+			return literalNode{value: t.text == "true"}, nil
+		}
+		if _, ok := filterFields[t.text]; !ok {
+			return nil, fmt.Errorf("%w: %s", errFilterField, t.text)
+		}
+		return fieldNode(t.text), nil
+	case filterTokenNumber:
+		n, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid number %q at %d", errFilterSyntax, t.text, t.pos)
+		}
+		return literalNode{value: n}, nil
+	case filterTokenString:
+		return literalNode{value: t.text}, nil
+	case filterTokenLParen:
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next().kind != filterTokenRParen {
+			return nil, fmt.Errorf("%w: missing ) for ( at %d", errFilterSyntax, t.pos)
+		}
+		return expr, nil
+	case filterTokenEOF:
+		return nil, fmt.Errorf("%w: unexpected end of filter", errFilterSyntax)
+	}
+	return nil, fmt.Errorf("%w: unexpected %q at %d", errFilterSyntax, t.text, t.pos)
+}
+
+type filterNode interface {
+	eval(issue Issue) (any, error)
+}
+
+type fieldNode string
+
+func (n fieldNode) eval(issue Issue) (any, error) {
+	return filterFields[string(n)](issue), nil
+}
+
+type literalNode struct {
+	value any
+}
+
+func (n literalNode) eval(Issue) (any, error) {
+	return n.value, nil
+}
+
+type notNode struct {
+	operand filterNode
+}
+
+func (n notNode) eval(issue Issue) (any, error) {
+	value, err := n.operand.eval(issue)
+	if err != nil {
+		return nil, err
+	}
+	b, ok := value.(bool)
+	if !ok {
+		return nil, fmt.Errorf("%w: ! requires a condition", errFilterType)
+	}
+	return !b, nil
+}
+
+type binaryNode struct {
+	op          string
+	left, right filterNode
+}
+
+// eval evaluates both operands without short-circuiting so that type errors
+// are detected regardless of the issue.
+func (n binaryNode) eval(issue Issue) (any, error) {
+	left, err := n.left.eval(issue)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(issue)
+	if err != nil {
+		return nil, err
+	}
+	switch l := left.(type) {
+	case bool:
+		r, ok := right.(bool)
+		if !ok {
+			break
+		}
+		switch n.op {
+		case "&&":
+			return l && r, nil
+		case "||":
+			return l || r, nil
+		case "==":
+			return l == r, nil
+		case "!=":
+			return l != r, nil
+		}
+	case float64:
+		r, ok := right.(float64)
+		if !ok {
+			break
+		}
+		switch n.op {
+		case "==":
+			return l == r, nil
+		case "!=":
+			return l != r, nil
+		case "<":
+			return l < r, nil
+		case "<=":
+			return l <= r, nil
+		case ">":
+			return l > r, nil
+		case ">=":
+			return l >= r, nil
+		}
+	case string:
+		r, ok := right.(string)
+		if !ok {
+			break
+		}
+		switch n.op {
+		case "==":
+			return l == r, nil
+		case "!=":
+			return l != r, nil
+		case "<":
+			return l < r, nil
+		case "<=":
+			return l <= r, nil
+		case ">":
+			return l > r, nil
+		case ">=":
+			return l >= r, nil
+		case "~":
+			return strings.Contains(strings.ToLower(l), strings.ToLower(r)), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %T %s %T", errFilterType, left, n.op, right)
+}
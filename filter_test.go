@@ -0,0 +1,69 @@
+package kong
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFilter(t *testing.T) {
+	issue := Issue{
+		Key:         "KONG-1",
+		Summary:     "Add filter expressions",
+		Priority:    "High",
+		Status:      Status{Name: "In Progress"},
+		StoryPoints: 5,
+		Reporter:    "Grace Hopper",
+		Created:     time.Date(2024, 3, 1, 9, 30, 0, 0, time.Local),
+	}
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{`points > 3`, true},
+		{`points > 3 && status == "In Progress"`, true},
+		{`points <= 3 || status != "In Progress"`, false},
+		{`!done && summary ~ "FILTER"`, true},
+		{`(priority == "Low" || priority == "High") && key == "KONG-1"`, true},
+		{`!(points >= 5)`, false},
+		{`done == false`, true},
+		{`reporter ~ "grace"`, true},
+		{`created < "2024-06" && created >= "2024-03-01"`, true},
+		{`age > 30`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			filter, err := ParseFilter(tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := filter.Match(issue); got != tt.want {
+				t.Errorf("got %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFilterError(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   error
+	}{
+		{`points >`, errFilterSyntax},
+		{`(points > 3`, errFilterSyntax},
+		{`status == "In Progress`, errFilterSyntax},
+		{`points > 3 points`, errFilterSyntax},
+		{`assignee == "me"`, errFilterField},
+		{`points > "3"`, errFilterType},
+		{`done && points`, errFilterType},
+		{`points`, errFilterType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			_, err := ParseFilter(tt.filter)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want: %v", err, tt.want)
+			}
+		})
+	}
+}
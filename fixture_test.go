@@ -0,0 +1,144 @@
+package kong
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+// Size of the synthetic board used by benchmarks, in the range of the boards
+// of large Jira instances.
+const (
+	fixtureIssues = 10000
+	fixtureEpics  = 200
+)
+
+// fixtureStatuses is the workflow of generated issues in transition order.
+var fixtureStatuses = []string{"To Do", "In Progress", "In Review", "Done"}
+
+// fixture is a synthetic board with issues spread across epics, statuses and
+// the active sprint as returned by the Jira API.
+type fixture struct {
+	config Config
+	issues []jira.Issue
+	epics  []jira.Issue
+}
+
+// newFixture generates the given number of issues and epics. The content is
+// deterministic so that benchmark results are comparable between runs.
+func newFixture(numIssues, numEpics int) fixture {
+	r := rand.New(rand.NewSource(1))
+	f := fixture{
+		config: Config{
+			Project: "KONG",
+			CustomFields: CustomFields{
+				Epics:       "customfield_10001",
+				StoryPoints: "customfield_10002",
+				Sprints:     "customfield_10003",
+			},
+			Fields: map[string]CustomField{
+				"team": {ID: "customfield_10004", Type: FieldTypeOption},
+			},
+		},
+	}
+	transitions := make([]jira.Transition, len(fixtureStatuses))
+	for i, status := range fixtureStatuses {
+		transitions[i] = jira.Transition{
+			ID:   fmt.Sprint(i + 1),
+			Name: status,
+			To:   jira.Status{Name: status},
+		}
+	}
+	created := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	newJiraIssue := func(key, issueType, summary string, status int, unknowns map[string]any) jira.Issue {
+		category := "new"
+		if fixtureStatuses[status] == "Done" {
+			category = "done"
+		}
+		return jira.Issue{
+			Key: key,
+			Fields: &jira.IssueFields{
+				Summary:     summary,
+				Description: "As a user I want to list issues so that I can plan my day.",
+				Type:        jira.IssueType{Name: issueType},
+				Project:     jira.Project{Key: f.config.Project},
+				Priority:    &jira.Priority{Name: "Medium"},
+				Status: &jira.Status{
+					Name:           fixtureStatuses[status],
+					StatusCategory: jira.StatusCategory{Key: category},
+				},
+				Reporter: &jira.User{DisplayName: "Jane Doe"},
+				Created:  jira.Time(created.Add(time.Duration(r.Intn(90*24)) * time.Hour)),
+				Updated:  jira.Time(created.AddDate(0, 3, 0)),
+				Unknowns: unknowns,
+			},
+			Transitions: transitions,
+		}
+	}
+
+	for i := 1; i <= numEpics; i++ {
+		key := fmt.Sprintf("KONG-%d", i)
+		f.epics = append(f.epics, newJiraIssue(key, "Epic", fmt.Sprintf("Epic %d", i), r.Intn(len(fixtureStatuses)), nil))
+	}
+	for i := 1; i <= numIssues; i++ {
+		unknowns := map[string]any{
+			"customfield_10002": float64(r.Intn(8) + 1),
+			"customfield_10004": map[string]any{"value": fmt.Sprintf("Team %d", r.Intn(5))},
+		}
+		if numEpics > 0 {
+			unknowns["customfield_10001"] = fmt.Sprintf("KONG-%d", r.Intn(numEpics)+1)
+		}
+		if r.Intn(10) == 0 {
+			unknowns["customfield_10003"] = []any{map[string]any{"id": 42.0, "state": "active"}}
+		}
+		key := fmt.Sprintf("KONG-%d", numEpics+i)
+		summary := fmt.Sprintf("Add command to list issues of sprint %d", i)
+		f.issues = append(f.issues, newJiraIssue(key, "Story", summary, r.Intn(len(fixtureStatuses)), unknowns))
+	}
+	return f
+}
+
+// data returns the board converted into the data the daemon writes to disk.
+func (f fixture) data(tb testing.TB) Data {
+	tb.Helper()
+	issues, err := NewIssues(f.issues, f.config)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	epics, err := NewIssues(f.epics, f.config)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	data := NewData()
+	data.Timestamp = time.Now().Unix()
+	data.Issues = issues
+	data.Epics = epics
+	for _, issue := range issues {
+		data.IssueByKey[issue.Key] = issue
+		if issue.SprintID != 0 {
+			data.SprintIssues = append(data.SprintIssues, issue)
+		}
+	}
+	return data
+}
+
+func TestFixture(t *testing.T) {
+	data := newFixture(100, 5).data(t)
+	if len(data.Issues) != 100 {
+		t.Errorf("got %d issues, want: %d", len(data.Issues), 100)
+	}
+	if len(data.Epics) != 5 {
+		t.Errorf("got %d epics, want: %d", len(data.Epics), 5)
+	}
+	if len(data.SprintIssues) == 0 {
+		t.Error("got no sprint issues")
+	}
+	for _, issue := range data.Issues {
+		if issue.EpicKey == "" || issue.StoryPoints == 0 || issue.Fields["team"] == nil {
+			t.Fatalf("got issue without custom fields: %+v", issue)
+		}
+	}
+}
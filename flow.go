@@ -0,0 +1,109 @@
+package kong
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// FlowSnapshot counts the issues per status on a given day in the board
+// timezone to export cumulative flow data.
+type FlowSnapshot struct {
+	Date   string
+	Counts map[string]int
+}
+
+// recordFlow updates the issue counts per status for the day of now.
+func (d *Data) recordFlow(now time.Time) {
+	snapshot := FlowSnapshot{
+		Date:   now.Format(snapshotDateLayout),
+		Counts: make(map[string]int),
+	}
+	seen := make(map[string]struct{})
+	for _, list := range []Issues{d.Issues, d.SprintIssues} {
+		for _, issue := range list {
+			if _, ok := seen[issue.Key]; ok {
+				continue
+			}
+			seen[issue.Key] = struct{}{}
+			snapshot.Counts[issue.Status.Name]++
+		}
+	}
+	for i, s := range d.Flow {
+		if s.Date == snapshot.Date {
+			d.Flow[i] = snapshot
+			return
+		}
+	}
+	d.Flow = append(d.Flow, snapshot)
+	if len(d.Flow) > maxSnapshots {
+		d.Flow = append([]FlowSnapshot(nil), d.Flow[len(d.Flow)-maxSnapshots:]...)
+	}
+}
+
+// flowStatuses returns the statuses of the snapshots in workflow order
+// followed by statuses which are no longer part of the workflow.
+func (d Data) flowStatuses() []string {
+	var statuses []string
+	seen := make(map[string]struct{})
+	for _, list := range []Issues{d.SprintIssues, d.Issues} {
+		for _, transition := range list.Transitions() {
+			if _, ok := seen[transition.Name]; !ok {
+				seen[transition.Name] = struct{}{}
+				statuses = append(statuses, transition.Name)
+			}
+		}
+	}
+	var others []string
+	for _, snapshot := range d.Flow {
+		for status := range snapshot.Counts {
+			if _, ok := seen[status]; !ok {
+				seen[status] = struct{}{}
+				others = append(others, status)
+			}
+		}
+	}
+	sort.Strings(others)
+	return append(statuses, others...)
+}
+
+// flowRows returns the header and one row per day of the cumulative flow data.
+func (d Data) flowRows() [][]string {
+	statuses := d.flowStatuses()
+	flow := append([]FlowSnapshot(nil), d.Flow...)
+	sort.Slice(flow, func(i, j int) bool {
+		return flow[i].Date < flow[j].Date
+	})
+	rows := [][]string{append([]string{"Date"}, statuses...)}
+	for _, snapshot := range flow {
+		row := []string{snapshot.Date}
+		for _, status := range statuses {
+			row = append(row, strconv.Itoa(snapshot.Counts[status]))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// PrintFlow writes the issue counts per status and day as table or as CSV for
+// plotting a cumulative flow diagram.
+func (d Data) PrintFlow(output io.Writer, asCSV bool) error {
+	rows := d.flowRows()
+	if asCSV {
+		w := csv.NewWriter(output)
+		if err := w.WriteAll(rows); err != nil {
+			return fmt.Errorf("PrintFlow: %w", err)
+		}
+		return nil
+	}
+	w := tabwriter.NewWriter(output, 1, 1, 1, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t-\t"))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,37 @@
+package kong
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPrintFlow(t *testing.T) {
+	transitions := []Transition{{Name: "To Do"}, {Name: "In Progress"}, {Name: "Done"}}
+	data := NewData()
+	data.Issues = Issues{
+		{Key: "KONG-1", Status: Status{Name: "To Do"}, Transitions: transitions},
+		{Key: "KONG-2", Status: Status{Name: "To Do"}, Transitions: transitions},
+	}
+	data.SprintIssues = Issues{
+		{Key: "KONG-2", Status: Status{Name: "To Do"}, Transitions: transitions},
+		{Key: "KONG-3", Status: Status{Name: "Done"}, Transitions: transitions},
+	}
+	day := time.Date(2022, 6, 1, 9, 0, 0, 0, time.UTC)
+	data.recordFlow(day)
+	data.Issues[0].Status.Name = "In Progress"
+	data.recordFlow(day.AddDate(0, 0, 1))
+
+	var buf bytes.Buffer
+	if err := data.PrintFlow(&buf, true); err != nil {
+		t.Fatal(err)
+	}
+	want := "Date,To Do,In Progress,Done\n" +
+		"2022-06-01,2,0,1\n" +
+		"2022-06-02,1,1,1\n"
+	if diff := cmp.Diff(buf.String(), want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
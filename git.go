@@ -0,0 +1,77 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// currentBranch returns the name of the checked out git branch.
+func currentBranch(ctx context.Context) (string, error) {
+	b, err := exec.CommandContext(ctx, "git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// branchIssueKey returns the first issue key of the project in the branch
+// name. Branch names are often lower case, for instance kong-123-add-command.
+func branchIssueKey(branch, project string) (string, bool) {
+	keys := findIssueKeys(strings.ToUpper(branch), project)
+	if len(keys) == 0 {
+		return "", false
+	}
+	return keys[0], true
+}
+
+// currentBranchIssueKey returns the issue key of the project in the name of
+// the checked out git branch.
+func (j Jira) currentBranchIssueKey(ctx context.Context) (string, error) {
+	branch, err := currentBranch(ctx)
+	if err != nil {
+		return "", err
+	}
+	key, ok := branchIssueKey(branch, j.config.Project)
+	if !ok {
+		return "", errIssueKeyMissing
+	}
+	return key, nil
+}
+
+// OpenIssue opens the issue in the default browser. If key is empty the issue
+// key is derived from the current branch.
+func (j Jira) OpenIssue(ctx context.Context, key string) error {
+	if key == "" {
+		var err error
+		key, err = j.currentBranchIssueKey(ctx)
+		if err != nil {
+			return fmt.Errorf("OpenIssue: %w", err)
+		}
+	}
+	return openURL(ctx, j.BrowseURL(key))
+}
+
+// CurrentIssue returns the issue referenced by the current git branch name
+// looked up from the cached data only. If the issue is not cached only the
+// key is set. It returns false if the branch does not reference an issue.
+func CurrentIssue(ctx context.Context, data Data) (Issue, bool) {
+	branch, err := currentBranch(ctx)
+	if err != nil {
+		return Issue{}, false
+	}
+	keys := findIssueKeys(branch, "")
+	if len(keys) == 0 {
+		return Issue{}, false
+	}
+	if issue, ok := data.IssueByKey[keys[0]]; ok {
+		return issue, true
+	}
+	for _, issue := range data.SprintIssues {
+		if issue.Key == keys[0] {
+			return issue, true
+		}
+	}
+	return Issue{Key: keys[0]}, true
+}
@@ -0,0 +1,25 @@
+package kong
+
+import "testing"
+
+func TestBranchIssueKey(t *testing.T) {
+	tests := []struct {
+		branch string
+		want   string
+		wantOK bool
+	}{
+		{branch: "KONG-12", want: "KONG-12", wantOK: true},
+		{branch: "kong-12-add-open-command", want: "KONG-12", wantOK: true},
+		{branch: "feature/KONG-3-fix", want: "KONG-3", wantOK: true},
+		{branch: "fix-2-bugs"},
+		{branch: "main"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			got, ok := branchIssueKey(tt.branch, "KONG")
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("got %v %v, want: %v %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package kong
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+// githubImportMarker starts the comment which links an imported GitHub issue
+// to its Jira issue. GitHub issues with such a comment are not imported again.
+const githubImportMarker = "Tracked in Jira as "
+
+// maxGitHubIssues bounds the number of GitHub issues listed for an import.
+const maxGitHubIssues = 1000
+
+var (
+	errGitHubRepoInvalid = errors.New("GitHub repository must be given as owner/repo")
+	errGitHubNotLinked   = errors.New("created issues are not linked to GitHub")
+)
+
+// GitHubIssue is an issue of a GitHub repository as listed by the GitHub CLI.
+type GitHubIssue struct {
+	Number   int             `json:"number"`
+	Title    string          `json:"title"`
+	Body     string          `json:"body"`
+	URL      string          `json:"url"`
+	Comments []GitHubComment `json:"comments"`
+}
+
+// GitHubComment is a comment of a GitHub issue.
+type GitHubComment struct {
+	Body string `json:"body"`
+}
+
+// imported reports whether the issue links to a Jira issue created by a
+// previous import.
+func (i GitHubIssue) imported() bool {
+	for _, comment := range i.Comments {
+		if strings.HasPrefix(comment.Body, githubImportMarker) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateGitHubRepo(repo string) error {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return fmt.Errorf("%w: %s", errGitHubRepoInvalid, repo)
+	}
+	return nil
+}
+
+// gh runs the GitHub CLI with the given arguments and returns its output.
+func gh(ctx context.Context, stdin string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "gh", args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	cmd.Stderr = os.Stderr
+	b, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("gh %s: %w", args[0], err)
+	}
+	return b, nil
+}
+
+// ListGitHubIssues returns the open issues of the repository which have all
+// of the given labels.
+func ListGitHubIssues(ctx context.Context, repo string, labels []string) ([]GitHubIssue, error) {
+	args := []string{
+		"issue", "list",
+		"--repo", repo,
+		"--state", "open",
+		"--limit", strconv.Itoa(maxGitHubIssues),
+		"--json", "number,title,body,url,comments",
+	}
+	for _, label := range labels {
+		args = append(args, "--label", label)
+	}
+	b, err := gh(ctx, "", args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseGitHubIssues(b)
+}
+
+func parseGitHubIssues(b []byte) ([]GitHubIssue, error) {
+	var issues []GitHubIssue
+	if err := json.NewDecoder(bytes.NewReader(b)).Decode(&issues); err != nil {
+		return nil, fmt.Errorf("ListGitHubIssues: %w", err)
+	}
+	return issues, nil
+}
+
+// githubIssueDescription returns the description of the Jira issue created
+// for the GitHub issue, which links back to it.
+func githubIssueDescription(issue GitHubIssue) string {
+	description := "Imported from GitHub: " + issue.URL
+	if body := strings.TrimSpace(issue.Body); body != "" {
+		description = body + "\n\n" + description
+	}
+	return description
+}
+
+// ImportGitHubIssues creates Jira issues for the open issues of the GitHub
+// repository with the given labels. Every created issue links to the GitHub
+// issue and the GitHub issue is commented with a link to the Jira issue.
+// GitHub issues which have been imported before are skipped. The links are
+// recorded in the state, also for issues which failed to be linked, so that
+// importing again does not create them twice.
+func (j Jira) ImportGitHubIssues(ctx context.Context, data *Data, repo string, labels []string) error {
+	if err := validateGitHubRepo(repo); err != nil {
+		return err
+	}
+	listed, err := ListGitHubIssues(ctx, repo, labels)
+	if err != nil {
+		return err
+	}
+	linked := make(map[string]bool, len(data.GitHubIssues))
+	for _, url := range data.GitHubIssues {
+		linked[url] = true
+	}
+	var pending []GitHubIssue
+	for _, issue := range listed {
+		if !issue.imported() && !linked[issue.URL] {
+			pending = append(pending, issue)
+		}
+	}
+	if len(pending) == 0 {
+		fmt.Println("No GitHub issues to import from", repo)
+		return nil
+	}
+	for _, issue := range pending {
+		fmt.Printf("#%d - %s\n", issue.Number, issue.Title)
+	}
+	ok, err := Confirm(fmt.Sprintf("Import %d issues into %s?", len(pending), j.config.Project))
+	if err != nil || !ok {
+		return err
+	}
+
+	issues := make([]*jira.Issue, len(pending))
+	for i, issue := range pending {
+		issues[i] = j.newIssue(j.config.IssueType, issue.Title, githubIssueDescription(issue), nil)
+	}
+	keys, createErr := j.CreateIssues(ctx, issues)
+	var unlinked []string
+	created := make(map[string]string, len(keys))
+	for i, key := range keys {
+		if key == "" {
+			continue
+		}
+		created[key] = pending[i].URL
+
+		// a failed link must not leave the remaining issues unlinked
+		if err := j.linkGitHubIssue(ctx, key, repo, pending[i]); err != nil {
+			fmt.Fprintf(os.Stderr, "%s - Linking #%d failed: %v\n", key, pending[i].Number, err)
+			unlinked = append(unlinked, key)
+		}
+	}
+	err = data.UpdateState(func(d *Data) error {
+		if d.GitHubIssues == nil {
+			d.GitHubIssues = make(map[string]string, len(created))
+		}
+		for key, url := range created {
+			d.GitHubIssues[key] = url
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if createErr != nil {
+		return createErr
+	}
+	if len(unlinked) > 0 {
+		return fmt.Errorf("%w: %s", errGitHubNotLinked, strings.Join(unlinked, ", "))
+	}
+	return nil
+}
+
+// linkGitHubIssue adds the GitHub issue as remote link of the Jira issue and
+// comments the Jira issue on the GitHub issue.
+func (j Jira) linkGitHubIssue(ctx context.Context, key, repo string, issue GitHubIssue) error {
+	link := &jira.RemoteLink{
+		GlobalID: issue.URL,
+		Object: &jira.RemoteLinkObject{
+			URL:   issue.URL,
+			Title: fmt.Sprintf("%s#%d", repo, issue.Number),
+		},
+	}
+	_, resp, err := j.client.Issue.AddRemoteLinkWithContext(ctx, key, link)
+	if err != nil {
+		return fmt.Errorf("ImportGitHubIssues: %w", parseResponseError(resp))
+	}
+	body := fmt.Sprintf("%s[%s](%s)", githubImportMarker, key, j.BrowseURL(key))
+	_, err = gh(ctx, body, "issue", "comment", strconv.Itoa(issue.Number), "--repo", repo, "--body-file", "-")
+	return err
+}
@@ -0,0 +1,169 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseGitHubIssues(t *testing.T) {
+	b := []byte(`[
+		{"number": 1, "title": "Crash", "body": "Steps", "url": "https://github.com/o/r/issues/1", "comments": []},
+		{"number": 2, "title": "Typo", "body": "", "url": "https://github.com/o/r/issues/2", "comments": [{"body": "Tracked in Jira as [KONG-3](https://jira.example.com/browse/KONG-3)"}]}
+	]`)
+	issues, err := parseGitHubIssues(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []GitHubIssue{
+		{Number: 1, Title: "Crash", Body: "Steps", URL: "https://github.com/o/r/issues/1", Comments: []GitHubComment{}},
+		{Number: 2, Title: "Typo", URL: "https://github.com/o/r/issues/2", Comments: []GitHubComment{
+			{Body: "Tracked in Jira as [KONG-3](https://jira.example.com/browse/KONG-3)"},
+		}},
+	}
+	if diff := cmp.Diff(issues, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	if issues[0].imported() {
+		t.Errorf("got imported, want: not imported")
+	}
+	if !issues[1].imported() {
+		t.Errorf("got not imported, want: imported")
+	}
+}
+
+func TestGitHubIssueDescription(t *testing.T) {
+	tests := []struct {
+		issue GitHubIssue
+		want  string
+	}{
+		{
+			issue: GitHubIssue{Body: "Steps\n", URL: "https://github.com/o/r/issues/1"},
+			want:  "Steps\n\nImported from GitHub: https://github.com/o/r/issues/1",
+		},
+		{
+			issue: GitHubIssue{URL: "https://github.com/o/r/issues/2"},
+			want:  "Imported from GitHub: https://github.com/o/r/issues/2",
+		},
+	}
+	for _, tt := range tests {
+		if got := githubIssueDescription(tt.issue); got != tt.want {
+			t.Errorf("got %q, want: %q", got, tt.want)
+		}
+	}
+}
+
+func TestValidateGitHubRepo(t *testing.T) {
+	tests := []struct {
+		repo string
+		err  error
+	}{
+		{repo: "konradreiche/kong"},
+		{repo: "kong", err: errGitHubRepoInvalid},
+		{repo: "/kong", err: errGitHubRepoInvalid},
+		{repo: "konradreiche/", err: errGitHubRepoInvalid},
+		{repo: "github.com/konradreiche/kong", err: errGitHubRepoInvalid},
+	}
+	for _, tt := range tests {
+		if err := validateGitHubRepo(tt.repo); !errors.Is(err, tt.err) {
+			t.Errorf("got %v, want: %v", err, tt.err)
+		}
+	}
+}
+
+func TestImportGitHubIssuesLinksEveryIssue(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake gh requires a POSIX shell")
+	}
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+	session.data.reset()
+	session.state.reset()
+
+	// fake gh which fails to comment on the first issue
+	bin := t.TempDir()
+	log := filepath.Join(bin, "log")
+	script := `#!/bin/sh
+echo "$@" >> ` + log + `
+case "$1 $2" in
+"issue list")
+	echo '[{"number": 1, "title": "One", "url": "https://github.com/o/r/issues/1", "comments": []},
+	{"number": 2, "title": "Two", "url": "https://github.com/o/r/issues/2", "comments": []}]' ;;
+"issue comment")
+	cat > /dev/null
+	[ "$3" = "1" ] && exit 1 ;;
+esac
+exit 0
+`
+	if err := os.WriteFile(filepath.Join(bin, "gh"), []byte(script), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	// answer the confirmation prompt
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() { os.Stdin = stdin })
+	if _, err := w.WriteString("y\n"); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	client := newTestJira(t, func(req *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(req.URL.Path, "/remotelink") {
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Body:       io.NopCloser(strings.NewReader(`{}`)),
+			}, nil
+		}
+		var issue jira.Issue
+		if err := json.NewDecoder(req.Body).Decode(&issue); err != nil {
+			return nil, err
+		}
+		key := map[string]string{"One": "KONG-1", "Two": "KONG-2"}[issue.Fields.Summary]
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       io.NopCloser(strings.NewReader(`{"key": "` + key + `"}`)),
+		}, nil
+	})
+	j := Jira{client: client, config: Config{Project: "KONG", IssueType: "Story"}}
+	data := NewData()
+
+	err = j.ImportGitHubIssues(context.Background(), &data, "o/r", nil)
+	if !errors.Is(err, errGitHubNotLinked) {
+		t.Fatalf("got %v, want: %v", err, errGitHubNotLinked)
+	}
+	b, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "issue comment 2 --repo o/r") {
+		t.Errorf("got %s, want comment on #2", b)
+	}
+	state, err := readState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.GitHubIssues) != 2 {
+		t.Errorf("got %v, want both issues linked", state.GitHubIssues)
+	}
+
+	// importing again skips the issues recorded in the state
+	data.State = state
+	if err := j.ImportGitHubIssues(context.Background(), &data, "o/r", nil); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,476 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Conflict resolution preferences of the GitHub sync.
+const (
+	PreferJira   = "jira"
+	PreferGitHub = "github"
+	PreferNone   = "none"
+)
+
+// GitHub issue states the statuses of the sync map to unless a project is
+// configured.
+const (
+	githubOpen   = "open"
+	githubClosed = "closed"
+)
+
+const (
+	// githubSyncRate bounds how often the daemon mirrors statuses to not
+	// run the GitHub CLI on every refresh
+	githubSyncRate = time.Minute
+
+	defaultGitHubProjectField = "Status"
+)
+
+var (
+	errGitHubSyncPrefer    = errors.New("unknown GitHub sync preference, expected jira, github or none")
+	errGitHubSyncStatus    = errors.New("GitHub sync status requires a Jira and a GitHub status")
+	errGitHubSyncState     = errors.New("GitHub sync status must be open or closed without a project")
+	errGitHubSyncProject   = errors.New("GitHub sync project requires an owner and a number")
+	errGitHubIssueURL      = errors.New("not a GitHub issue URL")
+	errGitHubProjectField  = errors.New("GitHub project field does not exist")
+	errGitHubProjectOption = errors.New("GitHub project field option does not exist")
+	errGitHubProjectItem   = errors.New("GitHub issue is not an item of the project")
+)
+
+// GitHubSync configures the daemon to mirror status changes between Jira
+// issues and the GitHub issues linked to them by kong import github. The
+// statuses map Jira statuses to GitHub issue states, or to options of the
+// status field of the project if a project is configured. A GitHub status
+// which several Jira statuses map to is mirrored as the first of them.
+// Prefer decides which side wins if both changed since the last sync.
+type GitHubSync struct {
+	Statuses []GitHubSyncStatus `yaml:"statuses"`
+	Project  GitHubProject      `yaml:"project"`
+	Prefer   string             `yaml:"prefer"`
+}
+
+// GitHubSyncStatus maps a Jira status to a GitHub status.
+type GitHubSyncStatus struct {
+	Jira   string `yaml:"jira"`
+	GitHub string `yaml:"github"`
+}
+
+// GitHubProject is a GitHub project whose items are updated instead of the
+// state of the issues. Field is the single select field holding the status.
+type GitHubProject struct {
+	Owner  string `yaml:"owner"`
+	Number int    `yaml:"number"`
+	Field  string `yaml:"field"`
+}
+
+// Enabled reports whether statuses are mirrored.
+func (s GitHubSync) Enabled() bool {
+	return len(s.Statuses) > 0
+}
+
+func (s GitHubSync) hasProject() bool {
+	return s.Project.Number != 0
+}
+
+func (s GitHubSync) projectField() string {
+	if s.Project.Field != "" {
+		return s.Project.Field
+	}
+	return defaultGitHubProjectField
+}
+
+func (s GitHubSync) validate() error {
+	switch s.Prefer {
+	case "", PreferJira, PreferGitHub, PreferNone:
+	default:
+		return fmt.Errorf("%w: %s", errGitHubSyncPrefer, s.Prefer)
+	}
+	if s.Project != (GitHubProject{}) && (s.Project.Owner == "" || s.Project.Number == 0) {
+		return errGitHubSyncProject
+	}
+	for _, status := range s.Statuses {
+		if status.Jira == "" || status.GitHub == "" {
+			return errGitHubSyncStatus
+		}
+		if s.hasProject() {
+			continue
+		}
+		if github := strings.ToLower(status.GitHub); github != githubOpen && github != githubClosed {
+			return fmt.Errorf("%w: %s", errGitHubSyncState, status.GitHub)
+		}
+	}
+	return nil
+}
+
+// githubStatus returns the GitHub status the Jira status maps to.
+func (s GitHubSync) githubStatus(jira string) (string, bool) {
+	for _, status := range s.Statuses {
+		if status.Jira == jira {
+			return status.GitHub, true
+		}
+	}
+	return "", false
+}
+
+// jiraStatus returns the first Jira status which maps to the GitHub status.
+func (s GitHubSync) jiraStatus(github string) (string, bool) {
+	for _, status := range s.Statuses {
+		if strings.EqualFold(status.GitHub, github) {
+			return status.Jira, true
+		}
+	}
+	return "", false
+}
+
+// GitHubSyncState holds the statuses of a linked issue as of the last sync.
+type GitHubSyncState struct {
+	Jira   string
+	GitHub string
+}
+
+// mirror decides how to bring the statuses of a linked issue in line based on
+// which side changed since the last sync. It returns the Jira or the GitHub
+// status to change to, at most one of which is set. Conflict reports that
+// both sides changed and the preference is to leave them unchanged.
+func (s GitHubSync) mirror(last GitHubSyncState, jira, github string) (toJira, toGitHub string, conflict bool) {
+	if want, ok := s.githubStatus(jira); ok && strings.EqualFold(want, github) {
+		return "", "", false
+	}
+	jiraChanged := last.Jira != jira
+	githubChanged := !strings.EqualFold(last.GitHub, github)
+	if jiraChanged && githubChanged {
+		switch s.Prefer {
+		case PreferNone:
+			return "", "", true
+		case PreferGitHub:
+			jiraChanged = false
+		default:
+			githubChanged = false
+		}
+	}
+	if jiraChanged {
+		if want, ok := s.githubStatus(jira); ok {
+			return "", want, false
+		}
+	}
+	if githubChanged {
+		if want, ok := s.jiraStatus(github); ok && want != jira {
+			return want, "", false
+		}
+	}
+	return "", "", false
+}
+
+// githubIssueRef identifies a GitHub issue by repository and number.
+type githubIssueRef struct {
+	repo   string
+	number int
+}
+
+// parseGitHubIssueURL parses URLs of the form
+// https://github.com/owner/repo/issues/number.
+func parseGitHubIssueURL(url string) (githubIssueRef, error) {
+	path := strings.TrimPrefix(url, "https://github.com/")
+	parts := strings.Split(path, "/")
+	if path == url || len(parts) != 4 || parts[2] != "issues" {
+		return githubIssueRef{}, fmt.Errorf("%w: %s", errGitHubIssueURL, url)
+	}
+	number, err := strconv.Atoi(parts[3])
+	if err != nil {
+		return githubIssueRef{}, fmt.Errorf("%w: %s", errGitHubIssueURL, url)
+	}
+	return githubIssueRef{repo: parts[0] + "/" + parts[1], number: number}, nil
+}
+
+// githubStates returns the states of the issues of the repositories by URL.
+func githubStates(ctx context.Context, repos []string) (map[string]string, error) {
+	states := make(map[string]string)
+	for _, repo := range repos {
+		b, err := gh(ctx, "", "issue", "list",
+			"--repo", repo,
+			"--state", "all",
+			"--limit", strconv.Itoa(maxGitHubIssues),
+			"--json", "url,state",
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := parseGitHubStates(b, states); err != nil {
+			return nil, err
+		}
+	}
+	return states, nil
+}
+
+func parseGitHubStates(b []byte, states map[string]string) error {
+	var issues []struct {
+		URL   string `json:"url"`
+		State string `json:"state"`
+	}
+	if err := json.Unmarshal(b, &issues); err != nil {
+		return fmt.Errorf("githubStates: %w", err)
+	}
+	for _, issue := range issues {
+		states[issue.URL] = strings.ToLower(issue.State)
+	}
+	return nil
+}
+
+// setGitHubState closes or reopens the issue.
+func setGitHubState(ctx context.Context, url, state string) error {
+	ref, err := parseGitHubIssueURL(url)
+	if err != nil {
+		return err
+	}
+	command := "reopen"
+	if strings.EqualFold(state, githubClosed) {
+		command = "close"
+	}
+	_, err = gh(ctx, "", "issue", command, strconv.Itoa(ref.number), "--repo", ref.repo)
+	return err
+}
+
+// githubProjectBoard is a GitHub project with the field the statuses of the
+// sync map to.
+type githubProjectBoard struct {
+	id      string
+	fieldID string
+	// options are the IDs of the field options by name
+	options map[string]string
+	// items are the project items of the issues by URL
+	items map[string]githubProjectItem
+}
+
+type githubProjectItem struct {
+	id     string
+	status string
+}
+
+// loadGitHubProject lists the items of the project with the values of the
+// status field.
+func loadGitHubProject(ctx context.Context, project GitHubProject, field string) (githubProjectBoard, error) {
+	number := strconv.Itoa(project.Number)
+	view, err := gh(ctx, "", "project", "view", number, "--owner", project.Owner, "--format", "json")
+	if err != nil {
+		return githubProjectBoard{}, err
+	}
+	fields, err := gh(ctx, "", "project", "field-list", number, "--owner", project.Owner, "--format", "json")
+	if err != nil {
+		return githubProjectBoard{}, err
+	}
+	items, err := gh(ctx, "", "project", "item-list", number,
+		"--owner", project.Owner,
+		"--format", "json",
+		"--limit", strconv.Itoa(maxGitHubIssues),
+	)
+	if err != nil {
+		return githubProjectBoard{}, err
+	}
+	return parseGitHubProject(view, fields, items, field)
+}
+
+func parseGitHubProject(view, fields, items []byte, field string) (githubProjectBoard, error) {
+	var project struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(view, &project); err != nil {
+		return githubProjectBoard{}, fmt.Errorf("loadGitHubProject: %w", err)
+	}
+	var fieldList struct {
+		Fields []struct {
+			ID      string `json:"id"`
+			Name    string `json:"name"`
+			Options []struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"options"`
+		} `json:"fields"`
+	}
+	if err := json.Unmarshal(fields, &fieldList); err != nil {
+		return githubProjectBoard{}, fmt.Errorf("loadGitHubProject: %w", err)
+	}
+	board := githubProjectBoard{
+		id:      project.ID,
+		options: make(map[string]string),
+		items:   make(map[string]githubProjectItem),
+	}
+	for _, f := range fieldList.Fields {
+		if f.Name != field {
+			continue
+		}
+		board.fieldID = f.ID
+		for _, option := range f.Options {
+			board.options[option.Name] = option.ID
+		}
+	}
+	if board.fieldID == "" {
+		return githubProjectBoard{}, fmt.Errorf("%w: %s", errGitHubProjectField, field)
+	}
+
+	// the item list holds field values by lowercase field name
+	var itemList struct {
+		Items []map[string]any `json:"items"`
+	}
+	if err := json.Unmarshal(items, &itemList); err != nil {
+		return githubProjectBoard{}, fmt.Errorf("loadGitHubProject: %w", err)
+	}
+	for _, item := range itemList.Items {
+		content, _ := item["content"].(map[string]any)
+		url, _ := content["url"].(string)
+		if url == "" {
+			continue
+		}
+		id, _ := item["id"].(string)
+		status, _ := item[strings.ToLower(field)].(string)
+		board.items[url] = githubProjectItem{id: id, status: status}
+	}
+	return board, nil
+}
+
+// setStatus changes the status field of the project item of the issue.
+func (b githubProjectBoard) setStatus(ctx context.Context, url, status string) error {
+	item, ok := b.items[url]
+	if !ok {
+		return fmt.Errorf("%w: %s", errGitHubProjectItem, url)
+	}
+	var optionID string
+	for name, id := range b.options {
+		if strings.EqualFold(name, status) {
+			optionID = id
+		}
+	}
+	if optionID == "" {
+		return fmt.Errorf("%w: %s", errGitHubProjectOption, status)
+	}
+	_, err := gh(ctx, "", "project", "item-edit",
+		"--id", item.id,
+		"--project-id", b.id,
+		"--field-id", b.fieldID,
+		"--single-select-option-id", optionID,
+	)
+	return err
+}
+
+// syncGitHub mirrors status changes between the Jira issues and the GitHub
+// issues linked to them and records the mirrored statuses in the state.
+// Nothing is mirrored in read-only mode. Failures of single issues are
+// reported without holding back the other issues.
+func (d *Data) syncGitHub(ctx context.Context, sync GitHubSync) error {
+	// mirroring transitions Jira issues, which read-only mode disables
+	if len(d.GitHubIssues) == 0 || d.jira.config.ReadOnly {
+		return nil
+	}
+	keys := make([]string, 0, len(d.GitHubIssues))
+	for key := range d.GitHubIssues {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	issues, err := d.jira.ListIssuesByKey(ctx, keys)
+	if err != nil {
+		return err
+	}
+
+	// issues which were deleted in Jira are unlinked instead of failing the
+	// sync of the other issues every time
+	var missing []string
+	linked := keys[:0]
+	for _, key := range keys {
+		if !issues.contains(key) {
+			fmt.Fprintf(os.Stderr, "%s - Issue does not exist, unlinking %s\n", key, d.GitHubIssues[key])
+			missing = append(missing, key)
+			continue
+		}
+		linked = append(linked, key)
+	}
+	keys = linked
+
+	var (
+		githubStatus map[string]string
+		setStatus    func(ctx context.Context, url, status string) error
+	)
+	if sync.hasProject() {
+		board, err := loadGitHubProject(ctx, sync.Project, sync.projectField())
+		if err != nil {
+			return err
+		}
+		githubStatus = make(map[string]string, len(board.items))
+		for url, item := range board.items {
+			githubStatus[url] = item.status
+		}
+		setStatus = board.setStatus
+	} else {
+		var repos []string
+		for _, key := range keys {
+			ref, err := parseGitHubIssueURL(d.GitHubIssues[key])
+			if err != nil {
+				return err
+			}
+			if !contains(repos, ref.repo) {
+				repos = append(repos, ref.repo)
+			}
+		}
+		githubStatus, err = githubStates(ctx, repos)
+		if err != nil {
+			return err
+		}
+		setStatus = setGitHubState
+	}
+
+	synced := make(map[string]GitHubSyncState, len(issues))
+	for _, issue := range issues {
+		url := d.GitHubIssues[issue.Key]
+		github, ok := githubStatus[url]
+		if !ok {
+			continue
+		}
+		jira := issue.Status.Name
+		toJira, toGitHub, conflict := sync.mirror(d.GitHubSynced[issue.Key], jira, github)
+		switch {
+		case conflict:
+			fmt.Fprintf(os.Stderr, "%s - Status %s conflicts with %s on %s\n", issue.Key, jira, github, url)
+		case toGitHub != "":
+			if err := setStatus(ctx, url, toGitHub); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				continue
+			}
+			fmt.Printf("%s - Status of %s changed to %s\n", issue.Key, url, toGitHub)
+			github = toGitHub
+		case toJira != "":
+			transition, ok := issue.TransitionByName(toJira)
+			if !ok {
+				fmt.Fprintf(os.Stderr, "%v: %s to %s\n", errUnknownTransition, issue.Key, toJira)
+				continue
+			}
+			err := d.jira.TransitionIssues(ctx, []issueTransition{{issueKey: issue.Key, transition: transition}})
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				continue
+			}
+			jira = toJira
+		}
+		synced[issue.Key] = GitHubSyncState{Jira: jira, GitHub: github}
+	}
+
+	// the state is only locked once the statuses are mirrored
+	return d.UpdateState(func(d *Data) error {
+		for _, key := range missing {
+			delete(d.GitHubIssues, key)
+			delete(d.GitHubSynced, key)
+		}
+		if d.GitHubSynced == nil {
+			d.GitHubSynced = make(map[string]GitHubSyncState, len(synced))
+		}
+		for key, state := range synced {
+			d.GitHubSynced[key] = state
+		}
+		return nil
+	})
+}
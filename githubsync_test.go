@@ -0,0 +1,207 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGitHubSyncMirror(t *testing.T) {
+	statuses := []GitHubSyncStatus{
+		{Jira: "To Do", GitHub: "open"},
+		{Jira: "In Progress", GitHub: "open"},
+		{Jira: "Done", GitHub: "closed"},
+	}
+	tests := []struct {
+		name         string
+		prefer       string
+		last         GitHubSyncState
+		jira         string
+		github       string
+		wantJira     string
+		wantGitHub   string
+		wantConflict bool
+	}{
+		{
+			name:   "in-sync",
+			last:   GitHubSyncState{Jira: "To Do", GitHub: "open"},
+			jira:   "In Progress",
+			github: "open",
+		},
+		{
+			name:       "jira-changed",
+			last:       GitHubSyncState{Jira: "In Progress", GitHub: "open"},
+			jira:       "Done",
+			github:     "open",
+			wantGitHub: "closed",
+		},
+		{
+			name:     "github-changed",
+			last:     GitHubSyncState{Jira: "Done", GitHub: "closed"},
+			jira:     "Done",
+			github:   "open",
+			wantJira: "To Do",
+		},
+		{
+			name:   "unmapped-jira-status",
+			last:   GitHubSyncState{Jira: "Done", GitHub: "closed"},
+			jira:   "Blocked",
+			github: "closed",
+		},
+		{
+			name:       "conflict-prefers-jira",
+			last:       GitHubSyncState{Jira: "In Progress", GitHub: "open"},
+			jira:       "To Do",
+			github:     "closed",
+			wantGitHub: "open",
+		},
+		{
+			name:     "conflict-prefers-github",
+			prefer:   PreferGitHub,
+			last:     GitHubSyncState{Jira: "In Progress", GitHub: "open"},
+			jira:     "To Do",
+			github:   "closed",
+			wantJira: "Done",
+		},
+		{
+			name:         "conflict-unresolved",
+			prefer:       PreferNone,
+			last:         GitHubSyncState{Jira: "In Progress", GitHub: "open"},
+			jira:         "To Do",
+			github:       "closed",
+			wantConflict: true,
+		},
+		{
+			name:       "first-sync",
+			jira:       "Done",
+			github:     "OPEN",
+			wantGitHub: "closed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sync := GitHubSync{Statuses: statuses, Prefer: tt.prefer}
+			toJira, toGitHub, conflict := sync.mirror(tt.last, tt.jira, tt.github)
+			if toJira != tt.wantJira {
+				t.Errorf("got %v, want: %v", toJira, tt.wantJira)
+			}
+			if toGitHub != tt.wantGitHub {
+				t.Errorf("got %v, want: %v", toGitHub, tt.wantGitHub)
+			}
+			if conflict != tt.wantConflict {
+				t.Errorf("got %v, want: %v", conflict, tt.wantConflict)
+			}
+		})
+	}
+}
+
+func TestGitHubSyncValidate(t *testing.T) {
+	tests := []struct {
+		sync GitHubSync
+		err  error
+	}{
+		{sync: GitHubSync{}},
+		{sync: GitHubSync{Statuses: []GitHubSyncStatus{{Jira: "Done", GitHub: "Closed"}}, Prefer: PreferGitHub}},
+		{sync: GitHubSync{Prefer: "newest"}, err: errGitHubSyncPrefer},
+		{sync: GitHubSync{Statuses: []GitHubSyncStatus{{Jira: "Done"}}}, err: errGitHubSyncStatus},
+		{sync: GitHubSync{Statuses: []GitHubSyncStatus{{Jira: "Done", GitHub: "Shipped"}}}, err: errGitHubSyncState},
+		{
+			sync: GitHubSync{
+				Statuses: []GitHubSyncStatus{{Jira: "Done", GitHub: "Shipped"}},
+				Project:  GitHubProject{Owner: "konradreiche", Number: 1},
+			},
+		},
+		{sync: GitHubSync{Project: GitHubProject{Number: 1}}, err: errGitHubSyncProject},
+	}
+	for _, tt := range tests {
+		if err := tt.sync.validate(); !errors.Is(err, tt.err) {
+			t.Errorf("got %v, want: %v", err, tt.err)
+		}
+	}
+}
+
+func TestParseGitHubIssueURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want githubIssueRef
+		err  error
+	}{
+		{url: "https://github.com/konradreiche/kong/issues/12", want: githubIssueRef{repo: "konradreiche/kong", number: 12}},
+		{url: "https://github.com/konradreiche/kong/pull/12", err: errGitHubIssueURL},
+		{url: "https://github.com/konradreiche/kong/issues/new", err: errGitHubIssueURL},
+		{url: "https://example.com/konradreiche/kong/issues/12", err: errGitHubIssueURL},
+	}
+	for _, tt := range tests {
+		got, err := parseGitHubIssueURL(tt.url)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("got %v, want: %v", err, tt.err)
+		}
+		if got != tt.want {
+			t.Errorf("got %v, want: %v", got, tt.want)
+		}
+	}
+}
+
+func TestParseGitHubStates(t *testing.T) {
+	states := make(map[string]string)
+	b := []byte(`[{"url": "https://github.com/o/r/issues/1", "state": "OPEN"}, {"url": "https://github.com/o/r/issues/2", "state": "CLOSED"}]`)
+	if err := parseGitHubStates(b, states); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"https://github.com/o/r/issues/1": "open",
+		"https://github.com/o/r/issues/2": "closed",
+	}
+	if diff := cmp.Diff(states, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestParseGitHubProject(t *testing.T) {
+	view := []byte(`{"id": "PVT_1", "number": 1, "title": "Roadmap"}`)
+	fields := []byte(`{"fields": [
+		{"id": "PVTF_1", "name": "Title", "type": "ProjectV2Field"},
+		{"id": "PVTSSF_1", "name": "Status", "type": "ProjectV2SingleSelectField", "options": [
+			{"id": "a1", "name": "Todo"},
+			{"id": "b2", "name": "Done"}
+		]}
+	]}`)
+	items := []byte(`{"items": [
+		{"id": "PVTI_1", "status": "Todo", "content": {"type": "Issue", "number": 1, "url": "https://github.com/o/r/issues/1"}},
+		{"id": "PVTI_2", "content": {"type": "DraftIssue", "title": "Draft"}}
+	]}`)
+	board, err := parseGitHubProject(view, fields, items, "Status")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := githubProjectBoard{
+		id:      "PVT_1",
+		fieldID: "PVTSSF_1",
+		options: map[string]string{"Todo": "a1", "Done": "b2"},
+		items: map[string]githubProjectItem{
+			"https://github.com/o/r/issues/1": {id: "PVTI_1", status: "Todo"},
+		},
+	}
+	if diff := cmp.Diff(board, want, cmp.AllowUnexported(githubProjectBoard{}, githubProjectItem{})); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+
+	_, err = parseGitHubProject(view, fields, items, "Stage")
+	if !errors.Is(err, errGitHubProjectField) {
+		t.Errorf("got %v, want: %v", err, errGitHubProjectField)
+	}
+}
+
+func TestSyncGitHubReadOnly(t *testing.T) {
+	data := NewData()
+	data.GitHubIssues = map[string]string{"KONG-1": "https://github.com/o/r/issues/1"}
+	data.jira = Jira{config: Config{ReadOnly: true}}
+
+	// the Jira client is not used in read-only mode
+	sync := GitHubSync{Statuses: []GitHubSyncStatus{{Jira: "Done", GitHub: "closed"}}}
+	if err := data.syncGitHub(context.Background(), sync); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,104 @@
+package kong
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+var errNoMutatingCommand = errors.New("history has no command which changed Jira")
+
+// History is the log of kong commands invoked on this machine.
+type History []HistoryEntry
+
+// HistoryEntry is a single invocation of kong with its arguments after
+// aliases have been expanded. Mutating commands change Jira and can be re-run.
+type HistoryEntry struct {
+	Time     time.Time `json:"time"`
+	Args     []string  `json:"args"`
+	Mutating bool      `json:"mutating,omitempty"`
+}
+
+// historyPath returns the path of the append-only command history next to
+// the data file.
+func historyPath() string {
+	return cachePath() + ".history"
+}
+
+// AppendHistory records the invocation in the command history.
+func AppendHistory(entry HistoryEntry) error {
+	return appendJSON(historyPath(), entry)
+}
+
+// ReadHistory returns the entries of the command history recorded since the
+// given time. A missing history has no entries.
+func ReadHistory(since time.Time) (History, error) {
+	f, err := os.Open(historyPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var history History
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("ReadHistory: %w", err)
+		}
+		if entry.Time.Before(since) {
+			continue
+		}
+		history = append(history, entry)
+	}
+	return history, scanner.Err()
+}
+
+// LastMutating returns the most recent command which changed Jira.
+func (h History) LastMutating() (HistoryEntry, error) {
+	for i := len(h) - 1; i >= 0; i-- {
+		if h[i].Mutating {
+			return h[i], nil
+		}
+	}
+	return HistoryEntry{}, errNoMutatingCommand
+}
+
+// CommandLine returns the invocation as shell command line, quoting arguments
+// which contain whitespace or quotes.
+func (e HistoryEntry) CommandLine() string {
+	args := make([]string, len(e.Args)+1)
+	args[0] = "kong"
+	for i, arg := range e.Args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n'\"") {
+			arg = strconv.Quote(arg)
+		}
+		args[i+1] = arg
+	}
+	return strings.Join(args, " ")
+}
+
+// Print writes the entries in the order they were recorded and marks commands
+// which changed Jira with an asterisk.
+func (h History) Print(output io.Writer) {
+	w := tabwriter.NewWriter(output, 1, 1, 1, ' ', 0)
+	for _, entry := range h {
+		marker := " "
+		if entry.Mutating {
+			marker = "*"
+		}
+		timestamp := entry.Time.Local().Format("2006/1/2 15:04:05")
+		fmt.Fprintf(w, "%s\t-\t%s %s\n", timestamp, marker, entry.CommandLine())
+	}
+	w.Flush()
+}
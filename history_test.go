@@ -0,0 +1,69 @@
+package kong
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHistory(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+
+	now := time.Now()
+	entries := []HistoryEntry{
+		{Time: now.AddDate(0, 0, -10), Args: []string{"sprint", "rollover"}, Mutating: true},
+		{Time: now.Add(-time.Minute), Args: []string{"issues", "new", "--input", "issues.txt"}, Mutating: true},
+		{Time: now, Args: []string{"sprint"}},
+	}
+	for _, entry := range entries {
+		if err := AppendHistory(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	history, err := ReadHistory(now.AddDate(0, 0, -7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(history), 2; got != want {
+		t.Fatalf("got %v, want: %v", got, want)
+	}
+	entry, err := history.LastMutating()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := entry.CommandLine(), "kong issues new --input issues.txt"; got != want {
+		t.Errorf("got %v, want: %v", got, want)
+	}
+	if _, err := history[1:].LastMutating(); !errors.Is(err, errNoMutatingCommand) {
+		t.Errorf("got %v, want: %v", err, errNoMutatingCommand)
+	}
+}
+
+func TestHistoryEntryCommandLine(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{
+			args: []string{"sprint", "--by-epic"},
+			want: "kong sprint --by-epic",
+		},
+		{
+			args: []string{"issues", "--filter", `status == "Done"`},
+			want: `kong issues --filter "status == \"Done\""`,
+		},
+		{
+			args: []string{"issue", "epic", "KONG-1", ""},
+			want: `kong issue epic KONG-1 ""`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := (HistoryEntry{Args: tt.args}).CommandLine(); got != tt.want {
+				t.Errorf("got %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// fullSyncInterval is the age after which the daemon fetches all sections
+	// again instead of only the issues changed since the last sync, which
+	// picks up changes the updated timestamp does not reflect, for instance
+	// sprints and deleted issues.
+	fullSyncInterval = 10 * time.Minute
+
+	// syncOverlap is subtracted from the time of the last sync since JQL
+	// compares updated timestamps in minutes.
+	syncOverlap = time.Minute
+
+	// maxChangedIssues bounds the number of changed issues merged into the
+	// data, larger changes like bulk edits fall back to a full sync.
+	maxChangedIssues = 200
+)
+
+// withKeys returns a copy of the Jira client which restricts searches to the
+// issues of the given keys.
+func (j Jira) withKeys(keys []string) Jira {
+	j.keys = keys
+	return j
+}
+
+// restrictJQL adds the condition to the query, keeping the order clause last.
+func restrictJQL(jql, condition string) string {
+	order := ""
+	if i := strings.Index(strings.ToUpper(jql), " ORDER BY "); i >= 0 {
+		jql, order = jql[:i], jql[i:]
+	}
+	return condition + " AND (" + jql + ")" + order
+}
+
+// ListChangedKeys returns the keys of the issues of the project and the
+// projects of the sprint board which were updated since the given time.
+func (j Jira) ListChangedKeys(ctx context.Context, since, now time.Time) ([]string, error) {
+	projects := []string{j.config.Project}
+	for _, project := range j.config.BoardProjects() {
+		if !contains(projects, project) {
+			projects = append(projects, project)
+		}
+	}
+	minutes := int(now.Sub(since).Minutes()) + 1
+	jql := fmt.Sprintf("project IN (%s) AND updated >= -%dm", strings.Join(projects, ", "), minutes)
+	issues, err := j.searchWithOptions(ctx, jql, jira.SearchOptions{Fields: []string{"key"}})
+	if err != nil {
+		return nil, fmt.Errorf("ListChangedKeys: %w", err)
+	}
+	keys := make([]string, len(issues))
+	for i, issue := range issues {
+		keys[i] = issue.Key
+	}
+	return keys, nil
+}
+
+// incremental reports whether the issues changed since the last sync suffice
+// to bring the data up to date.
+func (d Data) incremental(now time.Time) bool {
+	if d.Timestamp == 0 || d.FullSyncTimestamp == 0 {
+		return false
+	}
+	return time.Unix(d.FullSyncTimestamp, 0).After(now.Add(-fullSyncInterval))
+}
+
+// refresh brings all sections up to date. Only the issues changed since the
+// last sync are fetched unless a full sync is due.
+func (d *Data) refresh(ctx context.Context, now time.Time) error {
+	if d.incremental(now) {
+		if err := d.connect(); err != nil {
+			return err
+		}
+		ok, err := d.syncChanged(ctx, now)
+		if err != nil || ok {
+			return err
+		}
+	}
+	if err := d.load(ctx, SectionAll); err != nil {
+		return err
+	}
+	d.FullSyncTimestamp = d.Timestamp
+	return nil
+}
+
+// syncChanged merges the issues changed since the last sync into the issue
+// lists. It reports false if too many issues changed to merge them.
+func (d *Data) syncChanged(ctx context.Context, now time.Time) (bool, error) {
+	since := time.Unix(d.Timestamp, 0).Add(-syncOverlap)
+	keys, err := d.jira.ListChangedKeys(ctx, since, now)
+	if err != nil {
+		return false, err
+	}
+	if len(keys) > maxChangedIssues {
+		return false, nil
+	}
+	if len(keys) == 0 {
+		d.Timestamp = now.Unix()
+		return true, nil
+	}
+
+	// fetch the changed issues which are still part of each list
+	previous := d.snapshot()
+	j := d.jira.withKeys(keys)
+	lists := []struct {
+		issues *Issues
+		fetch  func(ctx context.Context) (Issues, error)
+	}{
+		{&d.Issues, func(ctx context.Context) (Issues, error) { return j.ListIssues(ctx, j.config.Project) }},
+		{&d.Epics, func(ctx context.Context) (Issues, error) { return j.ListEpics(ctx, j.config.Project) }},
+		{&d.Initiatives, func(ctx context.Context) (Issues, error) { return j.ListInitiatives(ctx, j.config.Project) }},
+		{&d.SprintIssues, j.ListSprintIssues},
+	}
+	fetched := make([]Issues, len(lists))
+	g, gctx := errgroup.WithContext(ctx)
+	for i, list := range lists {
+		i, fetch := i, list.fetch
+		g.Go(func() error {
+			issues, err := fetch(gctx)
+			fetched[i] = issues
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return false, err
+	}
+	for i, list := range lists {
+		*list.issues = mergeChanged(*list.issues, keys, fetched[i])
+	}
+	d.IssueByKey = withIssuesByKey(d.IssueByKey, fetched[0])
+
+	// only the progress of epics with changed children is fetched again
+	epicKeys, err := d.changedEpics(ctx, keys, previous)
+	if err != nil {
+		return false, err
+	}
+	children, err := d.jira.ListEpicChildren(ctx, epicKeys)
+	if err != nil {
+		return false, err
+	}
+	d.EpicProgress = withProgress(d.EpicProgress, epicKeys, NewProgress(children))
+	d.Timestamp = now.Unix()
+	return true, nil
+}
+
+// changedEpics returns the epics whose progress may have changed with the
+// changed issues: the epics the cached issues belonged to before, the epics
+// the issues belong to now and changed epics themselves. Archived epics are
+// left out.
+func (d *Data) changedEpics(ctx context.Context, keys []string, previous map[string]Issue) ([]string, error) {
+	current, err := d.jira.listEpicKeys(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+	affected := make(map[string]bool, len(keys)+len(current))
+	for _, key := range keys {
+		affected[key] = true
+		if issue, ok := previous[key]; ok && issue.EpicKey != "" {
+			affected[issue.EpicKey] = true
+		}
+	}
+	for _, key := range current {
+		affected[key] = true
+	}
+	var epicKeys []string
+	for _, epic := range d.WithoutArchived(d.Epics) {
+		if affected[epic.Key] {
+			epicKeys = append(epicKeys, epic.Key)
+		}
+	}
+	return epicKeys, nil
+}
+
+// mergeChanged replaces the changed issues of the list with the fetched
+// issues. Changed issues which were not fetched no longer belong to the list
+// and fetched issues which were not listed before are appended.
+func mergeChanged(issues Issues, changed []string, fetched Issues) Issues {
+	isChanged := make(map[string]bool, len(changed))
+	for _, key := range changed {
+		isChanged[key] = true
+	}
+	byKey := make(map[string]Issue, len(fetched))
+	for _, issue := range fetched {
+		byKey[issue.Key] = issue
+	}
+	result := make(Issues, 0, len(issues)+len(fetched))
+	for _, issue := range issues {
+		if !isChanged[issue.Key] {
+			result = append(result, issue)
+			continue
+		}
+		if update, ok := byKey[issue.Key]; ok {
+			result = append(result, update)
+			delete(byKey, issue.Key)
+		}
+	}
+	for _, issue := range fetched {
+		if _, ok := byKey[issue.Key]; ok {
+			result = append(result, issue)
+		}
+	}
+	return result
+}
@@ -0,0 +1,84 @@
+package kong
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMergeChanged(t *testing.T) {
+	issues := Issues{
+		{Key: "KONG-1", Summary: "Unchanged"},
+		{Key: "KONG-2", Summary: "Before"},
+		{Key: "KONG-3", Summary: "Moved to done"},
+	}
+	fetched := Issues{
+		{Key: "KONG-4", Summary: "Assigned"},
+		{Key: "KONG-2", Summary: "After"},
+	}
+	got := mergeChanged(issues, []string{"KONG-2", "KONG-3", "KONG-4", "KONG-5"}, fetched)
+	want := Issues{
+		{Key: "KONG-1", Summary: "Unchanged"},
+		{Key: "KONG-2", Summary: "After"},
+		{Key: "KONG-4", Summary: "Assigned"},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestRestrictJQL(t *testing.T) {
+	tests := []struct {
+		jql  string
+		want string
+	}{
+		{
+			jql:  "project = KONG AND status != Done",
+			want: "key IN (KONG-1) AND (project = KONG AND status != Done)",
+		},
+		{
+			jql:  "project = KONG ORDER BY created DESC",
+			want: "key IN (KONG-1) AND (project = KONG) ORDER BY created DESC",
+		},
+	}
+	for _, tt := range tests {
+		if got := restrictJQL(tt.jql, "key IN (KONG-1)"); got != tt.want {
+			t.Errorf("got %q, want: %q", got, tt.want)
+		}
+	}
+}
+
+func TestDataIncremental(t *testing.T) {
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		fullSync time.Time
+		want     bool
+	}{
+		{
+			name: "never synced",
+		},
+		{
+			name:     "recent full sync",
+			fullSync: now.Add(-time.Minute),
+			want:     true,
+		},
+		{
+			name:     "full sync due",
+			fullSync: now.Add(-fullSyncInterval),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := NewData()
+			if !tt.fullSync.IsZero() {
+				data.Timestamp = tt.fullSync.Unix()
+				data.FullSyncTimestamp = tt.fullSync.Unix()
+			}
+			if got := data.incremental(now); got != tt.want {
+				t.Errorf("got %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
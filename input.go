@@ -1,9 +1,14 @@
 package kong
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 )
 
+var errNoAnswer = errors.New("no answer to confirm")
+
 // ReadString reads the user input from stdin and returns the input as a
 // string.
 func ReadString(prompt string) (string, error) {
@@ -14,3 +19,22 @@ func ReadString(prompt string) (string, error) {
 	}
 	return input, nil
 }
+
+// Confirm prompts the user for a yes or no answer and returns true only if
+// the user answered with yes. An empty answer means no, while stdin being
+// closed returns an error so that callers do not mistake it for an answer.
+func Confirm(prompt string) (bool, error) {
+	input, err := ReadString(prompt + " [y/N] ")
+	if errors.Is(err, io.EOF) {
+		fmt.Println()
+		return false, fmt.Errorf("%w: %s", errNoAnswer, prompt)
+	}
+	if err != nil {
+		return false, nil
+	}
+	switch strings.ToLower(input) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
@@ -2,6 +2,7 @@ package kong
 
 import (
 	"errors"
+	"os"
 	"reflect"
 	"strconv"
 	"testing"
@@ -93,12 +94,23 @@ func TestParseIssue(t *testing.T) {
 		err     error
 	}{
 		{
-			name: "fails-non-integer-epics",
+			name: "fails-unknown-epic-key",
 			columns: []string{
-				"foo",
+				"KONG-2",
+				"0",
+				"summary",
+				"0.5",
+				"description",
+			},
+			data: Data{
+				Epics: []Issue{
+					{
+						Key: "KONG-1",
+					},
+				},
 			},
 			want: nil,
-			err:  strconv.ErrSyntax,
+			err:  errParentMismatch,
 		},
 		{
 			name: "fails-non-integer-sprints",
@@ -183,3 +195,41 @@ func TestParseIssue(t *testing.T) {
 		}
 	}
 }
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+		err   error
+	}{
+		{name: "yes", input: "y\n", want: true},
+		{name: "no", input: "n\n", want: false},
+		{name: "empty-answer", input: "\n", want: false},
+		{name: "closed-stdin", input: "", want: false, err: errNoAnswer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, w, err := os.Pipe()
+			if err != nil {
+				t.Fatal(err)
+			}
+			stdin := os.Stdin
+			os.Stdin = r
+			t.Cleanup(func() { os.Stdin = stdin })
+			if _, err := w.WriteString(tt.input); err != nil {
+				t.Fatal(err)
+			}
+			w.Close()
+
+			got, err := Confirm("Proceed?")
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want: %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
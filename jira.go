@@ -6,6 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"os"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -14,7 +17,16 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
-const defaultMaxResults = 100
+var (
+	errNoResponse  = errors.New("no response from Jira")
+	errRateLimited = errors.New("rate limited by Jira")
+)
+
+const (
+	defaultMaxResults = 100
+	userExpiry        = 24 * time.Hour
+	sprintDateLayout  = "2006-01-02T15:04:05.000-07:00"
+)
 
 // Jira encapsulates interaction with the Jira API. It exposes a subset of the
 // possible interactions in order to simplify the workflow tailored to the
@@ -22,36 +34,115 @@ const defaultMaxResults = 100
 type Jira struct {
 	client     *jira.Client
 	user       *jira.User
+	self       User
 	config     Config
 	maxResults int
+	schemas    *fieldSchemas
+
+	// keys restricts searches to the given issues if set
+	keys []string
 }
 
-// NewJira returns a Jira client based on the given username and password.
+// NewJira returns a Jira client based on the given username and password. The
+// client is created on first use and shared afterwards to reuse connections
+// and the GetSelf round trip. It is created again if the configuration changed
+// and errors are not cached.
 func NewJira() (Jira, error) {
+	session.jiraMu.Lock()
+	defer session.jiraMu.Unlock()
+	return session.jira.load(Config{}.filepath(), newJira)
+}
+
+func newJira() (Jira, error) {
 	config, err := LoadConfig()
 	if err != nil {
 		return Jira{}, fmt.Errorf("NewJira: %w", err)
 	}
-	tp := jira.BasicAuthTransport{
-		Username: config.Username,
-		Password: config.Password,
+	transport, err := config.transport()
+	if err != nil {
+		return Jira{}, fmt.Errorf("NewJira: %w", err)
+	}
+	httpClient := &http.Client{
+		Transport: config.authTransport(rateLimitTransport{
+			transport: auditTransport{
+				transport: transport,
+			},
+		}),
 	}
-	client, err := jira.NewClient(tp.Client(), config.Endpoint)
+	client, err := jira.NewClient(httpClient, config.Endpoint)
 	if err != nil {
 		return Jira{}, fmt.Errorf("NewClient: %w", err)
 	}
-	user, _, err := client.User.GetSelf()
+	self, err := currentUser(client, config)
 	if err != nil {
-		return Jira{}, fmt.Errorf("GetSelf: %w", err)
+		return Jira{}, err
 	}
 	return Jira{
 		client:     client,
-		user:       user,
+		user:       self.jiraUser(),
+		self:       self,
 		config:     config,
 		maxResults: defaultMaxResults,
+		schemas:    &fieldSchemas{},
 	}, nil
 }
 
+// withProject returns a copy of the Jira client for the given project using
+// the custom fields of the project.
+func (j Jira) withProject(project string) Jira {
+	if project == j.config.Project {
+		return j
+	}
+	j.config = j.config.forProject(project)
+	j.config.Project = project
+	j.config.boardProjects = nil
+	return j
+}
+
+// currentUser returns the user cached on disk to avoid blocking on GetSelf
+// and only asks Jira if the cached user is missing, expired or was fetched for
+// another account.
+func currentUser(client *jira.Client, config Config) (User, error) {
+	data, err := ReadData()
+	if err == nil && !data.User.Expired(time.Now()) && data.User.fetchedFor(config) {
+		return data.User, nil
+	}
+	return fetchUser(client, config, time.Now())
+}
+
+func fetchUser(client *jira.Client, config Config, now time.Time) (User, error) {
+	user, _, err := client.User.GetSelf()
+	if err != nil {
+		return User{}, fmt.Errorf("GetSelf: %w", err)
+	}
+	self := NewUser(user)
+	self.FetchedAt = now
+	self.Endpoint = config.Endpoint
+	self.Username = config.Username
+	return self, nil
+}
+
+// refreshSelf fetches the current user again once it expired. The shared
+// client is updated as well so that long-running processes like the daemon
+// keep the cached user fresh.
+func (j Jira) refreshSelf(now time.Time) (Jira, error) {
+	if !j.self.Expired(now) {
+		return j, nil
+	}
+	self, err := fetchUser(j.client, j.config, now)
+	if err != nil {
+		return j, err
+	}
+	j.self, j.user = self, self.jiraUser()
+
+	session.jiraMu.Lock()
+	if shared := &session.jira.value; session.jira.ok && shared.client == j.client {
+		shared.self, shared.user = j.self, j.user
+	}
+	session.jiraMu.Unlock()
+	return j, nil
+}
+
 // ListIssues fetches all issues according to a specific JQL query.
 func (j Jira) ListIssues(ctx context.Context, project string) (Issues, error) {
 	conditions := []string{
@@ -60,7 +151,10 @@ func (j Jira) ListIssues(ctx context.Context, project string) (Issues, error) {
 		"assignee = \"" + j.user.DisplayName + "\"",
 		"status NOT IN (Closed, Done)",
 	}
-	jql := strings.Join(conditions, " AND ")
+	jql, err := j.config.jql(jqlIssues, project, j.user.DisplayName, strings.Join(conditions, " AND "))
+	if err != nil {
+		return nil, fmt.Errorf("ListIssues: %w", err)
+	}
 	issues, err := j.search(ctx, jql)
 	if err != nil {
 		return nil, fmt.Errorf("ListIssues: %w", err)
@@ -70,13 +164,22 @@ func (j Jira) ListIssues(ctx context.Context, project string) (Issues, error) {
 
 // ListSprintIssues fetches all issues assigned to the current sprint.
 func (j Jira) ListSprintIssues(ctx context.Context) (Issues, error) {
+	return j.ListSprintIssuesForAssignee(ctx, j.user.DisplayName)
+}
+
+// ListSprintIssuesForAssignee fetches all issues of the current sprint
+// assigned to the given user.
+func (j Jira) ListSprintIssuesForAssignee(ctx context.Context, assignee string) (Issues, error) {
 	conditions := []string{
-		"project = " + j.config.Project,
+		j.config.projectCondition(),
 		"issueType IN (Story, Task, Bug)",
-		"assignee = \"" + j.user.DisplayName + "\"",
+		"assignee = \"" + assignee + "\"",
 		"sprint in openSprints()",
 	}
-	jql := strings.Join(conditions, " AND ")
+	jql, err := j.config.jql(jqlSprintIssues, j.config.Project, assignee, strings.Join(conditions, " AND "))
+	if err != nil {
+		return nil, fmt.Errorf("ListSprintIssues: %w", err)
+	}
 	issues, err := j.search(ctx, jql)
 	if err != nil {
 		return nil, fmt.Errorf("ListSprintIssues: %w", err)
@@ -84,6 +187,23 @@ func (j Jira) ListSprintIssues(ctx context.Context) (Issues, error) {
 	return issues, nil
 }
 
+// ListUntriagedBugs fetches all open bugs of the given project which have not
+// been assigned yet, newest first.
+func (j Jira) ListUntriagedBugs(ctx context.Context, project string) (Issues, error) {
+	conditions := []string{
+		"project = " + project,
+		"issueType = Bug",
+		"assignee IS EMPTY",
+		"status NOT IN (Closed, Done)",
+	}
+	jql := strings.Join(conditions, " AND ") + " ORDER BY created DESC"
+	issues, err := j.search(ctx, jql)
+	if err != nil {
+		return nil, fmt.Errorf("ListUntriagedBugs: %w", err)
+	}
+	return issues, nil
+}
+
 // ListEpics returns a list of epics associated with the current project.
 func (j Jira) ListEpics(ctx context.Context, project string) (Issues, error) {
 	conditions := []string{
@@ -99,7 +219,10 @@ func (j Jira) ListEpics(ctx context.Context, project string) (Issues, error) {
 		conditions = append(conditions, label)
 	}
 
-	jql := strings.Join(conditions, " AND ")
+	jql, err := j.config.jql(jqlEpics, project, j.user.DisplayName, strings.Join(conditions, " AND "))
+	if err != nil {
+		return nil, fmt.Errorf("ListEpics: %w", err)
+	}
 	issues, err := j.search(ctx, jql)
 	if err != nil {
 		return nil, fmt.Errorf("ListEpics: %w", err)
@@ -171,7 +294,9 @@ func (j Jira) GetBoardID(project string) (int, error) {
 	if err := json.Unmarshal(b, &result); err != nil {
 		return 0, err
 	}
-
+	if len(result.Values) == 0 {
+		return 0, boardError(project)
+	}
 	return result.Values[0].ID, nil
 }
 
@@ -189,42 +314,129 @@ func (j Jira) ListSprintsForBoard(boardID int) (Sprints, error) {
 	return NewSprints(sprints.Values), nil
 }
 
-// CreateIssues creates the given issues in parallel.
-func (j Jira) CreateIssues(ctx context.Context, issues []*jira.Issue) error {
+// CreateIssuesError reports the issues of a batch which could not be created
+// by their index in the batch.
+type CreateIssuesError map[int]error
+
+func (e CreateIssuesError) Error() string {
+	indices := make([]int, 0, len(e))
+	for i := range e {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+	messages := make([]string, len(indices))
+	for i, index := range indices {
+		messages[i] = fmt.Sprintf("issue %d: %v", index+1, e[index])
+	}
+	return strings.Join(messages, "; ")
+}
+
+// CreateIssues creates the given issues in parallel and returns the keys of
+// the created issues in the same order. If some issues could not be created
+// the keys of the created issues are returned together with a
+// CreateIssuesError.
+func (j Jira) CreateIssues(ctx context.Context, issues []*jira.Issue) ([]string, error) {
 	var (
 		mu               sync.Mutex
 		lastIssueCreated string
+		errs             = make(CreateIssuesError)
 	)
-	g, ctx := errgroup.WithContext(ctx)
-	for _, issue := range issues {
+	keys := make([]string, len(issues))
+	var g errgroup.Group
+	for i, issue := range issues {
 		// allocate variable to avoid scope capturing
-		issue := issue
+		i, issue := i, issue
 
-		// create issues concurrency
+		// create issues concurrency, failures must not cancel the other issues
 		g.Go(func() error {
-			newIssue, resp, err := j.client.Issue.CreateWithContext(ctx, issue)
+			key, err := j.CreateIssue(ctx, issue)
+			mu.Lock()
+			defer mu.Unlock()
 			if err != nil {
-				return parseResponseError(resp)
+				errs[i] = err
+				return nil
 			}
-			fmt.Printf("Created %s - %s\n", newIssue.Key, issue.Fields.Summary)
-			mu.Lock()
-			lastIssueCreated = newIssue.Key
-			mu.Unlock()
+			keys[i] = key
+			lastIssueCreated = key
 			return nil
 		})
 	}
 	if err := g.Wait(); err != nil {
-		return err
+		return nil, err
+	}
+	if err := setLastIssueCreated(lastIssueCreated); err != nil {
+		return nil, err
+	}
+	if len(errs) > 0 {
+		return keys, errs
 	}
-	data, err := LoadData()
+	return keys, nil
+}
+
+// setLastIssueCreated stores the key of the last created issue in the state
+// unless no issue has been created.
+func setLastIssueCreated(key string) error {
+	if key == "" {
+		return nil
+	}
+	return updateState(func(s *State) error {
+		s.LastIssueCreated = key
+		return nil
+	})
+}
+
+// CreateIssue creates a single issue and returns the key of the new issue.
+func (j Jira) CreateIssue(ctx context.Context, issue *jira.Issue) (string, error) {
+	newIssue, resp, err := j.client.Issue.CreateWithContext(ctx, j.withSprintShape(ctx, issue))
 	if err != nil {
-		return err
+		return "", parseResponseError(resp)
 	}
-	data.LastIssueCreated = lastIssueCreated
-	return data.WriteFile()
+	fmt.Printf("Created %s - %s - %s\n", newIssue.Key, issue.Fields.Summary, j.BrowseURL(newIssue.Key))
+	return newIssue.Key, nil
+}
+
+// BrowseURL returns the URL to view the issue in the browser.
+func (j Jira) BrowseURL(key string) string {
+	return j.config.BrowseURL(key)
 }
 
-func (j Jira) UpdateIssue(ctx context.Context, key string, issue Issue) error {
+// ListIssuesByKey fetches the issues for the given keys regardless of their
+// status or assignee. Keys of issues which do not exist are left out of the
+// result instead of failing the query.
+func (j Jira) ListIssuesByKey(ctx context.Context, keys []string) (Issues, error) {
+	var issues Issues
+	for _, batch := range batchKeys(keys) {
+		jql := "key IN (" + strings.Join(batch, ",") + ")"
+		if j.keys != nil {
+			jql = restrictJQL(jql, "key IN ("+strings.Join(j.keys, ", ")+")")
+		}
+		// Jira rejects the whole query for a single unknown key unless the
+		// validation only warns about it
+		options := jira.SearchOptions{Expand: "transitions", ValidateQuery: "warn"}
+		result, err := j.searchWithOptions(ctx, jql, options)
+		if err != nil {
+			return nil, fmt.Errorf("ListIssuesByKey: %w", err)
+		}
+		batchIssues, err := NewIssues(result, j.config)
+		if err != nil {
+			return nil, fmt.Errorf("ListIssuesByKey: %w", err)
+		}
+		issues = append(issues, batchIssues...)
+	}
+	return issues, nil
+}
+
+// Field is an issue field which UpdateIssue can clear.
+type Field string
+
+// Fields which can be cleared.
+const (
+	FieldSprint Field = "sprint"
+)
+
+// UpdateIssue changes the summary of the issue and moves it to the sprint if
+// a sprint ID is set. Fields which are given to clear are removed instead.
+func (j Jira) UpdateIssue(ctx context.Context, key string, issue Issue, clear ...Field) error {
 	data := map[string]interface{}{
 		"update": make(map[string]interface{}),
 	}
@@ -240,10 +452,23 @@ func (j Jira) UpdateIssue(ctx context.Context, key string, issue Issue) error {
 		updates := data["update"].(map[string][]map[string]interface{})
 		updates[j.config.CustomFields.Sprints] = []map[string]interface{}{
 			{
-				"set": issue.SprintID,
+				"set": j.sprintValue(ctx, issue.SprintID),
 			},
 		}
 	}
+	for _, field := range clear {
+		switch field {
+		case FieldSprint:
+			updates := data["update"].(map[string][]map[string]interface{})
+			updates[j.config.CustomFields.Sprints] = []map[string]interface{}{
+				{
+					"set": j.sprintValue(ctx, 0),
+				},
+			}
+		default:
+			return fmt.Errorf("UpdateIssue: %w: %s", errFieldNotClearable, field)
+		}
+	}
 
 	resp, err := j.client.Issue.UpdateIssueWithContext(ctx, key, data)
 	if err != nil {
@@ -253,16 +478,179 @@ func (j Jira) UpdateIssue(ctx context.Context, key string, issue Issue) error {
 	return nil
 }
 
+// AssignIssue assigns the issue to the current user.
+func (j Jira) AssignIssue(ctx context.Context, key string) error {
+	return j.assign(ctx, key, j.user)
+}
+
+// SetPriority changes the priority of the issue.
+func (j Jira) SetPriority(ctx context.Context, key, priority string) error {
+	data := map[string]interface{}{
+		"fields": map[string]interface{}{
+			"priority": map[string]string{
+				"name": priority,
+			},
+		},
+	}
+	resp, err := j.client.Issue.UpdateIssueWithContext(ctx, key, data)
+	if err != nil {
+		return fmt.Errorf("SetPriority: %w", parseResponseError(resp))
+	}
+	fmt.Printf("%s - Priority changed to %s\n", key, priority)
+	return nil
+}
+
+// SetEpic links the issue to the epic or removes the issue from its epic if
+// the epic key is empty.
+func (j Jira) SetEpic(ctx context.Context, key, epicKey string) error {
+	if j.config.CustomFields.Epics == "" {
+		return fmt.Errorf("SetEpic: %w", errConfigEpicField)
+	}
+	var epic interface{}
+	if epicKey != "" {
+		epic = epicKey
+	}
+	data := map[string]interface{}{
+		"fields": map[string]interface{}{
+			j.config.CustomFields.Epics: epic,
+		},
+	}
+	resp, err := j.client.Issue.UpdateIssueWithContext(ctx, key, data)
+	if err != nil {
+		return fmt.Errorf("SetEpic: %w", parseResponseError(resp))
+	}
+	if epicKey == "" {
+		fmt.Printf("%s - Removed from epic\n", key)
+	} else {
+		fmt.Printf("%s - Moved to epic %s\n", key, epicKey)
+	}
+	return recordEpicChange(key, epicKey, time.Now())
+}
+
+// recordEpicChange stores the epic of the issue in the state so that the
+// cached issue is listed in its epic before the daemon syncs again. The data
+// file is left to the daemon which would overwrite it.
+func recordEpicChange(key, epicKey string, now time.Time) error {
+	return updateState(func(s *State) error {
+		if s.EpicChanges == nil {
+			s.EpicChanges = make(map[string]EpicChange)
+		}
+		s.EpicChanges[key] = EpicChange{EpicKey: epicKey, At: now}
+		return nil
+	})
+}
+
+// pruneEpicChanges drops the epic changes made before the sync which started
+// at the given time since the synced data includes them.
+func pruneEpicChanges(synced time.Time) error {
+	state, err := readState()
+	if err != nil || len(state.EpicChanges) == 0 {
+		return err
+	}
+	return updateState(func(s *State) error {
+		for key, change := range s.EpicChanges {
+			if change.At.Before(synced) {
+				delete(s.EpicChanges, key)
+			}
+		}
+		return nil
+	})
+}
+
+// AddFixVersion adds an existing version to the fix versions of the issue.
+func (j Jira) AddFixVersion(ctx context.Context, key, version string) error {
+	data := map[string]interface{}{
+		"update": map[string][]map[string]interface{}{
+			"fixVersions": {
+				{
+					"add": map[string]string{
+						"name": version,
+					},
+				},
+			},
+		},
+	}
+	resp, err := j.client.Issue.UpdateIssueWithContext(ctx, key, data)
+	if err != nil {
+		return fmt.Errorf("AddFixVersion: %w", parseResponseError(resp))
+	}
+	fmt.Printf("%s - Fix version %s\n", key, version)
+	return nil
+}
+
+// MoveIssuesToSprint moves the given issues into the sprint.
+func (j Jira) MoveIssuesToSprint(ctx context.Context, sprint Sprint, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	resp, err := j.client.Sprint.MoveIssuesToSprintWithContext(ctx, sprint.ID, keys)
+	if err != nil {
+		return fmt.Errorf("MoveIssuesToSprint: %w", parseResponseError(resp))
+	}
+	for _, key := range keys {
+		fmt.Printf("%s - Moved to %s\n", key, sprint.Name)
+	}
+	return nil
+}
+
+// CloseAsDuplicate links the issue as duplicate of another issue and
+// transitions it into a done status.
+func (j Jira) CloseAsDuplicate(ctx context.Context, issue Issue, duplicateOf string) error {
+	link := &jira.IssueLink{
+		Type: jira.IssueLinkType{
+			Name: "Duplicate",
+		},
+		InwardIssue: &jira.Issue{
+			Key: issue.Key,
+		},
+		OutwardIssue: &jira.Issue{
+			Key: duplicateOf,
+		},
+	}
+	resp, err := j.client.Issue.AddLinkWithContext(ctx, link)
+	if err != nil {
+		return fmt.Errorf("CloseAsDuplicate: %w", parseResponseError(resp))
+	}
+	transition, ok := issue.DoneTransition()
+	if !ok {
+		return fmt.Errorf("CloseAsDuplicate: %w: %s", errUnknownTransition, issue.Key)
+	}
+	return j.TransitionIssues(ctx, []issueTransition{
+		{
+			issueKey:   issue.Key,
+			transition: transition,
+		},
+	})
+}
+
+// AddComment adds a comment to the issue.
+func (j Jira) AddComment(ctx context.Context, key, body string) error {
+	comment := &jira.Comment{
+		Body: body,
+	}
+	_, resp, err := j.client.Issue.AddCommentWithContext(ctx, key, comment)
+	if err != nil {
+		return fmt.Errorf("AddComment: %w", parseResponseError(resp))
+	}
+	return nil
+}
+
 // CreateSprint creates a new sprint.
 func (j Jira) CreateSprint(name string, month, day, boardID int) error {
-	// configure start and end date
-	now := time.Now()
-	tz := now.Location()
-	layout := "2006-01-02T15:04:05.000-07:00"
-	startDate := time.Date(now.Year(), time.Month(month), day, 0, 0, 0, 0, tz)
+	if boardID == 0 {
+		return boardError(j.config.Project)
+	}
+
+	// configure start and end date in the timezone of the board
+	loc, err := j.config.Location()
+	if err != nil {
+		return err
+	}
+	now := time.Now().In(loc)
+	startDate := time.Date(now.Year(), time.Month(month), day, 0, 0, 0, 0, loc)
 
 	// define end date based on configured sprint duration
-	endDate := startDate.Add(time.Duration(j.config.SprintDuration+1) * 24 * time.Hour)
+	endDate := j.config.Calendar().EndDate(startDate, j.config.SprintDuration)
 
 	// define payload
 	payload := struct {
@@ -273,8 +661,8 @@ func (j Jira) CreateSprint(name string, month, day, boardID int) error {
 		Goal          string `json:"goal,omitempty"`
 	}{
 		Name:          fmt.Sprintf("%s %d/%d", name, month, day),
-		StartDate:     startDate.Format(layout),
-		EndDate:       endDate.Format(layout),
+		StartDate:     startDate.Format(sprintDateLayout),
+		EndDate:       endDate.Format(sprintDateLayout),
 		OriginBoardID: boardID,
 	}
 
@@ -291,13 +679,100 @@ func (j Jira) CreateSprint(name string, month, day, boardID int) error {
 	return nil
 }
 
+// newIssue returns an issue of the given type for the configured project,
+// components and labels, assigned to and reported by the current user. Custom
+// field defaults are set unless unknowns contains the field. The defaults of
+// the issue type add labels and components and set the priority.
+func (j Jira) newIssue(issueType, summary, description string, unknowns map[string]any) *jira.Issue {
+	if unknowns == nil {
+		unknowns = make(map[string]any)
+	}
+	for id, value := range j.config.fieldDefaults(issueType) {
+		if _, ok := unknowns[id]; !ok {
+			unknowns[id] = value
+		}
+	}
+	defaults := j.config.IssueTypes[issueType]
+
+	// convert configured components
+	names := append(append([]string(nil), j.config.Components...), defaults.Components...)
+	components := make([]*jira.Component, 0, len(names))
+	for _, name := range uniqueStrings(names) {
+		components = append(components, &jira.Component{
+			Name: name,
+		})
+	}
+
+	var priority *jira.Priority
+	if defaults.Priority != "" {
+		priority = &jira.Priority{Name: defaults.Priority}
+	}
+
+	labels := append(append([]string(nil), j.config.Labels...), defaults.Labels...)
+	return &jira.Issue{
+		Fields: &jira.IssueFields{
+			Project: jira.Project{
+				Key: j.config.Project,
+			},
+			Assignee: j.user,
+			Reporter: j.user,
+			Type: jira.IssueType{
+				Name: issueType,
+			},
+			Summary:     summary,
+			Description: description,
+			Unknowns:    unknowns,
+			Components:  components,
+			Labels:      uniqueStrings(labels),
+			Priority:    priority,
+		},
+	}
+}
+
+// uniqueStrings returns the values without duplicates in their order.
+func uniqueStrings(values []string) []string {
+	var result []string
+	seen := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		result = append(result, value)
+	}
+	return result
+}
+
+// UpdateSprintState changes the state of the sprint, for instance to close the
+// active sprint or to start a future sprint.
+func (j Jira) UpdateSprintState(ctx context.Context, sprint Sprint, state string) error {
+	payload := map[string]string{
+		"state": state,
+	}
+	req, err := j.client.NewRequestWithContext(ctx, "POST", fmt.Sprintf("/rest/agile/1.0/sprint/%d", sprint.ID), payload)
+	if err != nil {
+		return err
+	}
+	resp, err := j.client.Do(req, nil)
+	if err != nil {
+		return fmt.Errorf("UpdateSprintState: %w", parseResponseError(resp))
+	}
+	fmt.Printf("%s - Sprint %s\n", sprint.Name, state)
+	return nil
+}
+
 type issueTransition struct {
 	issueKey   string
 	transition Transition
 }
 
-// TransitionIssues performs batch transitions on a set of issues.
+// TransitionIssues performs batch transitions on a set of issues. Issues
+// moved to a done status are recorded as completed.
 func (j Jira) TransitionIssues(ctx context.Context, issueTransitions []issueTransition) error {
+	var (
+		mu   sync.Mutex
+		done []string
+	)
 	g, ctx := errgroup.WithContext(ctx)
 	for _, t := range issueTransitions {
 		// allocate variable to avoid scope capturing
@@ -313,11 +788,44 @@ func (j Jira) TransitionIssues(ctx context.Context, issueTransitions []issueTran
 				return fmt.Errorf("TranitionIssues: %w", parseResponseError(resp))
 			}
 			fmt.Printf("%s - Status changed to %s\n", t.issueKey, t.transition.Name)
+			if contains(j.config.DoneStatusNames(), t.transition.Name) {
+				mu.Lock()
+				done = append(done, t.issueKey)
+				mu.Unlock()
+			}
 			return nil
 		})
 	}
 
-	return g.Wait()
+	err := g.Wait()
+	if len(done) > 0 {
+		j.recordCompletions(done)
+	}
+	return err
+}
+
+// recordCompletions appends the completed issues to the completion log and
+// celebrates them if enabled. Failures are reported without failing the
+// transitions which already succeeded.
+func (j Jira) recordCompletions(keys []string) {
+	now := time.Now()
+	if err := AppendCompletions(keys, now); err != nil {
+		fmt.Fprintf(os.Stderr, "recordCompletions: %v\n", err)
+		return
+	}
+	if !j.config.Celebrate {
+		return
+	}
+	loc, err := j.config.Location()
+	if err != nil {
+		loc = time.Local
+	}
+	completions, err := ReadCompletions(startOfDay(now.In(loc)).AddDate(0, 0, -maxSnapshots))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "recordCompletions: %v\n", err)
+		return
+	}
+	PrintCelebration(os.Stdout, completions, now.In(loc), j.config.Calendar())
 }
 
 func (j Jira) MoveIssuesToBacklog(ctx context.Context, keys []string) error {
@@ -341,33 +849,106 @@ func (j Jira) MoveIssuesToBacklog(ctx context.Context, keys []string) error {
 }
 
 func (j Jira) search(ctx context.Context, jql string) (Issues, error) {
+	if j.keys != nil {
+		jql = restrictJQL(jql, "key IN ("+strings.Join(j.keys, ", ")+")")
+	}
+	result, err := j.searchWithExpand(ctx, jql, "transitions")
+	if err != nil {
+		return nil, err
+	}
+	return NewIssues(result, j.config)
+}
+
+func (j Jira) searchWithExpand(ctx context.Context, jql, expand string) ([]jira.Issue, error) {
+	return j.searchWithOptions(ctx, jql, jira.SearchOptions{Expand: expand})
+}
+
+// searchWithOptions fetches all pages of the search results.
+func (j Jira) searchWithOptions(ctx context.Context, jql string, options jira.SearchOptions) ([]jira.Issue, error) {
 	var (
 		result  []jira.Issue
 		startAt int
 	)
 	for {
-		list, resp, err := j.client.Issue.SearchWithContext(ctx, jql, &jira.SearchOptions{
-			StartAt:    startAt,
-			MaxResults: j.maxResults,
-			Expand:     "transitions",
-		})
+		options.StartAt = startAt
+		options.MaxResults = j.maxResults
+		list, resp, err := j.client.Issue.SearchWithContext(ctx, jql, &options)
 		if err != nil {
 			return nil, fmt.Errorf("search: %w", parseResponseError(resp))
 		}
 		result = append(result, list...)
 
-		if len(result) >= resp.Total {
+		// an empty page ends the search even if the total promises more
+		if len(list) == 0 || len(result) >= resp.Total {
 			break
 		}
 		startAt += len(list)
 	}
-	return NewIssues(result, j.config.CustomFields)
+	return result, nil
+}
+
+// isUser reports whether the given user is the current user.
+func (j Jira) isUser(user jira.User) bool {
+	if j.user == nil {
+		return false
+	}
+	if user.AccountID != "" {
+		return user.AccountID == j.user.AccountID
+	}
+	return user.Name == j.user.Name
+}
+
+// ResponseError is an error response returned by the Jira API. Errors
+// contains the messages of invalid fields by field ID.
+type ResponseError struct {
+	ErrorMessages []string          `json:"errorMessages"`
+	Errors        map[string]string `json:"errors"`
+
+	body string
+}
+
+func (e *ResponseError) Error() string {
+	return e.body
+}
+
+// Messages returns all error messages. Field IDs are replaced with the given
+// field names to make field errors readable.
+func (e *ResponseError) Messages(names map[string]string) []string {
+	messages := append([]string(nil), e.ErrorMessages...)
+	fields := make([]string, 0, len(e.Errors))
+	for field := range e.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		message := e.Errors[field]
+		if name, ok := names[field]; ok {
+			message = strings.ReplaceAll(message, field, name)
+			if !strings.Contains(message, name) {
+				message = name + ": " + message
+			}
+		}
+		messages = append(messages, message)
+	}
+	if len(messages) == 0 {
+		messages = append(messages, e.body)
+	}
+	return messages
 }
 
 func parseResponseError(resp *jira.Response) error {
+	if resp == nil || resp.Body == nil {
+		return errNoResponse
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return errRateLimited
+	}
 	b, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return err
 	}
-	return errors.New(string(b))
+	respErr := &ResponseError{body: string(b)}
+	// the body is returned as is if it is not a Jira error response
+	_ = json.Unmarshal(b, respErr)
+	return respErr
 }
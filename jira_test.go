@@ -0,0 +1,193 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewJiraReloadsConfig(t *testing.T) {
+	home := t.TempDir()
+	setHome(t, home)
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+	session.config.reset()
+	session.data.reset()
+	session.jira.reset()
+	t.Cleanup(session.jira.reset)
+
+	if _, err := NewJira(); err == nil {
+		t.Fatal("got no error, want missing configuration")
+	}
+
+	// the cached user avoids asking Jira for the current user
+	data := NewData()
+	data.User = User{DisplayName: "Alice", FetchedAt: time.Now(), Endpoint: "https://jira.example.com"}
+	if err := data.WriteFile(); err != nil {
+		t.Fatal(err)
+	}
+	path := Config{}.filepath()
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	for _, project := range []string{"KONG", "GORILLA"} {
+		content := "endpoint: https://jira.example.com\nproject: " + project + "\n"
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		j, err := NewJira()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if j.config.Project != project {
+			t.Errorf("got %v, want: %v", j.config.Project, project)
+		}
+	}
+}
+
+func TestRefreshSelf(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+	session.data.reset()
+	var requests int
+	client := newTestJira(t, func(req *http.Request) (*http.Response, error) {
+		requests++
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"accountId": "1", "displayName": "Bob"}`)),
+		}, nil
+	})
+	config := Config{Endpoint: "https://jira.example.com", Username: "bob"}
+	now := time.Now()
+
+	j := Jira{client: client, config: config, self: User{DisplayName: "Bob", FetchedAt: now}}
+	if _, err := j.refreshSelf(now); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 0 {
+		t.Errorf("got %d requests, want: 0", requests)
+	}
+
+	j.self.FetchedAt = now.Add(-userExpiry - time.Second)
+	j, err := j.refreshSelf(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 1 {
+		t.Errorf("got %d requests, want: 1", requests)
+	}
+	if !j.self.FetchedAt.Equal(now) || !j.self.fetchedFor(config) {
+		t.Errorf("got %+v, want user fetched now for %s", j.self, config.Username)
+	}
+
+	// the cached user of another account is not used
+	data := NewData()
+	data.User = User{DisplayName: "Alice", FetchedAt: now, Endpoint: config.Endpoint, Username: "alice"}
+	if err := data.WriteFile(); err != nil {
+		t.Fatal(err)
+	}
+	self, err := currentUser(client, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if self.DisplayName != "Bob" {
+		t.Errorf("got %v, want: %v", self.DisplayName, "Bob")
+	}
+}
+
+func TestSetEpic(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+	var got []map[string]any
+	client := newTestJira(t, func(req *http.Request) (*http.Response, error) {
+		var body struct {
+			Fields map[string]any `json:"fields"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		got = append(got, body.Fields)
+		return &http.Response{
+			StatusCode: http.StatusNoContent,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}, nil
+	})
+	j := Jira{
+		client: client,
+		config: Config{CustomFields: CustomFields{Epics: "customfield_10008"}},
+	}
+
+	ctx := context.Background()
+	if err := j.SetEpic(ctx, "KONG-1", "KONG-10"); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.SetEpic(ctx, "KONG-1", ""); err != nil {
+		t.Fatal(err)
+	}
+	want := []map[string]any{
+		{"customfield_10008": "KONG-10"},
+		{"customfield_10008": nil},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+
+	// without the epic field the issue would be updated with an empty field
+	j.config.CustomFields.Epics = ""
+	if err := j.SetEpic(ctx, "KONG-1", "KONG-10"); !errors.Is(err, errConfigEpicField) {
+		t.Errorf("got %v, want: %v", err, errConfigEpicField)
+	}
+	if len(got) != 2 {
+		t.Errorf("got %d requests, want: %d", len(got), 2)
+	}
+}
+
+func TestListIssuesByKey(t *testing.T) {
+	var queries []string
+	client := newTestJira(t, func(req *http.Request) (*http.Response, error) {
+		if got := req.URL.Query().Get("validateQuery"); got != "warn" {
+			t.Errorf("got %q, want: %q", got, "warn")
+		}
+		queries = append(queries, req.URL.Query().Get("jql"))
+
+		// unknown keys are left out with a warning
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(strings.NewReader(`{
+				"issues": [{
+					"key": "KONG-1",
+					"fields": {"summary": "Mirror statuses", "status": {"name": "Done"}, "priority": {"name": "High"}},
+					"transitions": [{"id": "1", "name": "Done", "to": {"name": "Done"}}]
+				}],
+				"total": 1,
+				"warningMessages": ["The issue key 'KONG-2' for field 'key' is invalid."]
+			}`)),
+		}, nil
+	})
+	j := Jira{client: client}
+	keys := make([]string, maxJQLKeys+1)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("KONG-%d", i+1)
+	}
+	issues, err := j.ListIssuesByKey(context.Background(), keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"key IN (" + strings.Join(keys[:maxJQLKeys], ",") + ")",
+		"key IN (" + keys[maxJQLKeys] + ")",
+	}
+	if diff := cmp.Diff(queries, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	if len(issues) != 2 {
+		t.Errorf("got %d issues, want: %d", len(issues), 2)
+	}
+}
@@ -0,0 +1,58 @@
+package kong
+
+import (
+	"strings"
+)
+
+// Names of the JQL templates of the configuration.
+const (
+	jqlIssues       = "issuesJQL"
+	jqlEpics        = "epicsJQL"
+	jqlSprintIssues = "sprintIssuesJQL"
+)
+
+// jqlData is the data the JQL templates of the configuration are executed
+// with. Projects lists the projects of the sprint board separated by commas.
+type jqlData struct {
+	Project  string
+	Projects string
+	User     string
+}
+
+// jqlTemplates returns the configured JQL templates by name.
+func (c Config) jqlTemplates() map[string]string {
+	return map[string]string{
+		jqlIssues:       c.IssuesJQL,
+		jqlEpics:        c.EpicsJQL,
+		jqlSprintIssues: c.SprintIssuesJQL,
+	}
+}
+
+// validateJQLTemplates parses the configured JQL templates.
+func (c Config) validateJQLTemplates() error {
+	for name, text := range c.jqlTemplates() {
+		if text == "" {
+			continue
+		}
+		if _, err := parseTemplate(name, text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jql returns the query of the JQL template of the given name for the
+// project and user. It returns the default query if the template is not
+// configured.
+func (c Config) jql(name, project, user, defaultJQL string) (string, error) {
+	text := c.jqlTemplates()[name]
+	if text == "" {
+		return defaultJQL, nil
+	}
+	data := jqlData{
+		Project:  project,
+		Projects: strings.Join(c.BoardProjects(), ", "),
+		User:     user,
+	}
+	return executeTemplate(name, text, data)
+}
@@ -0,0 +1,66 @@
+package kong
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestJQL(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   string
+	}{
+		{
+			name:   "default",
+			config: Config{Project: "KONG"},
+			want:   "project = KONG",
+		},
+		{
+			name: "template",
+			config: Config{
+				Project:   "KONG",
+				IssuesJQL: `project = {{.Project}} AND assignee = "{{.User}}" AND status != Released ORDER BY rank`,
+			},
+			want: `project = OTHER AND assignee = "Alice" AND status != Released ORDER BY rank`,
+		},
+		{
+			name: "board-projects",
+			config: Config{
+				Project:       "KONG",
+				boardProjects: []string{"KONG", "APE"},
+				IssuesJQL:     "project IN ({{.Projects}})",
+			},
+			want: "project IN (KONG, APE)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.jql(jqlIssues, "OTHER", "Alice", "project = KONG")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateJQLTemplates(t *testing.T) {
+	tests := []struct {
+		config Config
+		err    bool
+	}{
+		{config: Config{}},
+		{config: Config{EpicsJQL: "project = {{.Project}}"}},
+		{config: Config{SprintIssuesJQL: "project = {{.Project"}, err: true},
+	}
+	for _, tt := range tests {
+		err := tt.config.validateJQLTemplates()
+		var templateErr *TemplateError
+		if tt.err != errors.As(err, &templateErr) {
+			t.Errorf("got %v, want template error: %v", err, tt.err)
+		}
+	}
+}
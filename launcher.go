@@ -0,0 +1,128 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Output formats of keyboard launchers.
+const (
+	LauncherAlfred = "alfred"
+	LauncherRofi   = "rofi"
+)
+
+// Actions performed on issues selected in a launcher.
+const (
+	LauncherOpen  = "open"
+	LauncherStart = "start"
+)
+
+// startStatus is the status issues are moved to by the start action.
+const startStatus = "In Progress"
+
+// rofiCustomKey is the return value rofi sets in script mode when an entry
+// was selected with the first custom key binding, Alt+1 by default.
+const rofiCustomKey = "10"
+
+var (
+	errUnknownLauncher       = errors.New("unknown launcher format, expected alfred or rofi")
+	errUnknownLauncherAction = errors.New("unknown launcher action, expected open or start")
+)
+
+type alfredItems struct {
+	Items []alfredItem `json:"items"`
+}
+
+type alfredItem struct {
+	UID      string               `json:"uid"`
+	Title    string               `json:"title"`
+	Subtitle string               `json:"subtitle"`
+	Arg      string               `json:"arg"`
+	Mods     map[string]alfredMod `json:"mods"`
+}
+
+type alfredMod struct {
+	Arg      string `json:"arg"`
+	Subtitle string `json:"subtitle"`
+}
+
+// PrintLauncher writes the issues as Alfred script filter JSON or as rofi
+// script mode entries. Selecting an issue opens it in the browser, holding
+// cmd in Alfred or pressing Alt+1 in rofi moves it to In Progress instead.
+func PrintLauncher(output io.Writer, format string, issues Issues) error {
+	switch format {
+	case LauncherAlfred:
+		items := alfredItems{Items: make([]alfredItem, len(issues))}
+		for i, issue := range issues.Sort() {
+			items.Items[i] = alfredItem{
+				UID:      issue.Key,
+				Title:    issue.Key + " " + issue.Summary,
+				Subtitle: issue.Status.Name,
+				Arg:      LauncherOpen + " " + issue.Key,
+				Mods: map[string]alfredMod{
+					"cmd": {
+						Arg:      LauncherStart + " " + issue.Key,
+						Subtitle: "Move to " + startStatus,
+					},
+				},
+			}
+		}
+		return json.NewEncoder(output).Encode(items)
+	case LauncherRofi:
+		var b strings.Builder
+		b.WriteString("\x00prompt\x1fkong\n")
+		b.WriteString("\x00message\x1fEnter: open in browser, Alt+1: move to " + startStatus + "\n")
+		b.WriteString("\x00use-hot-keys\x1ftrue\n")
+		for _, issue := range issues.Sort() {
+			fmt.Fprintf(&b, "%s - %s - %s\x00info\x1f%s\n", issue.Key, issue.Status.Name, issue.Summary, issue.Key)
+		}
+		_, err := io.WriteString(output, b.String())
+		return err
+	}
+	return fmt.Errorf("%w: %s", errUnknownLauncher, format)
+}
+
+// RofiSelection returns the action and issue key if rofi called Kong in
+// script mode after an entry was selected.
+func RofiSelection() (action, key string, ok bool) {
+	key = os.Getenv("ROFI_INFO")
+	if key == "" {
+		return "", "", false
+	}
+	if os.Getenv("ROFI_RETV") == rofiCustomKey {
+		return LauncherStart, key, true
+	}
+	return LauncherOpen, key, true
+}
+
+// RunLauncherAction opens the issue in the browser or moves it to In Progress.
+func (j Jira) RunLauncherAction(ctx context.Context, data Data, action, key string) error {
+	switch action {
+	case LauncherOpen:
+		return openURL(ctx, j.BrowseURL(key))
+	case LauncherStart:
+		issue, ok := data.SprintIssues.find(key)
+		if !ok {
+			issue, ok = data.IssueByKey[key]
+		}
+		if !ok {
+			return fmt.Errorf("%w: %s", errUnknownIssue, key)
+		}
+		transition, ok := issue.TransitionByName(startStatus)
+		if !ok {
+			return fmt.Errorf("%w: %s", errUnknownTransition, startStatus)
+		}
+		return j.TransitionIssues(ctx, []issueTransition{
+			{
+				issueKey:   issue.Key,
+				transition: transition,
+			},
+		})
+	}
+	return fmt.Errorf("%w: %s", errUnknownLauncherAction, action)
+}
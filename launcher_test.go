@@ -0,0 +1,56 @@
+package kong
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPrintLauncher(t *testing.T) {
+	issues := Issues{{Key: "KONG-1", Summary: "Add launcher", Status: Status{Name: "To Do"}}}
+	tests := []struct {
+		format  string
+		want    string
+		wantErr error
+	}{
+		{
+			format: LauncherAlfred,
+			want: `{"items":[{"uid":"KONG-1","title":"KONG-1 Add launcher","subtitle":"To Do","arg":"open KONG-1",` +
+				`"mods":{"cmd":{"arg":"start KONG-1","subtitle":"Move to In Progress"}}}]}` + "\n",
+		},
+		{
+			format: LauncherRofi,
+			want: "\x00prompt\x1fkong\n" +
+				"\x00message\x1fEnter: open in browser, Alt+1: move to In Progress\n" +
+				"\x00use-hot-keys\x1ftrue\n" +
+				"KONG-1 - To Do - Add launcher\x00info\x1fKONG-1\n",
+		},
+		{
+			format:  "dmenu",
+			wantErr: errUnknownLauncher,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := PrintLauncher(&buf, tt.format, issues)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want: %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(buf.String(), tt.want); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRofiSelection(t *testing.T) {
+	t.Setenv("ROFI_INFO", "KONG-1")
+	t.Setenv("ROFI_RETV", rofiCustomKey)
+	action, key, ok := RofiSelection()
+	if !ok || action != LauncherStart || key != "KONG-1" {
+		t.Errorf("got %v %v %v, want: %v %v %v", action, key, ok, LauncherStart, "KONG-1", true)
+	}
+}
@@ -0,0 +1,57 @@
+package kong
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+// lintIssues checks the issues against the configured lint rules and returns
+// a warning for every violation.
+func (l Lint) lintIssues(issues []*jira.Issue, storyPointsField string) []string {
+	var warnings []string
+	for _, issue := range issues {
+		for _, warning := range l.lintIssue(issue, storyPointsField) {
+			warnings = append(warnings, fmt.Sprintf("%q: %s", issue.Fields.Summary, warning))
+		}
+	}
+	return warnings
+}
+
+func (l Lint) lintIssue(issue *jira.Issue, storyPointsField string) []string {
+	var warnings []string
+	summary := issue.Fields.Summary
+	if l.MaxSummaryLength > 0 && len(summary) > l.MaxSummaryLength {
+		warnings = append(warnings, fmt.Sprintf("summary exceeds %d characters", l.MaxSummaryLength))
+	}
+	if l.RequireDescription && strings.TrimSpace(issue.Fields.Description) == "" {
+		warnings = append(warnings, "description is empty")
+	}
+	if l.RequireStoryPoints {
+		if points, _ := issue.Fields.Unknowns[storyPointsField].(float64); points == 0 {
+			warnings = append(warnings, "story points are missing")
+		}
+	}
+	text := strings.ToLower(summary + " " + issue.Fields.Description)
+	for _, word := range l.BannedWords {
+		if word != "" && strings.Contains(text, strings.ToLower(word)) {
+			warnings = append(warnings, fmt.Sprintf("contains banned word %q", word))
+		}
+	}
+	return warnings
+}
+
+// confirmLint prints all lint warnings and asks the user whether to proceed.
+// It returns true if there are no warnings.
+func (e Editor) confirmLint(issues []*jira.Issue) (bool, error) {
+	warnings := e.config.Lint.lintIssues(issues, e.config.CustomFields.StoryPoints)
+	if len(warnings) == 0 {
+		return true, nil
+	}
+	fmt.Println("Warnings:")
+	for _, warning := range warnings {
+		fmt.Println("  " + warning)
+	}
+	return e.confirm("Create issues anyway?")
+}
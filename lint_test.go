@@ -0,0 +1,77 @@
+package kong
+
+import (
+	"testing"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLintIssue(t *testing.T) {
+	tests := []struct {
+		name  string
+		lint  Lint
+		issue *jira.Issue
+		want  []string
+	}{
+		{
+			name: "disabled",
+			issue: &jira.Issue{
+				Fields: &jira.IssueFields{
+					Summary: "Add command to list issues",
+				},
+			},
+		},
+		{
+			name: "summary-too-long",
+			lint: Lint{
+				MaxSummaryLength: 10,
+			},
+			issue: &jira.Issue{
+				Fields: &jira.IssueFields{
+					Summary: "Add command to list issues",
+				},
+			},
+			want: []string{"summary exceeds 10 characters"},
+		},
+		{
+			name: "missing-description-and-story-points",
+			lint: Lint{
+				RequireDescription: true,
+				RequireStoryPoints: true,
+			},
+			issue: &jira.Issue{
+				Fields: &jira.IssueFields{
+					Summary:     "Add command to list issues",
+					Description: " ",
+					Unknowns: map[string]any{
+						"storyPoints": 0.0,
+					},
+				},
+			},
+			want: []string{"description is empty", "story points are missing"},
+		},
+		{
+			name: "banned-word",
+			lint: Lint{
+				BannedWords: []string{"TODO"},
+			},
+			issue: &jira.Issue{
+				Fields: &jira.IssueFields{
+					Summary:     "Fix the thing",
+					Description: "todo: describe",
+				},
+			},
+			want: []string{`contains banned word "TODO"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.lint.lintIssue(tt.issue, "storyPoints")
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
@@ -3,7 +3,9 @@ package kong
 import (
 	"errors"
 	"log"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 	"unicode"
@@ -33,12 +35,26 @@ type Sprints []Sprint
 type Issue struct {
 	Key                     string                `yaml:"-"`
 	Summary                 string                `yaml:"summary"`
+	Description             string                `yaml:"-"`
+	AcceptanceCriteria      string                `yaml:"-"`
 	Priority                string                `yaml:"-"`
 	Status                  Status                `yaml:"-"`
 	Transitions             []Transition          `yaml:"-"`
 	TransitionsByAcronym    map[string]Transition `yaml:"-"`
 	OrderByTransitionStatus map[string]int        `yaml:"-"`
 	SprintID                int                   `yaml:"sprintID"`
+	StoryPoints             float64               `yaml:"-"`
+	Comments                int                   `yaml:"-"`
+	EpicKey                 string                `yaml:"-"`
+	OriginalEstimate        string                `yaml:"-"`
+	RemainingEstimate       string                `yaml:"-"`
+	EpicColor               string                `yaml:"-"`
+	EpicStatus              string                `yaml:"-"`
+	Fields                  map[string]any        `yaml:"-"`
+	Labels                  []string              `yaml:"-"`
+	Reporter                string                `yaml:"-"`
+	Created                 time.Time             `yaml:"-"`
+	Updated                 time.Time             `yaml:"-"`
 }
 
 // Transition is a Jira transition abstraction. The type primarily exists to
@@ -60,15 +76,66 @@ type Status struct {
 // Sprint is a Jira sprint abstraction.  The type primarily exists to only
 // serialize a subset of the data to disk.
 type Sprint struct {
-	ID      int
-	Name    string
-	State   string
-	EndDate time.Time
+	ID        int
+	Name      string
+	State     string
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// User is a Jira user abstraction. The type primarily exists to cache the
+// current user on disk.
+type User struct {
+	AccountID   string
+	Name        string
+	DisplayName string
+	FetchedAt   time.Time
+
+	// Endpoint and Username identify the account the user was fetched for
+	Endpoint string
+	Username string
+}
+
+// NewUser returns a new instance of User by converting jira.User to User.
+func NewUser(user *jira.User) User {
+	if user == nil {
+		return User{}
+	}
+	return User{
+		AccountID:   user.AccountID,
+		Name:        user.Name,
+		DisplayName: user.DisplayName,
+	}
+}
+
+// Expired reports whether the user needs to be fetched again from Jira.
+func (u User) Expired(now time.Time) bool {
+	if u.DisplayName == "" {
+		return true
+	}
+	return u.FetchedAt.Before(now.Add(-userExpiry))
+}
+
+// fetchedFor reports whether the user was fetched for the account of the
+// configuration.
+func (u User) fetchedFor(config Config) bool {
+	return u.Endpoint == config.Endpoint && u.Username == config.Username
+}
+
+func (u User) jiraUser() *jira.User {
+	return &jira.User{
+		AccountID:   u.AccountID,
+		Name:        u.Name,
+		DisplayName: u.DisplayName,
+	}
 }
 
 // NewIssues returns a new instance of Issues by converting jira.Issue to
-// Issue.
-func NewIssues(jiraIssues []jira.Issue, customFields CustomFields) (Issues, error) {
+// Issue. The configured status aliases map user-defined acronyms to status
+// names and take precedence over generated acronyms. If done statuses are
+// configured, they decide which issues are done instead of the Jira status
+// category. Custom fields are decoded according to their mapping.
+func NewIssues(jiraIssues []jira.Issue, config Config) (Issues, error) {
 	result := make(Issues, 0, len(jiraIssues))
 	transitions := make([]Transition, 0)
 	transitionsByAcronym := make(map[string]Transition)
@@ -84,7 +151,7 @@ func NewIssues(jiraIssues []jira.Issue, customFields CustomFields) (Issues, erro
 
 		// only initialize list of transitions once
 		if len(transitions) == 0 {
-			acronyms = statusAcronyms(jiraIssue.Transitions)
+			acronyms = statusAcronyms(jiraIssue.Transitions, config.StatusAliases)
 			transitions = make([]Transition, len(jiraIssue.Transitions))
 			for j, transition := range jiraIssue.Transitions {
 				acronym := acronyms[transition.Name]
@@ -105,33 +172,75 @@ func NewIssues(jiraIssues []jira.Issue, customFields CustomFields) (Issues, erro
 		issue.TransitionsByAcronym = transitionsByAcronym
 		issue.OrderByTransitionStatus = orderByTransitionStatus
 		issue.Status.Acronym = acronyms[issue.Status.Name]
+		issue.Status = issue.Status.withDoneStatuses(config.DoneStatuses)
 
-		// set sprint
-		if jiraIssue.Fields.Unknowns[customFields.Sprints] != nil {
-			sprints := jiraIssue.Fields.Unknowns[customFields.Sprints].([]interface{})
-			for _, item := range sprints {
-				sprint := item.(map[string]interface{})
-				if sprint["state"] == "active" {
-					issue.SprintID = int(sprint["id"].(float64))
-					break
+		// count comments to detect new comments between syncs
+		if jiraIssue.Fields.Comments != nil {
+			issue.Comments = len(jiraIssue.Fields.Comments.Comments)
+		}
+
+		// set mapped custom fields if configured, which may differ between
+		// the projects of the sprint board
+		projectConfig := config.forProject(jiraIssue.Fields.Project.Key)
+		fields := projectConfig.decodeFields(jiraIssue.Fields.Unknowns)
+		issue.StoryPoints, _ = fields[fieldStoryPoints].(float64)
+		issue.EpicKey, _ = fields[fieldEpic].(string)
+		issue.EpicColor, _ = fields[fieldEpicColor].(string)
+		issue.EpicStatus, _ = fields[fieldEpicStatus].(string)
+		issue.AcceptanceCriteria, _ = fields[fieldAcceptanceCriteria].(string)
+		for name := range config.Fields {
+			if value, ok := fields[name]; ok {
+				if issue.Fields == nil {
+					issue.Fields = make(map[string]any)
 				}
+				issue.Fields[name] = value
 			}
 		}
+
+		issue.SprintID = activeSprintID(jiraIssue.Fields.Unknowns[projectConfig.CustomFields.Sprints])
 		result = append(result, issue)
 	}
 	return result, nil
 }
 
+// activeSprintID returns the ID of the active sprint of the value of the sprint
+// field, or zero if the issue is in no active sprint or the field does not
+// hold sprints, for instance because it is not configured correctly.
+func activeSprintID(value any) int {
+	sprints, _ := value.([]interface{})
+	for _, item := range sprints {
+		sprint, ok := item.(map[string]interface{})
+		if !ok || sprint["state"] != "active" {
+			continue
+		}
+		if id, ok := sprint["id"].(float64); ok {
+			return int(id)
+		}
+	}
+	return 0
+}
+
 // NewIssue returns a new instance of Issue by converting jira.Issue to Issue.
 func NewIssue(issue jira.Issue) (Issue, error) {
 	if err := validateJiraIssue(issue); err != nil {
 		return Issue{}, err
 	}
 	result := Issue{
-		Key:      issue.Key,
-		Summary:  issue.Fields.Summary,
-		Priority: issue.Fields.Priority.Name,
-		Status:   NewStatus(issue),
+		Key:         issue.Key,
+		Summary:     issue.Fields.Summary,
+		Description: issue.Fields.Description,
+		Priority:    issue.Fields.Priority.Name,
+		Status:      NewStatus(issue),
+		Labels:      issue.Fields.Labels,
+		Created:     time.Time(issue.Fields.Created),
+		Updated:     time.Time(issue.Fields.Updated),
+	}
+	if issue.Fields.Reporter != nil {
+		result.Reporter = issue.Fields.Reporter.DisplayName
+	}
+	if issue.Fields.TimeTracking != nil {
+		result.OriginalEstimate = issue.Fields.TimeTracking.OriginalEstimate
+		result.RemainingEstimate = issue.Fields.TimeTracking.RemainingEstimate
 	}
 	return result, nil
 }
@@ -158,6 +267,24 @@ func validateJiraIssue(issue jira.Issue) error {
 	return nil
 }
 
+// Age returns the number of full days since the issue was created or 0 if the
+// creation date is unknown.
+func (i Issue) Age(now time.Time) float64 {
+	if i.Created.IsZero() {
+		return 0
+	}
+	return math.Floor(now.Sub(i.Created).Hours() / 24)
+}
+
+// StatusName returns the epic status if it is set and otherwise the name of
+// the workflow status.
+func (i Issue) StatusName() string {
+	if i.EpicStatus != "" {
+		return i.EpicStatus
+	}
+	return i.Status.Name
+}
+
 // NewStatus returns a new instance of Status by converting the status field of
 // jira.Issue.
 func NewStatus(issue jira.Issue) Status {
@@ -173,6 +300,16 @@ func NewStatus(issue jira.Issue) Status {
 	}
 }
 
+// withDoneStatuses returns the status marked as done if its name is one of
+// the given done statuses. Without done statuses the status is unchanged.
+func (s Status) withDoneStatuses(doneStatuses []string) Status {
+	if len(doneStatuses) == 0 {
+		return s
+	}
+	s.IsDone = contains(doneStatuses, s.Name)
+	return s
+}
+
 // NewSprints returns a new instance of Sprints by converting jira.Sprint to
 // Sprint.
 func NewSprints(sprints []jira.Sprint) Sprints {
@@ -191,6 +328,9 @@ func NewSprint(sprint jira.Sprint) Sprint {
 		Name:  sprint.Name,
 		State: sprint.State,
 	}
+	if sprint.StartDate != nil {
+		s.StartDate = *sprint.StartDate
+	}
 	if sprint.EndDate != nil {
 		s.EndDate = *sprint.EndDate
 	}
@@ -208,6 +348,42 @@ func (s Sprints) ActiveSprint() (Sprint, error) {
 	return Sprint{}, ErrNoActiveSprint
 }
 
+// NextSprint returns the first future sprint or an error if there is no
+// future sprint.
+func (s Sprints) NextSprint() (Sprint, error) {
+	for _, sprint := range s {
+		if sprint.State == "future" {
+			return sprint, nil
+		}
+	}
+	return Sprint{}, ErrNoFutureSprint
+}
+
+// WithoutDone returns the issues which are not done.
+func (i Issues) WithoutDone() Issues {
+	result := make(Issues, 0, len(i))
+	for _, issue := range i {
+		if !issue.Status.IsDone {
+			result = append(result, issue)
+		}
+	}
+	return result
+}
+
+func (i Issues) contains(key string) bool {
+	_, ok := i.find(key)
+	return ok
+}
+
+func (i Issues) find(key string) (Issue, bool) {
+	for _, issue := range i {
+		if issue.Key == key {
+			return issue, true
+		}
+	}
+	return Issue{}, false
+}
+
 // Transitions returns a list of transitions from one of the issues since each
 // issue should have the same set of transitions.
 func (i Issues) Transitions() []Transition {
@@ -217,6 +393,26 @@ func (i Issues) Transitions() []Transition {
 	return i[0].Transitions
 }
 
+// DoneTransition returns the transition which closes the issue.
+func (i Issue) DoneTransition() (Transition, bool) {
+	for _, name := range []string{"Closed", "Done"} {
+		if t, ok := i.TransitionByName(name); ok {
+			return t, true
+		}
+	}
+	return Transition{}, false
+}
+
+// TransitionByName returns the transition into the status of the given name.
+func (i Issue) TransitionByName(name string) (Transition, bool) {
+	for _, t := range i.Transitions {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Transition{}, false
+}
+
 func (i Issues) Sort() Issues {
 	sort.Slice(i, func(a, b int) bool {
 		return i[a].OrderByTransitionStatus[i[a].Status.Name] <
@@ -226,25 +422,62 @@ func (i Issues) Sort() Issues {
 }
 
 // order by implicit transition status order returned from the Jira API
-func statusAcronyms(transitions []jira.Transition) map[string]string {
+func statusAcronyms(transitions []jira.Transition, aliases map[string]string) map[string]string {
 	acronymByTransition := make(map[string]string, len(transitions))
 	acronyms := make(map[string]struct{}, len(transitions))
+
+	// reserve configured aliases before generating acronyms to avoid collisions
+	for alias, status := range aliases {
+		acronymByTransition[status] = alias
+		acronyms[alias] = struct{}{}
+	}
 	for _, transition := range transitions {
+		if _, ok := acronymByTransition[transition.To.Name]; ok {
+			continue
+		}
+		acronym := uniqueAcronym(transition.To.Name, acronyms)
+		acronyms[acronym] = struct{}{}
+		acronymByTransition[transition.To.Name] = acronym
+	}
+	return acronymByTransition
+}
+
+// uniqueAcronym returns the first letters of the words of the status, moving
+// on to later letters of words which are long enough while the acronym is
+// taken. Once no word has further letters a numeric suffix is added.
+func uniqueAcronym(status string, acronyms map[string]struct{}) string {
+	words := strings.Fields(status)
+	acronym := func(n int) (string, bool) {
 		var (
-			s strings.Builder
-			n int
+			s    strings.Builder
+			more bool
 		)
-		for {
-			for _, word := range strings.Split(transition.To.Name, " ") {
-				s.WriteRune(unicode.ToLower(rune(word[n])))
-			}
-			if _, ok := acronyms[s.String()]; !ok {
-				acronyms[s.String()] = struct{}{}
-				acronymByTransition[transition.To.Name] = s.String()
-				break
+		for _, word := range words {
+			letters := []rune(word)
+			i := n
+			if i >= len(letters) {
+				i = len(letters) - 1
+			} else {
+				more = true
 			}
-			n++
+			s.WriteRune(unicode.ToLower(letters[i]))
+		}
+		return s.String(), more
+	}
+	for n := 0; ; n++ {
+		s, more := acronym(n)
+		if !more {
+			break
+		}
+		if _, ok := acronyms[s]; !ok {
+			return s
+		}
+	}
+	base, _ := acronym(0)
+	for i := 2; ; i++ {
+		s := base + strconv.Itoa(i)
+		if _, ok := acronyms[s]; !ok {
+			return s
 		}
 	}
-	return acronymByTransition
 }
@@ -1,6 +1,7 @@
 package kong
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
@@ -47,3 +48,304 @@ func TestNewSprint(t *testing.T) {
 		}
 	})
 }
+
+func TestActiveSprintID(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  int
+	}{
+		{
+			name: "active",
+			value: []interface{}{
+				map[string]interface{}{"id": 1.0, "state": "closed"},
+				map[string]interface{}{"id": 2.0, "state": "active"},
+			},
+			want: 2,
+		},
+		{
+			name:  "none",
+			value: []interface{}{map[string]interface{}{"id": 1.0, "state": "closed"}},
+		},
+		{
+			name: "missing",
+		},
+		{
+			name:  "misconfigured",
+			value: "Sprint 1",
+		},
+		{
+			name:  "unexpected sprint",
+			value: []interface{}{"Sprint 1", map[string]interface{}{"state": "active"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := activeSprintID(tt.value); got != tt.want {
+				t.Errorf("got %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusAcronyms(t *testing.T) {
+	transitions := []jira.Transition{
+		{To: jira.Status{Name: "To Do"}},
+		{To: jira.Status{Name: "In Progress"}},
+		{To: jira.Status{Name: "In Review"}},
+		{To: jira.Status{Name: "Done"}},
+	}
+
+	t.Run("generated", func(t *testing.T) {
+		got := statusAcronyms(transitions, nil)
+		want := map[string]string{
+			"To Do":       "td",
+			"In Progress": "ip",
+			"In Review":   "ir",
+			"Done":        "d",
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("diff: %s", diff)
+		}
+	})
+
+	t.Run("with-aliases", func(t *testing.T) {
+		aliases := map[string]string{
+			"r":  "In Review",
+			"ip": "Done",
+		}
+		got := statusAcronyms(transitions, aliases)
+		want := map[string]string{
+			"To Do":       "td",
+			"In Progress": "nr",
+			"In Review":   "r",
+			"Done":        "ip",
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("diff: %s", diff)
+		}
+	})
+
+	// statuses shorter than the taken acronyms run out of letters
+	collisions := []struct {
+		name     string
+		statuses []string
+		aliases  map[string]string
+		want     map[string]string
+	}{
+		{
+			name:     "later-letter",
+			statuses: []string{"Do"},
+			aliases:  map[string]string{"d": "Done"},
+			want:     map[string]string{"Do": "o", "Done": "d"},
+		},
+		{
+			name:     "numeric-suffix",
+			statuses: []string{"D"},
+			aliases:  map[string]string{"d": "Done"},
+			want:     map[string]string{"D": "d2", "Done": "d"},
+		},
+		{
+			name:     "short-word",
+			statuses: []string{"To Do", "To Dos", "T D"},
+			want:     map[string]string{"To Do": "td", "To Dos": "oo", "T D": "td2"},
+		},
+	}
+	for _, tt := range collisions {
+		t.Run(tt.name, func(t *testing.T) {
+			var transitions []jira.Transition
+			for _, status := range tt.statuses {
+				transitions = append(transitions, jira.Transition{To: jira.Status{Name: status}})
+			}
+			got := statusAcronyms(transitions, tt.aliases)
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestUserExpired(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		user User
+		want bool
+	}{
+		{
+			name: "missing",
+			user: User{},
+			want: true,
+		},
+		{
+			name: "fresh",
+			user: User{DisplayName: "Jane Doe", FetchedAt: now.Add(-time.Hour)},
+			want: false,
+		},
+		{
+			name: "expired",
+			user: User{DisplayName: "Jane Doe", FetchedAt: now.Add(-userExpiry - time.Second)},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.user.Expired(now); got != tt.want {
+				t.Errorf("got %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusWithDoneStatuses(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       Status
+		doneStatuses []string
+		want         bool
+	}{
+		{
+			name:   "status-category",
+			status: Status{Name: "Ready for Release", IsDone: true},
+			want:   true,
+		},
+		{
+			name:         "not-configured-as-done",
+			status:       Status{Name: "Ready for Release", IsDone: true},
+			doneStatuses: []string{"Done", "Released"},
+			want:         false,
+		},
+		{
+			name:         "configured-as-done",
+			status:       Status{Name: "Released"},
+			doneStatuses: []string{"Done", "Released"},
+			want:         true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.withDoneStatuses(tt.doneStatuses).IsDone; got != tt.want {
+				t.Errorf("got %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIssuesSummary(t *testing.T) {
+	issues := Issues{
+		{Status: Status{Name: "To Do"}, StoryPoints: 3},
+		{Status: Status{Name: "Done"}, StoryPoints: 5},
+		{Status: Status{Name: "To Do"}, StoryPoints: 0.5},
+	}
+	got := issues.Summary()
+	want := "3 issues — 2 To Do, 1 Done — 8.5 pts"
+	if got != want {
+		t.Errorf("got %v, want: %v", got, want)
+	}
+	if got, want := (Issues{}).Summary(), "0 issues"; got != want {
+		t.Errorf("got %v, want: %v", got, want)
+	}
+}
+
+func TestIssuesPrintSprintByEpic(t *testing.T) {
+	epics := Issues{{Key: "KONG-1", Summary: "Sprint view"}}
+	issues := Issues{
+		{Key: "KONG-2", Summary: "Group by epic", Status: Status{Name: "To Do"}, EpicKey: "KONG-1", StoryPoints: 3},
+		{Key: "KONG-3", Summary: "Fix typo", Status: Status{Name: "To Do"}, StoryPoints: 1},
+		{Key: "KONG-4", Summary: "Sum points", Status: Status{Name: "Done", IsDone: true}, EpicKey: "KONG-1", StoryPoints: 2},
+		{Key: "KONG-5", Summary: "Show epic", Status: Status{Name: "To Do"}, EpicKey: "KONG-9", StoryPoints: 5},
+	}
+	var buf bytes.Buffer
+	issues.PrintSprintByEpic(&buf, epics, false)
+
+	want := "KONG-1 Sprint view (3 pts)\n" +
+		"  To Do - KONG-2 - Group by epic\n" +
+		"KONG-9 (5 pts)\n" +
+		"  To Do - KONG-5 - Show epic\n" +
+		"No epic (1 pts)\n" +
+		"  To Do - KONG-3 - Fix typo\n"
+	if diff := cmp.Diff(buf.String(), want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestNewIssue(t *testing.T) {
+	created := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	issue, err := NewIssue(jira.Issue{
+		Key: "KONG-1",
+		Fields: &jira.IssueFields{
+			Summary:  "Show reporter",
+			Priority: &jira.Priority{Name: "High"},
+			Status:   &jira.Status{Name: "To Do"},
+			Reporter: &jira.User{DisplayName: "Grace Hopper"},
+			Labels:   []string{"cli"},
+			Created:  jira.Time(created),
+			Updated:  jira.Time(created.Add(time.Hour)),
+		},
+		Transitions: []jira.Transition{{Name: "To Do"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issue.Reporter != "Grace Hopper" || !issue.Created.Equal(created) || !issue.Updated.Equal(created.Add(time.Hour)) {
+		t.Errorf("got %v %v %v, want: Grace Hopper %v %v", issue.Reporter, issue.Created, issue.Updated, created, created.Add(time.Hour))
+	}
+	if diff := cmp.Diff(issue.Labels, []string{"cli"}); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	if got, want := issue.Age(created.AddDate(0, 0, 3).Add(time.Hour)), 3.0; got != want {
+		t.Errorf("got %v, want: %v", got, want)
+	}
+}
+
+func TestIssuePrintDetails(t *testing.T) {
+	issue := Issue{
+		Key:         "KONG-1",
+		Summary:     "Show reporter",
+		Status:      Status{Name: "To Do"},
+		Priority:    "High",
+		Reporter:    "Grace Hopper",
+		Created:     time.Date(2024, 3, 1, 9, 30, 0, 0, time.Local),
+		StoryPoints: 3,
+		Description: "Display the reporter in kong issue view.",
+	}
+	var buf bytes.Buffer
+	issue.PrintDetails(&buf)
+	want := `Key:          KONG-1
+Summary:      Show reporter
+Status:       To Do
+Priority:     High
+Reporter:     Grace Hopper
+Created:      2024-03-01 09:30
+Story Points: 3
+
+Display the reporter in kong issue view.
+`
+	if diff := cmp.Diff(buf.String(), want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func BenchmarkNewIssues(b *testing.B) {
+	f := newFixture(fixtureIssues, fixtureEpics)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := NewIssues(f.issues, f.config); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkIssuesSort(b *testing.B) {
+	issues := newFixture(fixtureIssues, fixtureEpics).data(b).Issues
+	unsorted := make(Issues, len(issues))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		copy(unsorted, issues)
+		b.StartTimer()
+		unsorted.Sort()
+	}
+}
@@ -1,10 +1,16 @@
 package kong
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
 	"text/tabwriter"
+	"time"
 )
 
 // Print formats a list of issues and writes them to stdout.
@@ -16,6 +22,82 @@ func (i Issues) Print(output io.Writer) {
 	w.Flush()
 }
 
+// Summary returns a line counting the issues by status in the order of the
+// transitions and summing up their story points, for instance:
+//
+//	12 issues — 5 To Do, 4 In Progress, 3 Done — 34 pts
+func (i Issues) Summary() string {
+	noun := "issues"
+	if len(i) == 1 {
+		noun = "issue"
+	}
+	var (
+		statuses []string
+		counts   = make(map[string]int)
+		points   float64
+	)
+	for _, issue := range i.Sort() {
+		if _, ok := counts[issue.Status.Name]; !ok {
+			statuses = append(statuses, issue.Status.Name)
+		}
+		counts[issue.Status.Name]++
+		points += issue.StoryPoints
+	}
+	parts := []string{fmt.Sprintf("%d %s", len(i), noun)}
+	if len(statuses) > 0 {
+		byStatus := make([]string, len(statuses))
+		for j, status := range statuses {
+			byStatus[j] = fmt.Sprintf("%d %s", counts[status], status)
+		}
+		parts = append(parts, strings.Join(byStatus, ", "))
+	}
+	if points > 0 {
+		parts = append(parts, formatValue(points)+" pts")
+	}
+	return strings.Join(parts, Plain(" — "))
+}
+
+// PrintDetails writes the fields of the issue followed by its description.
+func (i Issue) PrintDetails(output io.Writer) {
+	w := tabwriter.NewWriter(output, 1, 1, 1, ' ', 0)
+	rows := [][2]string{
+		{"Key", i.Key},
+		{"Summary", i.Summary},
+		{"Status", i.StatusName()},
+		{"Priority", i.Priority},
+		{"Reporter", i.Reporter},
+		{"Created", formatTimestamp(i.Created)},
+		{"Updated", formatTimestamp(i.Updated)},
+		{"Story Points", formatValue(i.StoryPoints)},
+		{"Epic", i.EpicKey},
+		{"Estimate", i.RemainingEstimate},
+	}
+	for _, row := range rows {
+		if row[1] == "" {
+			continue
+		}
+		fmt.Fprintf(w, "%s:\t%s\n", row[0], row[1])
+	}
+	w.Flush()
+	if i.Description != "" {
+		fmt.Fprintf(output, "\n%s\n", i.Description)
+	}
+}
+
+// PrintColumns formats a list of issues in the given order showing the given
+// fields as columns.
+func (i Issues) PrintColumns(output io.Writer, columns []string) {
+	w := tabwriter.NewWriter(output, 1, 1, 1, ' ', 0)
+	for _, issue := range i {
+		values := make([]string, len(columns))
+		for j, column := range columns {
+			values[j] = formatValue(filterFields[column](issue))
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t-\t"))
+	}
+	w.Flush()
+}
+
 // PrintSprint formats a list of issues with sprint status and writes them to stdout.
 func (i Issues) PrintSprint(includeDone bool) {
 	w := tabwriter.NewWriter(os.Stdout, 1, 1, 1, ' ', 0)
@@ -28,15 +110,95 @@ func (i Issues) PrintSprint(includeDone bool) {
 	w.Flush()
 }
 
-// Print formats a list of sprints and writes them to stdout.
-func (s Sprints) Print() {
+// PrintSprintByEpic formats a list of issues with sprint status grouped under
+// their epics, given to look up the epic summaries, and sums up the story
+// points of each epic. Issues without an epic are listed last.
+func (i Issues) PrintSprintByEpic(output io.Writer, epics Issues, includeDone bool) {
+	summaries := make(map[string]string, len(epics))
+	for _, epic := range epics {
+		summaries[epic.Key] = epic.Summary
+	}
+	var (
+		keys         []string
+		byEpic       = make(map[string]Issues)
+		noEpic       Issues
+		points       = make(map[string]float64)
+		noEpicPoints float64
+	)
+	for _, issue := range i.Sort() {
+		if issue.Status.IsDone && !includeDone {
+			continue
+		}
+		if issue.EpicKey == "" {
+			noEpic = append(noEpic, issue)
+			noEpicPoints += issue.StoryPoints
+			continue
+		}
+		if _, ok := byEpic[issue.EpicKey]; !ok {
+			keys = append(keys, issue.EpicKey)
+		}
+		byEpic[issue.EpicKey] = append(byEpic[issue.EpicKey], issue)
+		points[issue.EpicKey] += issue.StoryPoints
+	}
+
+	w := tabwriter.NewWriter(output, 1, 1, 1, ' ', 0)
+	printGroup := func(header string, points float64, issues Issues) {
+		fmt.Fprintf(w, "%s (%s pts)\n", header, formatValue(points))
+		for _, issue := range issues {
+			fmt.Fprintf(w, "  %s\t-\t%s\t-\t%s\n", issue.Status.Name, issue.Key, issue.Summary)
+		}
+	}
+	for _, key := range keys {
+		header := key
+		if summary, ok := summaries[key]; ok {
+			header += " " + summary
+		}
+		printGroup(header, points[key], byEpic[key])
+	}
+	if len(noEpic) > 0 {
+		printGroup("No epic", noEpicPoints, noEpic)
+	}
+	w.Flush()
+}
+
+// Print formats a list of sprints and writes them to stdout. The remaining
+// days of the active sprint are computed based on the given calendar.
+func (s Sprints) Print(calendar Calendar) {
 	w := tabwriter.NewWriter(os.Stdout, 1, 1, 1, ' ', 0)
 	for _, sprint := range s {
 		endDate := "N/A"
 		if !sprint.EndDate.IsZero() {
-			endDate = sprint.EndDate.Local().Format("2006/1/2")
+			endDate = sprint.EndDate.Local().Format("2006/1/2 MST")
+		}
+		if sprint.State == "active" && !sprint.EndDate.IsZero() {
+			days := calendar.DaysRemaining(time.Now(), sprint.EndDate)
+			endDate += fmt.Sprintf(" (%d days left)", days)
 		}
 		fmt.Fprintf(w, "%d\t-\t%s\t-\t%s\n", sprint.ID, endDate, sprint.Name)
 	}
 	w.Flush()
 }
+
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	}
+	return fmt.Sprint(value)
+}
+
+// openURL opens the URL with the default browser of the operating system.
+func openURL(ctx context.Context, url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.CommandContext(ctx, "open", url)
+	case "windows":
+		cmd = exec.CommandContext(ctx, "rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.CommandContext(ctx, "xdg-open", url)
+	}
+	return cmd.Run()
+}
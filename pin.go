@@ -0,0 +1,64 @@
+package kong
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// Pin adds the given issues to the focus list which is shown at the top of
+// issue listings without changing them in Jira.
+func (d *Data) Pin(keys ...string) error {
+	issues := d.snapshot()
+	for _, key := range keys {
+		if _, ok := issues[key]; !ok {
+			return fmt.Errorf("%w: %s", errUnknownIssue, key)
+		}
+	}
+	for _, key := range keys {
+		if !contains(d.Pinned, key) {
+			d.Pinned = append(d.Pinned, key)
+		}
+	}
+	return nil
+}
+
+// Unpin removes the given issues from the focus list.
+func (d *Data) Unpin(keys ...string) {
+	pinned := make([]string, 0, len(d.Pinned))
+	for _, key := range d.Pinned {
+		if !contains(keys, key) {
+			pinned = append(pinned, key)
+		}
+	}
+	d.Pinned = pinned
+}
+
+// PinnedIssues returns the issues of the focus list in the order they were
+// pinned. Issues which are no longer synced are skipped.
+func (d Data) PinnedIssues() Issues {
+	issues := d.snapshot()
+	pinned := make(Issues, 0, len(d.Pinned))
+	for _, key := range d.Pinned {
+		if issue, ok := issues[key]; ok {
+			pinned = append(pinned, issue)
+		}
+	}
+	return pinned
+}
+
+// PrintPinned writes the issues of the focus list followed by an empty line
+// to separate them from the listing below. Nothing is written if no issues
+// are pinned.
+func PrintPinned(output io.Writer, pinned Issues) {
+	if len(pinned) == 0 {
+		return
+	}
+	fmt.Fprintln(output, "Pinned")
+	w := tabwriter.NewWriter(output, 1, 1, 1, ' ', 0)
+	for _, issue := range pinned {
+		fmt.Fprintf(w, "  %s\t-\t%s\t-\t%s\n", issue.Key, issue.Status.Name, issue.Summary)
+	}
+	w.Flush()
+	fmt.Fprintln(output)
+}
@@ -0,0 +1,32 @@
+package kong
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDataPin(t *testing.T) {
+	data := Data{
+		Issues:       Issues{{Key: "KONG-1"}, {Key: "KONG-2"}},
+		SprintIssues: Issues{{Key: "KONG-3"}},
+	}
+	if err := data.Pin("KONG-3", "KONG-1", "KONG-3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := data.Pin("KONG-4"); !errors.Is(err, errUnknownIssue) {
+		t.Errorf("got %v, want: %v", err, errUnknownIssue)
+	}
+	data.Unpin("KONG-1")
+	if err := data.Pin("KONG-2"); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, issue := range data.PinnedIssues() {
+		got = append(got, issue.Key)
+	}
+	if diff := cmp.Diff(got, []string{"KONG-3", "KONG-2"}); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
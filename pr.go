@@ -0,0 +1,93 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+const defaultPullRequestTemplate = `## [{{.Key}}]({{.URL}}) {{.Summary}}
+{{if .Description}}
+{{.Description}}
+{{end}}{{if .AcceptanceCriteria}}
+### Acceptance Criteria
+
+{{.AcceptanceCriteria}}
+{{end}}`
+
+var errIssueKeyMissing = errors.New("issue key missing and not found in branch name")
+
+// PullRequest is the data passed to the pull request template.
+type PullRequest struct {
+	Key                string
+	Summary            string
+	Description        string
+	AcceptanceCriteria string
+	URL                string
+}
+
+// Title returns the pull request title derived from the issue.
+func (p PullRequest) Title() string {
+	return p.Key + ": " + p.Summary
+}
+
+// NewPullRequest fetches the issue with the given key to describe a pull
+// request. If key is empty the issue key is derived from the current branch.
+func (j Jira) NewPullRequest(ctx context.Context, key string) (PullRequest, error) {
+	if key == "" {
+		var err error
+		key, err = j.currentBranchIssueKey(ctx)
+		if err != nil {
+			return PullRequest{}, fmt.Errorf("NewPullRequest: %w", err)
+		}
+	}
+	issues, err := j.ListIssuesByKey(ctx, []string{key})
+	if err != nil {
+		return PullRequest{}, err
+	}
+	if len(issues) == 0 {
+		return PullRequest{}, fmt.Errorf("%w: %s", errUnknownIssue, key)
+	}
+	issue := issues[0]
+	return PullRequest{
+		Key:                issue.Key,
+		Summary:            issue.Summary,
+		Description:        issue.Description,
+		AcceptanceCriteria: issue.AcceptanceCriteria,
+		URL:                j.BrowseURL(issue.Key),
+	}, nil
+}
+
+// Render executes the configured pull request template or a default one.
+func (p PullRequest) Render(text string) (string, error) {
+	if text == "" {
+		text = defaultPullRequestTemplate
+	}
+	return renderTemplate(templateSourcePullRequest, text, p)
+}
+
+// DescribePullRequest renders the pull request body for the given issue and
+// either creates the pull request with the GitHub CLI or prints the body and
+// copies it to the clipboard.
+func (j Jira) DescribePullRequest(ctx context.Context, key string, create bool) error {
+	pr, err := j.NewPullRequest(ctx, key)
+	if err != nil {
+		return err
+	}
+	body, err := pr.Render(j.config.PullRequestTemplate)
+	if err != nil {
+		return err
+	}
+	if create {
+		cmd := exec.CommandContext(ctx, "gh", "pr", "create", "--title", pr.Title(), "--body-file", "-")
+		cmd.Stdin = strings.NewReader(body)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		return cmd.Run()
+	}
+	fmt.Print(body)
+	return copyToClipboard(ctx, j.config.CopyCommand, []byte(body))
+}
@@ -0,0 +1,94 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewPullRequest(t *testing.T) {
+	body := `{"issues": [{
+		"key": "KONG-1",
+		"fields": {
+			"summary": "Add socket",
+			"description": "Serve JSON-RPC",
+			"priority": {"name": "High"},
+			"status": {"name": "In Progress"}
+		},
+		"transitions": [{"id": "1", "name": "Done", "to": {"name": "Done"}}]
+	}], "total": 1}`
+	client := newTestJira(t, func(req *http.Request) (*http.Response, error) {
+		result := body
+		if !strings.Contains(req.URL.Query().Get("jql"), "KONG-1") {
+			result = `{"issues": [], "total": 0}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(result)),
+		}, nil
+	})
+	j := Jira{client: client, config: Config{Endpoint: "https://jira.example.com"}}
+
+	ctx := context.Background()
+	got, err := j.NewPullRequest(ctx, "KONG-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := PullRequest{
+		Key:         "KONG-1",
+		Summary:     "Add socket",
+		Description: "Serve JSON-RPC",
+		URL:         "https://jira.example.com/browse/KONG-1",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	if got := got.Title(); got != "KONG-1: Add socket" {
+		t.Errorf("got %q, want: %q", got, "KONG-1: Add socket")
+	}
+
+	if _, err := j.NewPullRequest(ctx, "KONG-2"); !errors.Is(err, errUnknownIssue) {
+		t.Errorf("got %v, want: %v", err, errUnknownIssue)
+	}
+}
+
+func TestPullRequestRender(t *testing.T) {
+	pr := PullRequest{
+		Key:                "KONG-1",
+		Summary:            "Add socket",
+		AcceptanceCriteria: "Tools can list issues",
+		URL:                "https://jira.example.com/browse/KONG-1",
+	}
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "default",
+			want: "## [KONG-1](https://jira.example.com/browse/KONG-1) Add socket\n" +
+				"\n### Acceptance Criteria\n\nTools can list issues\n",
+		},
+		{
+			name: "configured",
+			text: "{{.Key}} {{.Summary}}",
+			want: "KONG-1 Add socket",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pr.Render(tt.text)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package kong
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// DefaultProfile is the profile which uses the configuration and data file
+// without suffix.
+const DefaultProfile = "default"
+
+var (
+	errProfileInvalid = errors.New("profile name must only contain letters, digits, dashes and underscores")
+	errProfileUnknown = errors.New("profile is not configured, run kong --profile <name> configure")
+)
+
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Profiles records the profile in use if none is given with --profile.
+type Profiles struct {
+	Current string `yaml:"current"`
+}
+
+// profilesPath returns the path of the file recording the profile in use.
+func profilesPath() string {
+	return filepath.Join(Config{}.dir(), "kong.profiles.yaml")
+}
+
+func readProfiles() (Profiles, error) {
+	var profiles Profiles
+	b, err := os.ReadFile(profilesPath())
+	if os.IsNotExist(err) {
+		return profiles, nil
+	}
+	if err != nil {
+		return profiles, err
+	}
+	if err := yaml.Unmarshal(b, &profiles); err != nil {
+		return profiles, fmt.Errorf("readProfiles: %w", err)
+	}
+	return profiles, nil
+}
+
+func validateProfile(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: %s", errProfileInvalid, name)
+	}
+	return nil
+}
+
+// profilePath returns the path namespaced by the profile. The default profile
+// uses the path as is so that existing files keep working.
+func profilePath(path, profile string) string {
+	if profile == "" || profile == DefaultProfile {
+		return path
+	}
+	return path + "." + profile
+}
+
+// SetProfile selects the profile for the remainder of the process, overriding
+// the profile in use. It has to be called before the configuration or the
+// data is loaded.
+func SetProfile(name string) error {
+	if err := validateProfile(name); err != nil {
+		return err
+	}
+	session.profileOnce.Do(func() {})
+	session.profile = name
+	return nil
+}
+
+// CurrentProfile returns the name of the profile of the process which is read
+// once from the profiles file unless set with SetProfile.
+func CurrentProfile() string {
+	session.profileOnce.Do(func() {
+		profiles, err := readProfiles()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		session.profile = profiles.Current
+	})
+	if session.profile == "" {
+		return DefaultProfile
+	}
+	return session.profile
+}
+
+// UseProfile records the profile as the profile in use for subsequent
+// invocations. The profile has to be configured already.
+func UseProfile(name string) error {
+	if err := validateProfile(name); err != nil {
+		return err
+	}
+	path := profilePath(filepath.Join(Config{}.dir(), "kong"), name)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return fmt.Errorf("%w: %s", errProfileUnknown, name)
+	}
+	b, err := yaml.Marshal(Profiles{Current: name})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(profilesPath(), b, 0o600)
+}
+
+// ListProfiles returns the names of the configured profiles.
+func ListProfiles() ([]string, error) {
+	path := filepath.Join(Config{}.dir(), "kong")
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		return nil, err
+	}
+	var profiles []string
+	if _, err := os.Stat(path); err == nil {
+		profiles = append(profiles, DefaultProfile)
+	}
+	for _, match := range matches {
+		// skip the profiles file and other files next to the configuration
+		name := strings.TrimPrefix(match, path+".")
+		if validateProfile(name) == nil && name != DefaultProfile {
+			profiles = append(profiles, name)
+		}
+	}
+	sort.Strings(profiles)
+	return profiles, nil
+}
+
+// PrintProfiles writes the configured profiles and marks the current one.
+func PrintProfiles(output io.Writer, profiles []string, current string) {
+	for _, profile := range profiles {
+		marker := " "
+		if profile == current {
+			marker = "*"
+		}
+		fmt.Fprintf(output, "%s %s\n", marker, profile)
+	}
+}
@@ -0,0 +1,76 @@
+package kong
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProfilePath(t *testing.T) {
+	tests := []struct {
+		profile string
+		want    string
+	}{
+		{profile: "", want: "kong"},
+		{profile: DefaultProfile, want: "kong"},
+		{profile: "work", want: "kong.work"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.profile, func(t *testing.T) {
+			if got := profilePath("kong", tt.profile); got != tt.want {
+				t.Errorf("got %s, want: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// setHome points the home and configuration directories to the given
+// directory. Config.dir resolves them with os.UserHomeDir and os.UserConfigDir
+// which read different variables on each platform.
+func setHome(t *testing.T, home string) {
+	t.Helper()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("AppData", filepath.Join(home, ".config"))
+}
+
+func TestListProfiles(t *testing.T) {
+	home := t.TempDir()
+	setHome(t, home)
+	dir := filepath.Join(home, ".config")
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"kong", "kong.work", "kong.oss", "kong.profiles.yaml"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := ListProfiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(got, []string{DefaultProfile, "oss", "work"}); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	if err := UseProfile("home"); !errors.Is(err, errProfileUnknown) {
+		t.Errorf("got %v, want: %v", err, errProfileUnknown)
+	}
+	if err := UseProfile("../kong"); !errors.Is(err, errProfileInvalid) {
+		t.Errorf("got %v, want: %v", err, errProfileInvalid)
+	}
+	if err := UseProfile("work"); err != nil {
+		t.Fatal(err)
+	}
+	profiles, err := readProfiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profiles.Current != "work" {
+		t.Errorf("got %s, want: %s", profiles.Current, "work")
+	}
+}
@@ -0,0 +1,160 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+const progressBarWidth = 10
+
+// maxJQLKeys bounds the number of keys listed in a single JQL query since
+// Jira rejects queries which exceed its length limit.
+const maxJQLKeys = 100
+
+// Progress aggregates the child issues of an epic.
+type Progress struct {
+	Done        int
+	Total       int
+	DonePoints  float64
+	TotalPoints float64
+}
+
+// NewProgress aggregates the given child issues by their epic key.
+func NewProgress(children Issues) map[string]Progress {
+	result := make(map[string]Progress)
+	for _, child := range children {
+		if child.EpicKey == "" {
+			continue
+		}
+		p := result[child.EpicKey]
+		p.Total++
+		p.TotalPoints += child.StoryPoints
+		if child.Status.IsDone {
+			p.Done++
+			p.DonePoints += child.StoryPoints
+		}
+		result[child.EpicKey] = p
+	}
+	return result
+}
+
+// Bar renders a compact progress bar of the done child issues.
+func (p Progress) Bar() string {
+	filled := 0
+	if p.Total > 0 {
+		filled = p.Done * progressBarWidth / p.Total
+	}
+	return "[" + strings.Repeat("#", filled) + strings.Repeat("-", progressBarWidth-filled) + "]"
+}
+
+func (p Progress) String() string {
+	s := fmt.Sprintf("%s %d/%d", p.Bar(), p.Done, p.Total)
+	if p.TotalPoints > 0 {
+		s += fmt.Sprintf(" %s/%s pts", formatValue(p.DonePoints), formatValue(p.TotalPoints))
+	}
+	return s
+}
+
+// ListEpicChildren returns the issues of the given epics regardless of their
+// status or assignee. It requires the epic custom field to be configured.
+func (j Jira) ListEpicChildren(ctx context.Context, epicKeys []string) (Issues, error) {
+	field := jqlField(j.config.CustomFields.Epics)
+	if field == "" {
+		return nil, nil
+	}
+	var children Issues
+	for _, batch := range batchKeys(epicKeys) {
+		jql := field + " IN (" + strings.Join(batch, ",") + ")"
+		issues, err := j.search(ctx, jql)
+		if err != nil {
+			return nil, fmt.Errorf("ListEpicChildren: %w", err)
+		}
+		children = append(children, issues...)
+	}
+	return children, nil
+}
+
+// listEpicKeys returns the keys of the epics the given issues belong to.
+func (j Jira) listEpicKeys(ctx context.Context, keys []string) ([]string, error) {
+	field := j.config.CustomFields.Epics
+	if jqlField(field) == "" {
+		return nil, nil
+	}
+	var epicKeys []string
+	for _, batch := range batchKeys(keys) {
+		jql := "key IN (" + strings.Join(batch, ",") + ")"
+		issues, err := j.searchWithOptions(ctx, jql, jira.SearchOptions{Fields: []string{"key", field}})
+		if err != nil {
+			return nil, fmt.Errorf("listEpicKeys: %w", err)
+		}
+		for _, issue := range issues {
+			if issue.Fields == nil {
+				continue
+			}
+			if epicKey, ok := issue.Fields.Unknowns[field].(string); ok && epicKey != "" {
+				epicKeys = append(epicKeys, epicKey)
+			}
+		}
+	}
+	return epicKeys, nil
+}
+
+// batchKeys splits the keys into batches of at most maxJQLKeys keys.
+func batchKeys(keys []string) [][]string {
+	var batches [][]string
+	for len(keys) > maxJQLKeys {
+		batches = append(batches, keys[:maxJQLKeys])
+		keys = keys[maxJQLKeys:]
+	}
+	if len(keys) > 0 {
+		batches = append(batches, keys)
+	}
+	return batches
+}
+
+// withProgress returns a copy of the progress with the progress of the given
+// epics replaced by the updated progress.
+func withProgress(progress map[string]Progress, epicKeys []string, updated map[string]Progress) map[string]Progress {
+	result := make(map[string]Progress, len(progress))
+	for key, p := range progress {
+		result[key] = p
+	}
+	for _, key := range epicKeys {
+		delete(result, key)
+		if p, ok := updated[key]; ok {
+			result[key] = p
+		}
+	}
+	return result
+}
+
+// jqlField returns the JQL reference of a custom field ID, for instance
+// cf[10008] for customfield_10008.
+func jqlField(customField string) string {
+	id := strings.TrimPrefix(customField, "customfield_")
+	if id == "" || id == customField {
+		return ""
+	}
+	return "cf[" + id + "]"
+}
+
+// PrintProgress formats a list of epics including the progress of their child
+// issues and writes them to output. Keys are shown in the epic color if the
+// output is a terminal.
+func (i Issues) PrintProgress(output io.Writer, progress map[string]Progress) {
+	color := colorEnabled(output)
+	w := tabwriter.NewWriter(output, 1, 1, 1, ' ', 0)
+	for _, epic := range i.Sort() {
+		key := epic.Key
+		if color {
+			key = epic.colorKey()
+		}
+		fmt.Fprintf(w, "%s\t-\t%s\t-\t%s\t-\t%s\n", key, epic.StatusName(), progress[epic.Key], epic.Summary)
+	}
+	w.Flush()
+}
@@ -0,0 +1,125 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewProgress(t *testing.T) {
+	children := Issues{
+		{Key: "KONG-2", EpicKey: "KONG-1", StoryPoints: 3, Status: Status{IsDone: true}},
+		{Key: "KONG-3", EpicKey: "KONG-1", StoryPoints: 5},
+		{Key: "KONG-4", EpicKey: "KONG-1", StoryPoints: 2, Status: Status{IsDone: true}},
+		{Key: "KONG-5", EpicKey: "KONG-1"},
+		{Key: "KONG-6"},
+	}
+	got := NewProgress(children)
+	want := map[string]Progress{
+		"KONG-1": {Done: 2, Total: 4, DonePoints: 5, TotalPoints: 10},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	if got, want := got["KONG-1"].String(), "[#####-----] 2/4 5/10 pts"; got != want {
+		t.Errorf("got %v, want: %v", got, want)
+	}
+	if got, want := (Progress{}).String(), "[----------] 0/0"; got != want {
+		t.Errorf("got %v, want: %v", got, want)
+	}
+}
+
+func TestJQLField(t *testing.T) {
+	tests := []struct {
+		customField string
+		want        string
+	}{
+		{"customfield_10008", "cf[10008]"},
+		{"", ""},
+		{"Epic Link", ""},
+	}
+	for _, tt := range tests {
+		if got := jqlField(tt.customField); got != tt.want {
+			t.Errorf("got %v, want: %v", got, tt.want)
+		}
+	}
+}
+
+func TestListEpicChildrenBatches(t *testing.T) {
+	var queries []string
+	client := newTestJira(t, func(req *http.Request) (*http.Response, error) {
+		queries = append(queries, req.URL.Query().Get("jql"))
+
+		// an empty page ends the search although the total is not reached
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"issues": [], "total": 3}`)),
+		}, nil
+	})
+	j := Jira{
+		client: client,
+		config: Config{CustomFields: CustomFields{Epics: "customfield_10008"}},
+	}
+	keys := make([]string, maxJQLKeys+1)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("KONG-%d", i+1)
+	}
+	if _, err := j.ListEpicChildren(context.Background(), keys); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"cf[10008] IN (" + strings.Join(keys[:maxJQLKeys], ",") + ")",
+		"cf[10008] IN (" + keys[maxJQLKeys] + ")",
+	}
+	if diff := cmp.Diff(queries, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestListEpicKeys(t *testing.T) {
+	client := newTestJira(t, func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(strings.NewReader(`{"issues": [
+				{"key": "KONG-1", "fields": {"customfield_10008": "KONG-10"}},
+				{"key": "KONG-2", "fields": {"customfield_10008": null}}
+			], "total": 2}`)),
+		}, nil
+	})
+	j := Jira{
+		client: client,
+		config: Config{CustomFields: CustomFields{Epics: "customfield_10008"}},
+	}
+	got, err := j.listEpicKeys(context.Background(), []string{"KONG-1", "KONG-2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(got, []string{"KONG-10"}); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestWithProgress(t *testing.T) {
+	progress := map[string]Progress{
+		"KONG-10": {Done: 1, Total: 2},
+		"KONG-11": {Done: 0, Total: 1},
+		"KONG-12": {Done: 3, Total: 3},
+	}
+	updated := map[string]Progress{"KONG-10": {Done: 2, Total: 2}}
+	got := withProgress(progress, []string{"KONG-10", "KONG-11"}, updated)
+	want := map[string]Progress{
+		"KONG-10": {Done: 2, Total: 2},
+		"KONG-12": {Done: 3, Total: 3},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	if progress["KONG-11"].Total != 1 {
+		t.Errorf("got %v, want: %v", progress["KONG-11"].Total, 1)
+	}
+}
@@ -0,0 +1,129 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ProjectData holds the sections of a project which is not currently in use
+// so that switching projects does not require a sync.
+type ProjectData struct {
+	Timestamp         int64
+	FullSyncTimestamp int64
+	Issues            Issues
+	IssueByKey        map[string]Issue
+	Initiatives       Issues
+	Epics             Issues
+	EpicProgress      map[string]Progress
+	SprintIssues      Issues
+	BoardID           int
+	NoBoard           bool
+	Sprints           Sprints
+	SprintsByName     map[string]Sprint
+}
+
+func (d Data) projectData() ProjectData {
+	return ProjectData{
+		Timestamp:         d.Timestamp,
+		FullSyncTimestamp: d.FullSyncTimestamp,
+		Issues:            d.Issues,
+		IssueByKey:        d.IssueByKey,
+		Initiatives:       d.Initiatives,
+		Epics:             d.Epics,
+		EpicProgress:      d.EpicProgress,
+		SprintIssues:      d.SprintIssues,
+		BoardID:           d.BoardID,
+		NoBoard:           d.NoBoard,
+		Sprints:           d.Sprints,
+		SprintsByName:     d.SprintsByName,
+	}
+}
+
+func (d *Data) setProjectData(p ProjectData) {
+	d.Timestamp = p.Timestamp
+	d.FullSyncTimestamp = p.FullSyncTimestamp
+	d.Issues = p.Issues
+	d.IssueByKey = p.IssueByKey
+	d.Initiatives = p.Initiatives
+	d.Epics = p.Epics
+	d.EpicProgress = p.EpicProgress
+	d.SprintIssues = p.SprintIssues
+	d.BoardID = p.BoardID
+	d.NoBoard = p.NoBoard
+	d.Sprints = p.Sprints
+	d.SprintsByName = p.SprintsByName
+	if d.IssueByKey == nil {
+		d.IssueByKey = make(map[string]Issue)
+	}
+	if d.SprintsByName == nil {
+		d.SprintsByName = make(map[string]Sprint)
+	}
+}
+
+// Use switches the sections to the given project and reports whether the
+// project has been synced before. The sections of the previous project are
+// kept and restored when switching back, projects which were never synced
+// start out empty and stale.
+func (d *Data) Use(project string) bool {
+	if d.Project == "" || d.Project == project {
+		d.Project = project
+		return true
+	}
+
+	// copy the projects since the data may be shared with the session cache
+	projects := make(map[string]ProjectData, len(d.Projects)+1)
+	for name, p := range d.Projects {
+		projects[name] = p
+	}
+	projects[d.Project] = d.projectData()
+	p, ok := projects[project]
+	delete(projects, project)
+	d.Projects = projects
+	d.setProjectData(p)
+	d.Project = project
+	return ok
+}
+
+// loadProjects syncs the sections of the given projects other than the one in
+// use, for instance to switch between them instantly with kong use.
+func (d *Data) loadProjects(ctx context.Context, projects []string) error {
+	synced := make(map[string]ProjectData, len(projects))
+	for name, p := range d.Projects {
+		synced[name] = p
+	}
+	defer func() {
+		d.Projects = synced
+	}()
+	for _, project := range projects {
+		if project == d.Project {
+			continue
+		}
+		other := NewData()
+		other.jira = d.jira.withProject(project)
+		other.Project = project
+		other.BoardID = synced[project].BoardID
+		if err := other.loadSections(ctx, SectionAll); err != nil {
+			return fmt.Errorf("loadProjects(%s): %w", project, err)
+		}
+		synced[project] = other.projectData()
+	}
+	return nil
+}
+
+// PrintProjects writes the synced projects and marks the project in use with
+// an asterisk.
+func (d Data) PrintProjects(output io.Writer, projects []string) {
+	for _, project := range projects {
+		marker, timestamp := " ", d.Projects[project].Timestamp
+		if project == d.Project {
+			marker, timestamp = "*", d.Timestamp
+		}
+		synced := "never synced"
+		if timestamp != 0 {
+			synced = "synced " + time.Unix(timestamp, 0).Local().Format("2006/1/2 15:04")
+		}
+		fmt.Fprintf(output, "%s %s (%s)\n", marker, project, synced)
+	}
+}
@@ -0,0 +1,69 @@
+package kong
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDataUse(t *testing.T) {
+	data := NewData()
+	data.Project = "KONG"
+	data.Timestamp = 1
+	data.Issues = Issues{{Key: "KONG-1"}}
+	data.BoardID = 7
+
+	// switching to a project which was never synced starts out empty
+	if synced := data.Use("APE"); synced {
+		t.Errorf("got %v, want: %v", synced, false)
+	}
+	if data.Project != "APE" || len(data.Issues) != 0 || data.BoardID != 0 || data.IssueByKey == nil {
+		t.Errorf("unexpected sections after switching: %+v", data)
+	}
+	data.Issues = Issues{{Key: "APE-1"}}
+
+	// switching back restores the sections without a sync
+	if synced := data.Use("KONG"); !synced {
+		t.Errorf("got %v, want: %v", synced, true)
+	}
+	if diff := cmp.Diff(data.Issues, Issues{{Key: "KONG-1"}}); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	if data.BoardID != 7 || data.Timestamp != 1 {
+		t.Errorf("got board %d and timestamp %d, want: 7 and 1", data.BoardID, data.Timestamp)
+	}
+	if diff := cmp.Diff(data.Projects["APE"].Issues, Issues{{Key: "APE-1"}}); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	if _, ok := data.Projects["KONG"]; ok {
+		t.Error("project in use must not be kept in Projects")
+	}
+}
+
+func TestConfigUseProject(t *testing.T) {
+	config := Config{Project: "KONG", Projects: []string{"APE"}}
+	config.UseProject("APE")
+	config.UseProject("ZOO")
+	if config.Project != "ZOO" {
+		t.Errorf("got %v, want: %v", config.Project, "ZOO")
+	}
+	if diff := cmp.Diff(config.SyncedProjects(), []string{"ZOO", "APE", "KONG"}); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestPrintProjects(t *testing.T) {
+	data := Data{
+		Project: "KONG",
+		Projects: map[string]ProjectData{
+			"APE": {},
+		},
+	}
+	var buf bytes.Buffer
+	data.PrintProjects(&buf, []string{"KONG", "APE"})
+	want := "* KONG (never synced)\n  APE (never synced)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want: %q", got, want)
+	}
+}
@@ -0,0 +1,138 @@
+package kong
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Output formats of issue listings.
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+	OutputCSV  = "csv"
+)
+
+var (
+	errQueryInvalid = errors.New("saved query name must be a single word")
+	errQueryEmpty   = errors.New("saved query requires a JQL query")
+	errOutputFormat = errors.New("unknown output format, expected text, json or csv")
+)
+
+func validateQuery(name, jql string) error {
+	if name == "" || strings.ContainsAny(name, " \t") {
+		return fmt.Errorf("%w: %q", errQueryInvalid, name)
+	}
+	if strings.TrimSpace(jql) == "" {
+		return fmt.Errorf("%w: %s", errQueryEmpty, name)
+	}
+	return nil
+}
+
+// SaveQuery stores the JQL query under the name so that it can be run with
+// kong search NAME. An existing query of the same name is replaced.
+func (c *Config) SaveQuery(name, jql string) error {
+	if err := validateQuery(name, jql); err != nil {
+		return err
+	}
+	if c.Queries == nil {
+		c.Queries = make(map[string]string)
+	}
+	c.Queries[name] = jql
+	return nil
+}
+
+// Query returns the JQL of the saved query with the given name or the
+// argument itself if no query of that name is saved.
+func (c Config) Query(arg string) string {
+	if jql, ok := c.Queries[arg]; ok {
+		return jql
+	}
+	return arg
+}
+
+// Search returns all issues matching the JQL query.
+func (j Jira) Search(ctx context.Context, jql string) (Issues, error) {
+	issues, err := j.search(ctx, jql)
+	if err != nil {
+		return nil, fmt.Errorf("Search: %w", err)
+	}
+	return issues, nil
+}
+
+// PrintJSON writes the issues as JSON array in the same shape the JSON-RPC
+// server returns them.
+func (i Issues) PrintJSON(output io.Writer) error {
+	if i == nil {
+		i = Issues{}
+	}
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(i)
+}
+
+// csvHeader names the columns of PrintCSV.
+var csvHeader = []string{"Key", "Summary", "Status", "Priority", "Story Points", "Epic", "Sprint", "Labels", "Created", "Updated"}
+
+// PrintCSV writes the issues as comma-separated values with a header row for
+// spreadsheets. The sprint is named after the given sprints if the issue is in
+// one of them and given by ID otherwise.
+func (i Issues) PrintCSV(output io.Writer, sprints Sprints) error {
+	names := make(map[int]string, len(sprints))
+	for _, sprint := range sprints {
+		names[sprint.ID] = sprint.Name
+	}
+	w := csv.NewWriter(output)
+	if err := w.Write(csvHeader); err != nil {
+		return fmt.Errorf("PrintCSV: %w", err)
+	}
+	for _, issue := range i {
+		sprint, ok := names[issue.SprintID]
+		if !ok && issue.SprintID != 0 {
+			sprint = strconv.Itoa(issue.SprintID)
+		}
+		row := []string{
+			issue.Key,
+			issue.Summary,
+			issue.Status.Name,
+			issue.Priority,
+			formatValue(issue.StoryPoints),
+			issue.EpicKey,
+			sprint,
+			strings.Join(issue.Labels, " "),
+			formatCSVTime(issue.Created),
+			formatCSVTime(issue.Updated),
+		}
+		if err := w.Write(row); err != nil {
+			return fmt.Errorf("PrintCSV: %w", err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("PrintCSV: %w", err)
+	}
+	return nil
+}
+
+func formatCSVTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// ValidateOutput returns an error if the output format is not text, json or
+// csv.
+func ValidateOutput(format string) error {
+	switch format {
+	case OutputText, OutputJSON, OutputCSV:
+		return nil
+	}
+	return fmt.Errorf("%w: %s", errOutputFormat, format)
+}
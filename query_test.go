@@ -0,0 +1,110 @@
+package kong
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConfigSaveQuery(t *testing.T) {
+	var config Config
+	if err := config.SaveQuery("mybugs", "assignee = currentUser() AND type = Bug"); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.SaveQuery("my bugs", "type = Bug"); !errors.Is(err, errQueryInvalid) {
+		t.Errorf("got %v, want: %v", err, errQueryInvalid)
+	}
+	if err := config.SaveQuery("empty", " "); !errors.Is(err, errQueryEmpty) {
+		t.Errorf("got %v, want: %v", err, errQueryEmpty)
+	}
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		arg  string
+		want string
+	}{
+		{arg: "mybugs", want: "assignee = currentUser() AND type = Bug"},
+		{arg: "project = KONG", want: "project = KONG"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			if got := config.Query(tt.arg); got != tt.want {
+				t.Errorf("got %s, want: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIssuesPrintJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		issues Issues
+		want   []string
+	}{
+		{name: "empty", want: []string{}},
+		{name: "issues", issues: Issues{{Key: "KONG-1"}, {Key: "KONG-2"}}, want: []string{"KONG-1", "KONG-2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := tt.issues.PrintJSON(&b); err != nil {
+				t.Fatal(err)
+			}
+			var issues []struct{ Key string }
+			if err := json.Unmarshal(b.Bytes(), &issues); err != nil {
+				t.Fatal(err)
+			}
+			got := []string{}
+			for _, issue := range issues {
+				got = append(got, issue.Key)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestIssuesPrintCSV(t *testing.T) {
+	created := time.Date(2022, 3, 1, 9, 30, 0, 0, time.UTC)
+	issues := Issues{
+		{
+			Key:         "KONG-1",
+			Summary:     "Export issues, epics and sprints",
+			Status:      Status{Name: "In Progress"},
+			Priority:    "High",
+			StoryPoints: 2.5,
+			EpicKey:     "KONG-10",
+			SprintID:    7,
+			Labels:      []string{"cli", "reporting"},
+			Created:     created,
+		},
+		{Key: "KONG-2", Summary: "Unplanned", SprintID: 8},
+	}
+	var b bytes.Buffer
+	if err := issues.PrintCSV(&b, Sprints{{ID: 7, Name: "Sprint 7"}}); err != nil {
+		t.Fatal(err)
+	}
+	want := "Key,Summary,Status,Priority,Story Points,Epic,Sprint,Labels,Created,Updated\n" +
+		`KONG-1,"Export issues, epics and sprints",In Progress,High,2.5,KONG-10,Sprint 7,cli reporting,2022-03-01T09:30:00Z,` + "\n" +
+		"KONG-2,Unplanned,,,0,,8,,,\n"
+	if diff := cmp.Diff(b.String(), want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestValidateOutput(t *testing.T) {
+	for _, format := range []string{OutputText, OutputJSON, OutputCSV} {
+		if err := ValidateOutput(format); err != nil {
+			t.Errorf("got %v, want: %v", err, nil)
+		}
+	}
+	if err := ValidateOutput("xml"); !errors.Is(err, errOutputFormat) {
+		t.Errorf("got %v, want: %v", err, errOutputFormat)
+	}
+}
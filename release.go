@@ -0,0 +1,120 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+var (
+	errReleasedStatusMissing = errors.New("released status is not configured")
+
+	issueKeyPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9_]*-[0-9]+\b`)
+)
+
+// findIssueKeys returns all distinct issue keys of the given project in the
+// order of their first occurrence in s.
+func findIssueKeys(s, project string) []string {
+	var keys []string
+	seen := make(map[string]struct{})
+	for _, key := range issueKeyPattern.FindAllString(s, -1) {
+		if project != "" && !strings.HasPrefix(key, project+"-") {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// gitLogIssueKeys scans the commit messages of the given revision range for
+// issue keys of the project.
+func gitLogIssueKeys(ctx context.Context, revisionRange, project string) ([]string, error) {
+	cmd := exec.CommandContext(ctx, "git", "log", "--format=%B", revisionRange)
+	cmd.Stderr = os.Stderr
+	b, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log %s: %w", revisionRange, err)
+	}
+	return findIssueKeys(string(b), project), nil
+}
+
+// CloseRelease transitions all issues referenced in the commit messages of the
+// given revision range to the configured released status and adds the fix
+// version. Issues which are released already are skipped. It asks for
+// confirmation before applying any change.
+func (j Jira) CloseRelease(ctx context.Context, revisionRange, version string) error {
+	if j.config.ReleasedStatus == "" {
+		return errReleasedStatusMissing
+	}
+	keys, err := gitLogIssueKeys(ctx, revisionRange, j.config.Project)
+	if err != nil {
+		return err
+	}
+	issues, err := j.ListIssuesByKey(ctx, keys)
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		fmt.Println("No issues found in", revisionRange)
+		return nil
+	}
+
+	pending, issueTransitions, err := j.releaseTransitions(issues)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		fmt.Println("All issues in", revisionRange, "are released already")
+		return nil
+	}
+
+	pending.Print(os.Stdout)
+	prompt := fmt.Sprintf("Release %d issues as %s in %s?", len(pending), j.config.ReleasedStatus, version)
+	if ok, err := Confirm(prompt); err != nil || !ok {
+		return err
+	}
+	if ok, err := j.confirmChecklists(issueTransitions, Confirm); err != nil || !ok {
+		return err
+	}
+	for _, issue := range pending {
+		if err := j.AddFixVersion(ctx, issue.Key, version); err != nil {
+			return err
+		}
+	}
+	if err := j.TransitionIssues(ctx, issueTransitions); err != nil {
+		return err
+	}
+	return j.commentChecklists(ctx, issueTransitions)
+}
+
+// releaseTransitions returns the issues which are not released yet together
+// with their transitions to the released status.
+func (j Jira) releaseTransitions(issues Issues) (Issues, []issueTransition, error) {
+	var (
+		pending          Issues
+		issueTransitions []issueTransition
+	)
+	for _, issue := range issues {
+		if issue.Status.Name == j.config.ReleasedStatus {
+			continue
+		}
+		transition, ok := issue.TransitionByName(j.config.ReleasedStatus)
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %s to %s", errUnknownTransition, issue.Key, j.config.ReleasedStatus)
+		}
+		pending = append(pending, issue)
+		issueTransitions = append(issueTransitions, issueTransition{
+			issueKey:   issue.Key,
+			transition: transition,
+		})
+	}
+	return pending, issueTransitions, nil
+}
@@ -0,0 +1,47 @@
+package kong
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindIssueKeys(t *testing.T) {
+	log := `KONG-2: Add release command
+
+Refs KONG-1, KONG-2 and OTHER-3.
+
+Fix KONG-1 regression (see UTF-8 handling)
+`
+	got := findIssueKeys(log, "KONG")
+	want := []string{"KONG-2", "KONG-1"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+
+	got = findIssueKeys(log, "")
+	want = []string{"KONG-2", "KONG-1", "OTHER-3", "UTF-8"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestReleaseTransitions(t *testing.T) {
+	released := Transition{ID: "41", Name: "Released"}
+	j := Jira{config: Config{ReleasedStatus: "Released"}}
+	issues := Issues{
+		{Key: "KONG-1", Status: Status{Name: "Done"}, Transitions: []Transition{released}},
+		{Key: "KONG-2", Status: Status{Name: "Released"}},
+	}
+	pending, transitions, err := j.releaseTransitions(issues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(pending, issues[:1]); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	want := []issueTransition{{issueKey: "KONG-1", transition: released}}
+	if diff := cmp.Diff(transitions, want, cmp.AllowUnexported(issueTransition{})); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
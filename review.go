@@ -0,0 +1,108 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+var errNoReviewers = errors.New("no reviewers configured")
+
+// reviewers returns the configured reviewers which default to the team.
+func (c Config) reviewers() []string {
+	if len(c.Reviewers) > 0 {
+		return c.Reviewers
+	}
+	return c.Team
+}
+
+// nextReviewer returns the reviewer following last in the rotation. The
+// author is skipped since nobody reviews their own changes.
+func nextReviewer(reviewers []string, last, author string) (string, error) {
+	start := 0
+	for i, reviewer := range reviewers {
+		if reviewer == last {
+			start = i + 1
+			break
+		}
+	}
+	for i := 0; i < len(reviewers); i++ {
+		reviewer := reviewers[(start+i)%len(reviewers)]
+		if reviewer != author {
+			return reviewer, nil
+		}
+	}
+	return "", errNoReviewers
+}
+
+// AssignReviewer picks the next reviewer of the rotation, sets them as
+// reviewer of the issue and mentions them in a comment. The reviewer is set
+// in the configured reviewer field or added as watcher otherwise. The
+// rotation is kept in the state.
+func (j Jira) AssignReviewer(ctx context.Context, data *Data, key string) error {
+	var author string
+	if j.user != nil {
+		author = j.user.DisplayName
+	}
+	name, err := nextReviewer(j.config.reviewers(), data.LastReviewer, author)
+	if err != nil {
+		return err
+	}
+	user, err := j.FindUser(ctx, name)
+	if err != nil {
+		return err
+	}
+	if err := j.setReviewer(ctx, key, user); err != nil {
+		return err
+	}
+	comment := fmt.Sprintf("%s please review", j.mention(user))
+	if err := j.AddComment(ctx, key, comment); err != nil {
+		return err
+	}
+	if err := data.UpdateState(func(d *Data) error {
+		d.LastReviewer = name
+		return nil
+	}); err != nil {
+		return err
+	}
+	fmt.Printf("%s - Review assigned to %s\n", key, user.DisplayName)
+	return nil
+}
+
+func (j Jira) setReviewer(ctx context.Context, key string, user jira.User) error {
+	if j.config.ReviewerField == "" {
+		id := user.AccountID
+		if j.config.isServer() {
+			id = user.Name
+		}
+		resp, err := j.client.Issue.AddWatcherWithContext(ctx, key, id)
+		if err != nil {
+			return fmt.Errorf("AssignReviewer: %w", parseResponseError(resp))
+		}
+		return nil
+	}
+	reviewer := map[string]string{"accountId": user.AccountID}
+	if j.config.isServer() {
+		reviewer = map[string]string{"name": user.Name}
+	}
+	data := map[string]interface{}{
+		"fields": map[string]interface{}{
+			j.config.ReviewerField: reviewer,
+		},
+	}
+	resp, err := j.client.Issue.UpdateIssueWithContext(ctx, key, data)
+	if err != nil {
+		return fmt.Errorf("AssignReviewer: %w", parseResponseError(resp))
+	}
+	return nil
+}
+
+// mention returns the markup mentioning the user in a comment.
+func (j Jira) mention(user jira.User) string {
+	if j.config.isServer() {
+		return "[~" + user.Name + "]"
+	}
+	return "[~accountid:" + user.AccountID + "]"
+}
@@ -0,0 +1,118 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNextReviewer(t *testing.T) {
+	reviewers := []string{"Alice", "Bob", "Carol"}
+	tests := []struct {
+		reviewers []string
+		last      string
+		author    string
+		want      string
+		err       error
+	}{
+		{reviewers: reviewers, want: "Alice"},
+		{reviewers: reviewers, last: "Alice", want: "Bob"},
+		{reviewers: reviewers, last: "Carol", want: "Alice"},
+		{reviewers: reviewers, last: "Alice", author: "Bob", want: "Carol"},
+		{reviewers: reviewers, last: "Dave", want: "Alice"},
+		{reviewers: []string{"Alice"}, author: "Alice", err: errNoReviewers},
+		{err: errNoReviewers},
+	}
+	for _, tt := range tests {
+		got, err := nextReviewer(tt.reviewers, tt.last, tt.author)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("got %v, want: %v", err, tt.err)
+		}
+		if got != tt.want {
+			t.Errorf("got %v, want: %v", got, tt.want)
+		}
+	}
+}
+
+func TestAssignReviewer(t *testing.T) {
+	tests := []struct {
+		name          string
+		reviewerField string
+		want          []string
+	}{
+		{
+			name: "watcher",
+			want: []string{
+				"GET /rest/api/2/user/search",
+				`POST /rest/api/2/issue/KONG-1/watchers "2"`,
+				`POST /rest/api/2/issue/KONG-1/comment {"author":{"avatarUrls":{}},"body":"[~accountid:2] please review","updateAuthor":{"avatarUrls":{}},"visibility":{}}`,
+			},
+		},
+		{
+			name:          "field",
+			reviewerField: "customfield_10001",
+			want: []string{
+				"GET /rest/api/2/user/search",
+				`PUT /rest/api/2/issue/KONG-1 {"fields":{"customfield_10001":{"accountId":"2"}}}`,
+				`POST /rest/api/2/issue/KONG-1/comment {"author":{"avatarUrls":{}},"body":"[~accountid:2] please review","updateAuthor":{"avatarUrls":{}},"visibility":{}}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			client := newTestJira(t, func(req *http.Request) (*http.Response, error) {
+				request := req.Method + " " + req.URL.Path
+				if req.Body != nil {
+					b, err := io.ReadAll(req.Body)
+					if err != nil {
+						return nil, err
+					}
+					request += " " + strings.TrimSpace(string(b))
+				}
+				got = append(got, request)
+				body := `{}`
+				if req.Method == http.MethodGet {
+					body = `[{"accountId": "2", "displayName": "Bob"}]`
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(body)),
+				}, nil
+			})
+			j := Jira{
+				client: client,
+				user:   &jira.User{DisplayName: "Alice"},
+				config: Config{
+					Reviewers:     []string{"Alice", "Bob"},
+					ReviewerField: tt.reviewerField,
+				},
+			}
+			t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+			data := NewData()
+			if err := j.AssignReviewer(context.Background(), &data, "KONG-1"); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+			if data.LastReviewer != "Bob" {
+				t.Errorf("got %v, want: %v", data.LastReviewer, "Bob")
+			}
+			state, err := readState()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if state.LastReviewer != "Bob" {
+				t.Errorf("got %v, want: %v", state.LastReviewer, "Bob")
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package kong
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"time"
+)
+
+var errUnknownStatus = errors.New("status does not exist")
+
+const (
+	// rpcName is the name the JSON-RPC methods are registered under, for
+	// instance Kong.ListIssues.
+	rpcName = "Kong"
+
+	// rpcTimeout bounds reading the data from the daemon before falling back
+	// to the data file.
+	rpcTimeout = 2 * time.Second
+)
+
+// socketPath returns the path of the unix socket the daemon listens on.
+func socketPath() string {
+	return cachePath() + ".sock"
+}
+
+// RPC exposes the cached data and operations of the daemon over JSON-RPC so
+// that editor plugins and other tools can integrate without shelling out and
+// decoding the data file themselves.
+type RPC struct {
+	ctx    context.Context
+	daemon *Daemon
+}
+
+// GetArgs selects the sections returned by Kong.Get. The issue bodies are
+// left out unless Bodies is set.
+type GetArgs struct {
+	Bodies bool
+}
+
+// GetReply contains the content of the data file held by the daemon.
+type GetReply struct {
+	Cache []byte
+}
+
+// Get returns the data file so that the CLI does not have to read it from
+// disk on every invocation.
+func (r *RPC) Get(args GetArgs, reply *GetReply) error {
+	b, err := r.daemon.cached()
+	if err != nil {
+		return err
+	}
+	if !args.Bodies {
+		b = withoutBodies(b)
+	}
+	reply.Cache = b
+	return nil
+}
+
+// RefreshArgs is the empty argument of Kong.Refresh.
+type RefreshArgs struct{}
+
+// RefreshReply is the empty reply of Kong.Refresh.
+type RefreshReply struct{}
+
+// Refresh syncs the data right away instead of waiting for the next interval
+// and returns once the data file is written.
+func (r *RPC) Refresh(args RefreshArgs, reply *RefreshReply) error {
+	return r.daemon.refresh(r.ctx)
+}
+
+// InvalidateArgs is the empty argument of Kong.Invalidate.
+type InvalidateArgs struct{}
+
+// InvalidateReply is the empty reply of Kong.Invalidate.
+type InvalidateReply struct{}
+
+// Invalidate drops the data held by the daemon after the data file was
+// written by another process.
+func (r *RPC) Invalidate(args InvalidateArgs, reply *InvalidateReply) error {
+	r.daemon.invalidate()
+	return nil
+}
+
+// ListIssuesArgs selects the issues returned by Kong.ListIssues. The source
+// is one of the view sources and the filter a filter expression.
+type ListIssuesArgs struct {
+	Source string
+	Filter string
+}
+
+// ListIssuesReply contains the issues returned by Kong.ListIssues.
+type ListIssuesReply struct {
+	Issues Issues
+}
+
+// ListIssues returns the cached issues of the given source.
+func (r *RPC) ListIssues(args ListIssuesArgs, reply *ListIssuesReply) error {
+	data, err := ReadData()
+	if err != nil {
+		return err
+	}
+	if err := data.LoadBodies(); err != nil {
+		return err
+	}
+	view := View{Source: args.Source, Filter: args.Filter}
+	if err := view.validate(); err != nil {
+		return err
+	}
+	reply.Issues, err = view.Issues(r.ctx, data)
+	return err
+}
+
+// CreateIssueArgs describes the issue created by Kong.CreateIssue.
+type CreateIssueArgs struct {
+	Summary     string
+	Description string
+	StoryPoints float64
+	EpicKey     string
+	SprintID    int
+}
+
+// CreateIssueReply contains the key of the issue created by Kong.CreateIssue.
+type CreateIssueReply struct {
+	Key string
+}
+
+// CreateIssue creates an issue of the configured issue type.
+func (r *RPC) CreateIssue(args CreateIssueArgs, reply *CreateIssueReply) error {
+	j, err := r.writableJira()
+	if err != nil {
+		return err
+	}
+	fields := make(map[string]any)
+	if args.StoryPoints != 0 {
+		fields[fieldStoryPoints] = args.StoryPoints
+	}
+	if args.EpicKey != "" {
+		fields[fieldEpic] = args.EpicKey
+	}
+	unknowns, err := j.config.encodeFields(fields)
+	if err != nil {
+		return err
+	}
+	if args.SprintID != 0 {
+		unknowns[j.config.CustomFields.Sprints] = args.SprintID
+	}
+	issue := j.newIssue(j.config.IssueType, args.Summary, args.Description, unknowns)
+	reply.Key, err = j.CreateIssue(r.ctx, issue)
+	return err
+}
+
+// TransitionArgs moves the issue with the key into the status given by name
+// or sprint editor acronym.
+type TransitionArgs struct {
+	Key    string
+	Status string
+}
+
+// TransitionReply is the empty reply of Kong.Transition.
+type TransitionReply struct{}
+
+// Transition moves a cached issue into another status.
+func (r *RPC) Transition(args TransitionArgs, reply *TransitionReply) error {
+	j, err := r.writableJira()
+	if err != nil {
+		return err
+	}
+	data, err := ReadData()
+	if err != nil {
+		return err
+	}
+	issue, ok := data.IssueByKey[args.Key]
+	if !ok {
+		issue, ok = data.SprintIssues.find(args.Key)
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnknownIssue, args.Key)
+	}
+	transition, ok := issue.TransitionByName(args.Status)
+	if !ok {
+		transition, ok = issue.TransitionsByAcronym[args.Status]
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnknownStatus, args.Status)
+	}
+	return j.TransitionIssues(r.ctx, []issueTransition{
+		{
+			issueKey:   issue.Key,
+			transition: transition,
+		},
+	})
+}
+
+func (r *RPC) writableJira() (Jira, error) {
+	j, err := NewJira()
+	if err != nil {
+		return j, err
+	}
+	if j.config.ReadOnly {
+		return j, ErrReadOnly
+	}
+	return j, nil
+}
+
+// ServeRPC listens on the unix socket next to the data file and serves
+// JSON-RPC requests until the context is canceled.
+func (d *Daemon) ServeRPC(ctx context.Context) error {
+	server := rpc.NewServer()
+	if err := server.RegisterName(rpcName, &RPC{ctx: ctx, daemon: d}); err != nil {
+		return err
+	}
+
+	// a previous daemon may have left the socket behind
+	path := socketPath()
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("ServeRPC: %w", err)
+	}
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("ServeRPC: %w", err)
+		}
+		go server.ServeCodec(jsonrpc.NewServerCodec(conn))
+	}
+}
+
+// dialDaemon connects to the socket of the daemon. The daemon itself never
+// connects to its own socket.
+func dialDaemon(timeout time.Duration) (*rpc.Client, error) {
+	if session.daemon {
+		return nil, errDaemonNotRunning
+	}
+	conn, err := net.DialTimeout("unix", socketPath(), timeout)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errDaemonNotRunning, err)
+	}
+	return jsonrpc.NewClient(conn), nil
+}
+
+// callDaemon calls the method of the daemon and gives up after the timeout.
+func callDaemon(ctx context.Context, timeout time.Duration, method string, args, reply any) error {
+	client, err := dialDaemon(timeout)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	call := client.Go(rpcName+"."+method, args, reply, nil)
+	select {
+	case <-call.Done:
+		return call.Error
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// readDaemonData returns the data held by the daemon without the issue bodies,
+// which Data.LoadBodies reads from the data file. It returns an error if the
+// daemon is not running, in which case the data file has to be read.
+func readDaemonData() (Data, error) {
+	var reply GetReply
+	if err := callDaemon(context.Background(), rpcTimeout, "Get", GetArgs{}, &reply); err != nil {
+		return Data{}, err
+	}
+	return readCache(bytes.NewReader(reply.Cache), false)
+}
+
+// invalidateDaemon makes a running daemon read the data file again.
+func invalidateDaemon() {
+	var reply InvalidateReply
+	_ = callDaemon(context.Background(), rpcTimeout, "Invalidate", InvalidateArgs{}, &reply)
+}
+
+// RefreshDaemon makes the running daemon sync right away and waits until it
+// wrote the data file. It returns an error wrapping errDaemonNotRunning if no
+// daemon is listening.
+func RefreshDaemon(ctx context.Context, timeout time.Duration) error {
+	var reply RefreshReply
+	return callDaemon(ctx, timeout, "Refresh", RefreshArgs{}, &reply)
+}
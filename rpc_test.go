@@ -0,0 +1,168 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"net/rpc/jsonrpc"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// serveRPC serves the socket of the daemon until the end of the test.
+func serveRPC(t *testing.T, d *Daemon) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- d.ServeRPC(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Error(err)
+		}
+	})
+
+	// wait for the daemon to listen
+	for i := 0; i < 100; i++ {
+		if client, err := jsonrpc.Dial("unix", socketPath()); err == nil {
+			client.Close()
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("daemon socket not reachable")
+}
+
+// rpcCacheDir returns a directory for the data file whose path is short enough
+// for a unix socket, unlike the temporary directories of tests on macOS.
+func rpcCacheDir(t *testing.T) string {
+	dir, err := os.MkdirTemp("", "kong")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func TestServeRPC(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(rpcCacheDir(t), "kong"))
+
+	data := NewData()
+	data.Timestamp = time.Now().Unix()
+	data.Issues = Issues{
+		{Key: "KONG-1", Summary: "Add socket", StoryPoints: 3},
+		{Key: "KONG-2", Summary: "Add plugin", StoryPoints: 1},
+	}
+	if err := data.WriteFile(); err != nil {
+		t.Fatal(err)
+	}
+
+	serveRPC(t, &Daemon{refreshes: make(chan chan error)})
+	client, err := jsonrpc.Dial("unix", socketPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	var reply ListIssuesReply
+	args := ListIssuesArgs{Filter: "points > 2"}
+	if err := client.Call("Kong.ListIssues", args, &reply); err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for _, issue := range reply.Issues {
+		keys = append(keys, issue.Key)
+	}
+	if diff := cmp.Diff(keys, []string{"KONG-1"}); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestReadDaemonData(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(rpcCacheDir(t), "kong"))
+	session.data.reset()
+
+	data := NewData()
+	data.Issues = Issues{{Key: "KONG-1", Summary: "Add socket", Description: "Serve JSON-RPC"}}
+	if err := data.WriteFile(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readDaemonData(); !errors.Is(err, errDaemonNotRunning) {
+		t.Errorf("got %v, want: %v", err, errDaemonNotRunning)
+	}
+
+	serveRPC(t, &Daemon{refreshes: make(chan chan error)})
+	got, err := readDaemonData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the daemon leaves out the bodies which are read from the data file
+	if got.Issues[0].Description != "" {
+		t.Errorf("got %q, want no description", got.Issues[0].Description)
+	}
+	if err := got.LoadBodies(); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(got.Issues, data.Issues); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+
+	client, err := jsonrpc.Dial("unix", socketPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	var reply GetReply
+	if err := client.Call("Kong.Get", GetArgs{Bodies: true}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	withBodies, err := decodeCache(reply.Cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(withBodies.Issues, data.Issues); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+
+	// writing the data file drops the data held by the daemon
+	data.Issues = append(data.Issues, Issue{Key: "KONG-2", Summary: "Add fallback"})
+	if err := data.WriteFile(); err != nil {
+		t.Fatal(err)
+	}
+	got, err = ReadData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := got.LoadBodies(); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(got.Issues, data.Issues); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestRefreshDaemon(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(rpcCacheDir(t), "kong"))
+
+	ctx := context.Background()
+	if err := RefreshDaemon(ctx, time.Second); !errors.Is(err, errDaemonNotRunning) {
+		t.Errorf("got %v, want: %v", err, errDaemonNotRunning)
+	}
+
+	d := &Daemon{refreshes: make(chan chan error)}
+	serveRPC(t, d)
+	errSync := errors.New("sync failed")
+	go func() {
+		refreshed := <-d.refreshes
+		refreshed <- errSync
+	}()
+	err := RefreshDaemon(ctx, time.Second)
+	if err == nil || err.Error() != errSync.Error() {
+		t.Errorf("got %v, want: %v", err, errSync)
+	}
+}
@@ -0,0 +1,150 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+// defaultEpicTemplate is the epic template used unless configured otherwise.
+const defaultEpicTemplate = "feature"
+
+var (
+	errUnknownEpicTemplate = errors.New("epic template does not exist")
+	errEpicTemplateEmpty   = errors.New("epic template has no issues")
+	errEpicTemplateSummary = errors.New("epic template issue summary missing")
+)
+
+// EpicTemplate lists the child issues created for an epic with kong epics
+// scaffold. The summary and description of each issue are expanded like user
+// input, {{epic}} is the summary of the epic.
+type EpicTemplate struct {
+	Issues []EpicTemplateIssue `yaml:"issues"`
+}
+
+// EpicTemplateIssue is a child issue of an epic template. The issue type
+// defaults to the configured issue type.
+type EpicTemplateIssue struct {
+	Summary     string  `yaml:"summary"`
+	Description string  `yaml:"description"`
+	IssueType   string  `yaml:"issueType"`
+	StoryPoints float64 `yaml:"storyPoints"`
+}
+
+// featureEpicTemplate is the standard set of child issues of a feature.
+var featureEpicTemplate = EpicTemplate{
+	Issues: []EpicTemplateIssue{
+		{Summary: "Design {{epic}}"},
+		{Summary: "Implement {{epic}}"},
+		{Summary: "Test {{epic}}"},
+		{Summary: "Document {{epic}}"},
+	},
+}
+
+// EpicTemplate returns the epic template of the given name. The feature
+// template is built in unless it is configured.
+func (c Config) EpicTemplate(name string) (EpicTemplate, error) {
+	if template, ok := c.EpicTemplates[name]; ok {
+		return template, nil
+	}
+	if name == defaultEpicTemplate {
+		return featureEpicTemplate, nil
+	}
+	return EpicTemplate{}, fmt.Errorf("%w: %s", errUnknownEpicTemplate, name)
+}
+
+func (t EpicTemplate) validate() error {
+	if len(t.Issues) == 0 {
+		return errEpicTemplateEmpty
+	}
+	for _, issue := range t.Issues {
+		if issue.Summary == "" {
+			return errEpicTemplateSummary
+		}
+	}
+	return nil
+}
+
+// findSprint returns the sprint given as active, next or by ID.
+func (d Data) findSprint(name string) (Sprint, error) {
+	switch name {
+	case "active":
+		return d.Sprints.ActiveSprint()
+	case "next":
+		return d.Sprints.NextSprint()
+	}
+	id, err := strconv.Atoi(name)
+	if err != nil {
+		return Sprint{}, fmt.Errorf("%w: %s", errSprintMismatch, name)
+	}
+	for _, sprint := range d.Sprints {
+		if sprint.ID == id {
+			return sprint, nil
+		}
+	}
+	return Sprint{}, fmt.Errorf("%w: %s", errSprintMismatch, name)
+}
+
+// ScaffoldEpic creates the child issues of the epic template linked to the
+// epic. If sprint is not empty the issues are added to the sprint given as
+// active, next or by ID.
+func (j Jira) ScaffoldEpic(ctx context.Context, data Data, key, templateName, sprint string) ([]string, error) {
+	template, err := j.config.EpicTemplate(templateName)
+	if err != nil {
+		return nil, err
+	}
+	if err := template.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, templateName)
+	}
+	epic, err := data.LookupIssue(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := j.scaffoldIssues(data, epic, template, sprint)
+	if err != nil {
+		return nil, err
+	}
+	return j.CreateIssues(ctx, issues)
+}
+
+func (j Jira) scaffoldIssues(data Data, epic Issue, template EpicTemplate, sprint string) ([]*jira.Issue, error) {
+	sprintID := 0
+	if sprint != "" {
+		if err := data.CheckBoard(); err != nil {
+			return nil, err
+		}
+		s, err := data.findSprint(sprint)
+		if err != nil {
+			return nil, err
+		}
+		sprintID = s.ID
+	}
+
+	variables := NewVariables(data, j)
+	variables.Epic = epic.Summary
+	issues := make([]*jira.Issue, len(template.Issues))
+	for i, t := range template.Issues {
+		fields := map[string]any{fieldEpic: epic.Key}
+		if t.StoryPoints != 0 {
+			fields[fieldStoryPoints] = t.StoryPoints
+		}
+		unknowns, err := j.config.encodeFields(fields)
+		if err != nil {
+			return nil, err
+		}
+		if sprintID != 0 {
+			unknowns[j.config.CustomFields.Sprints] = sprintID
+		}
+		issueType := t.IssueType
+		if issueType == "" {
+			issueType = j.config.IssueType
+		}
+		summary := variables.Expand(t.Summary)
+		description := variables.Expand(t.Description)
+		issues[i] = j.newIssue(issueType, summary, description, unknowns)
+	}
+	return issues, nil
+}
@@ -0,0 +1,146 @@
+package kong
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEpicTemplate(t *testing.T) {
+	config := Config{
+		EpicTemplates: map[string]EpicTemplate{
+			"spike": {Issues: []EpicTemplateIssue{{Summary: "Research {{epic}}"}}},
+		},
+	}
+	tests := []struct {
+		name string
+		want EpicTemplate
+		err  error
+	}{
+		{name: "feature", want: featureEpicTemplate},
+		{name: "spike", want: config.EpicTemplates["spike"]},
+		{name: "bug", err: errUnknownEpicTemplate},
+	}
+	for _, tt := range tests {
+		got, err := config.EpicTemplate(tt.name)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("got %v, want: %v", err, tt.err)
+		}
+		if diff := cmp.Diff(got, tt.want); diff != "" {
+			t.Errorf("diff: %s", diff)
+		}
+	}
+}
+
+func TestEpicTemplateValidate(t *testing.T) {
+	tests := []struct {
+		template EpicTemplate
+		err      error
+	}{
+		{template: featureEpicTemplate},
+		{template: EpicTemplate{}, err: errEpicTemplateEmpty},
+		{template: EpicTemplate{Issues: []EpicTemplateIssue{{Description: "Body"}}}, err: errEpicTemplateSummary},
+	}
+	for _, tt := range tests {
+		if err := tt.template.validate(); !errors.Is(err, tt.err) {
+			t.Errorf("got %v, want: %v", err, tt.err)
+		}
+	}
+}
+
+func TestFindSprint(t *testing.T) {
+	data := NewData()
+	data.Sprints = Sprints{
+		{ID: 1, Name: "Sprint 1", State: "active"},
+		{ID: 2, Name: "Sprint 2", State: "future"},
+	}
+	tests := []struct {
+		name string
+		want int
+		err  error
+	}{
+		{name: "active", want: 1},
+		{name: "next", want: 2},
+		{name: "2", want: 2},
+		{name: "3", err: errSprintMismatch},
+		{name: "later", err: errSprintMismatch},
+	}
+	for _, tt := range tests {
+		got, err := data.findSprint(tt.name)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("got %v, want: %v", err, tt.err)
+		}
+		if got.ID != tt.want {
+			t.Errorf("got %v, want: %v", got.ID, tt.want)
+		}
+	}
+}
+
+func TestScaffoldIssues(t *testing.T) {
+	j := Jira{config: Config{
+		IssueType: "Story",
+		CustomFields: CustomFields{
+			Epics:       "customfield_10001",
+			Sprints:     "customfield_10002",
+			StoryPoints: "customfield_10003",
+		},
+	}}
+	data := NewData()
+	data.Sprints = Sprints{{ID: 7, Name: "Sprint 7", State: "active"}}
+	epic := Issue{Key: "KONG-1", Summary: "Search"}
+	template := EpicTemplate{Issues: []EpicTemplateIssue{
+		{Summary: "Design {{epic}}", Description: "Write down {{epic}}", IssueType: "Task"},
+		{Summary: "Implement {{epic}}", StoryPoints: 5},
+	}}
+
+	issues, err := j.scaffoldIssues(data, epic, template, "active")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("got %d issues, want: %d", len(issues), 2)
+	}
+	tests := []struct {
+		summary     string
+		description string
+		issueType   string
+		unknowns    map[string]any
+	}{
+		{
+			summary:     "Design Search",
+			description: "Write down Search",
+			issueType:   "Task",
+			unknowns:    map[string]any{"customfield_10001": "KONG-1", "customfield_10002": 7},
+		},
+		{
+			summary:   "Implement Search",
+			issueType: "Story",
+			unknowns:  map[string]any{"customfield_10001": "KONG-1", "customfield_10002": 7, "customfield_10003": 5.0},
+		},
+	}
+	for i, tt := range tests {
+		fields := issues[i].Fields
+		if fields.Summary != tt.summary {
+			t.Errorf("got %v, want: %v", fields.Summary, tt.summary)
+		}
+		if fields.Description != tt.description {
+			t.Errorf("got %v, want: %v", fields.Description, tt.description)
+		}
+		if fields.Type.Name != tt.issueType {
+			t.Errorf("got %v, want: %v", fields.Type.Name, tt.issueType)
+		}
+		if diff := cmp.Diff(map[string]any(fields.Unknowns), tt.unknowns); diff != "" {
+			t.Errorf("diff: %s", diff)
+		}
+	}
+}
+
+func TestScaffoldIssuesWithoutBoard(t *testing.T) {
+	data := NewData()
+	data.NoBoard = true
+	template := EpicTemplate{Issues: []EpicTemplateIssue{{Summary: "Design"}}}
+	if _, err := (Jira{}).scaffoldIssues(data, Issue{Key: "KONG-1"}, template, "active"); !errors.Is(err, ErrNoBoard) {
+		t.Errorf("got %v, want: %v", err, ErrNoBoard)
+	}
+}
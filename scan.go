@@ -0,0 +1,45 @@
+package kong
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// ScanIssueKeys finds all issue keys in the text, for instance a Slack thread,
+// and returns the cached issues in the order of their first occurrence as
+// well as the keys which are not cached.
+func (d Data) ScanIssueKeys(s string) (Issues, []string) {
+	var (
+		issues  Issues
+		unknown []string
+	)
+	for _, key := range findIssueKeys(s, "") {
+		issue, ok := d.IssueByKey[key]
+		if !ok {
+			issue, ok = d.SprintIssues.find(key)
+		}
+		if !ok {
+			issue, ok = d.Epics.find(key)
+		}
+		if !ok {
+			unknown = append(unknown, key)
+			continue
+		}
+		issues = append(issues, issue)
+	}
+	return issues, unknown
+}
+
+// PrintScan writes the status and summary of the found issues followed by the
+// keys which are not cached.
+func PrintScan(output io.Writer, issues Issues, unknown []string) {
+	w := tabwriter.NewWriter(output, 1, 1, 1, ' ', 0)
+	for _, issue := range issues {
+		fmt.Fprintf(w, "%s\t-\t%s\t-\t%s\n", issue.Key, issue.Status.Name, issue.Summary)
+	}
+	for _, key := range unknown {
+		fmt.Fprintf(w, "%s\t-\t%s\t-\t%s\n", key, "?", "not cached")
+	}
+	w.Flush()
+}
@@ -0,0 +1,26 @@
+package kong
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestScanIssueKeys(t *testing.T) {
+	data := NewData()
+	data.IssueByKey["KONG-1"] = Issue{Key: "KONG-1", Summary: "Add scan", Status: Status{Name: "To Do"}}
+	data.Epics = Issues{{Key: "KONG-2", Summary: "Triage", Status: Status{Name: "In Progress"}}}
+
+	text := "can someone look at KONG-2? it blocks KONG-1 and OPS-7, see KONG-1"
+	issues, unknown := data.ScanIssueKeys(text)
+
+	var buf bytes.Buffer
+	PrintScan(&buf, issues, unknown)
+	want := "KONG-2 - In Progress - Triage\n" +
+		"KONG-1 - To Do       - Add scan\n" +
+		"OPS-7  - ?           - not cached\n"
+	if diff := cmp.Diff(buf.String(), want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
@@ -0,0 +1,93 @@
+package kong
+
+import (
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	maxRefreshRate   = 5 * time.Minute
+	quietRefreshRate = 15 * time.Minute
+
+	// responseTimeFactor keeps the daemon idle for a multiple of the time
+	// Jira took to respond to avoid piling up load on a slow instance
+	responseTimeFactor = 5
+)
+
+// rateLimitedUntil holds the Unix time in nanoseconds until which Jira asked
+// to pause requests through the Retry-After header.
+var rateLimitedUntil int64
+
+// rateLimitTransport records rate-limit responses returned by Jira.
+type rateLimitTransport struct {
+	transport http.RoundTripper
+}
+
+func (t rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+		return resp, err
+	}
+	retryAfter := time.Minute
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		retryAfter = time.Duration(seconds) * time.Second
+	}
+	atomic.StoreInt64(&rateLimitedUntil, time.Now().Add(retryAfter).UnixNano())
+	return resp, err
+}
+
+// retryAfter returns how long Jira asked to pause requests.
+func retryAfter(now time.Time) time.Duration {
+	until := time.Unix(0, atomic.LoadInt64(&rateLimitedUntil))
+	if until.Before(now) {
+		return 0
+	}
+	return until.Sub(now)
+}
+
+// scheduler adapts the refresh interval of the daemon to Jira response times,
+// rate limits and quiet hours.
+type scheduler struct {
+	interval   time.Duration
+	quietHours QuietHours
+}
+
+func newScheduler(quietHours QuietHours) *scheduler {
+	return &scheduler{
+		interval:   refreshRate,
+		quietHours: quietHours,
+	}
+}
+
+// next computes the interval until the next refresh based on how long the
+// last refresh took and whether it failed.
+func (s *scheduler) next(now time.Time, elapsed time.Duration, err error) time.Duration {
+	switch {
+	case retryAfter(now) > 0:
+		s.interval = maxDuration(retryAfter(now), s.interval*2)
+	case err != nil:
+		s.interval *= 2
+	default:
+		s.interval = maxDuration(refreshRate, elapsed*responseTimeFactor)
+	}
+	if s.interval > maxRefreshRate {
+		s.interval = maxRefreshRate
+	}
+	if s.quietHours.Contains(now) {
+		return maxDuration(s.interval, quietRefreshRate)
+	}
+	return s.interval
+}
+
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
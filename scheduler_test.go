@@ -0,0 +1,57 @@
+package kong
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSchedulerNext(t *testing.T) {
+	noon := time.Date(2023, time.July, 3, 12, 0, 0, 0, time.UTC)
+	midnight := time.Date(2023, time.July, 3, 0, 0, 0, 0, time.UTC)
+	quietHours := QuietHours{Start: 22, End: 6}
+
+	t.Run("fast-response", func(t *testing.T) {
+		s := newScheduler(quietHours)
+		if got := s.next(noon, time.Second, nil); got != refreshRate {
+			t.Errorf("got %v, want: %v", got, refreshRate)
+		}
+	})
+
+	t.Run("slow-response", func(t *testing.T) {
+		s := newScheduler(quietHours)
+		if got := s.next(noon, 4*time.Second, nil); got != 20*time.Second {
+			t.Errorf("got %v, want: %v", got, 20*time.Second)
+		}
+	})
+
+	t.Run("backoff-on-error", func(t *testing.T) {
+		s := newScheduler(quietHours)
+		s.next(noon, time.Second, errors.New("failed"))
+		if got := s.next(noon, time.Second, errors.New("failed")); got != 4*refreshRate {
+			t.Errorf("got %v, want: %v", got, 4*refreshRate)
+		}
+		if got := s.next(noon, time.Second, nil); got != refreshRate {
+			t.Errorf("got %v, want: %v", got, refreshRate)
+		}
+	})
+
+	t.Run("rate-limited", func(t *testing.T) {
+		atomic.StoreInt64(&rateLimitedUntil, noon.Add(time.Minute).UnixNano())
+		t.Cleanup(func() {
+			atomic.StoreInt64(&rateLimitedUntil, 0)
+		})
+		s := newScheduler(quietHours)
+		if got := s.next(noon, time.Second, errRateLimited); got != time.Minute {
+			t.Errorf("got %v, want: %v", got, time.Minute)
+		}
+	})
+
+	t.Run("quiet-hours", func(t *testing.T) {
+		s := newScheduler(quietHours)
+		if got := s.next(midnight, time.Second, nil); got != quietRefreshRate {
+			t.Errorf("got %v, want: %v", got, quietRefreshRate)
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+// greenhopperSprint is the custom type of the sprint field of Jira Software.
+// Its schema declares an array but updates take the bare sprint ID.
+const greenhopperSprint = "com.pyxis.greenhopper.jira:gh-sprint"
+
+const (
+	sprintShapeArray  = "array"
+	sprintShapeScalar = "scalar"
+)
+
+// fieldSchemas caches which fields take an array on update. Instances differ
+// in the custom type of the sprint field and some reject a bare value.
+type fieldSchemas struct {
+	mu     sync.Mutex
+	arrays map[string]bool
+}
+
+// isArrayField reports whether the field takes an array on update. The schema
+// of all fields is fetched on first use; if that fails the field is assumed to
+// be a scalar as before and the schema is fetched again on the next use.
+func (j Jira) isArrayField(ctx context.Context, id string) bool {
+	if j.schemas == nil {
+		return false
+	}
+	j.schemas.mu.Lock()
+	defer j.schemas.mu.Unlock()
+	if j.schemas.arrays == nil {
+		fields, resp, err := j.client.Field.GetListWithContext(ctx)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, fmt.Errorf("GetFields: %w", parseResponseError(resp)))
+			return false
+		}
+		j.schemas.arrays = arrayFields(fields)
+	}
+	return j.schemas.arrays[id]
+}
+
+// arrayFields returns the fields which take an array on update. Custom types
+// which are known to take a bare value are excluded.
+func arrayFields(fields []jira.Field) map[string]bool {
+	arrays := make(map[string]bool)
+	for _, field := range fields {
+		if field.Schema.Type == "array" && field.Schema.Custom != greenhopperSprint {
+			arrays[field.ID] = true
+		}
+	}
+	return arrays
+}
+
+// isArraySprint reports whether the sprint field takes an array on update. The
+// configured sprint shape takes precedence over the field schema.
+func (j Jira) isArraySprint(ctx context.Context) bool {
+	switch j.config.SprintShape {
+	case sprintShapeArray:
+		return true
+	case sprintShapeScalar:
+		return false
+	}
+	return j.isArrayField(ctx, j.config.CustomFields.Sprints)
+}
+
+// sprintValue returns the value to set the sprint field to in the shape of the
+// field. A sprint ID of 0 clears the field.
+func sprintValue(id int, array bool) interface{} {
+	switch {
+	case array && id == 0:
+		return []int{}
+	case array:
+		return []int{id}
+	case id == 0:
+		return nil
+	}
+	return id
+}
+
+func (j Jira) sprintValue(ctx context.Context, id int) interface{} {
+	return sprintValue(id, j.isArraySprint(ctx))
+}
+
+// withSprintShape returns a copy of the issue with the sprint field converted
+// to the shape of the field.
+func (j Jira) withSprintShape(ctx context.Context, issue *jira.Issue) *jira.Issue {
+	field := j.config.CustomFields.Sprints
+	if issue.Fields == nil {
+		return issue
+	}
+	id, ok := issue.Fields.Unknowns[field].(int)
+	if !ok || !j.isArraySprint(ctx) {
+		return issue
+	}
+	fields := *issue.Fields
+	fields.Unknowns = make(map[string]interface{}, len(issue.Fields.Unknowns))
+	for k, v := range issue.Fields.Unknowns {
+		fields.Unknowns[k] = v
+	}
+	fields.Unknowns[field] = sprintValue(id, true)
+	shaped := *issue
+	shaped.Fields = &fields
+	return &shaped
+}
@@ -0,0 +1,131 @@
+package kong
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestArrayFields(t *testing.T) {
+	fields := []jira.Field{
+		{ID: "customfield_10001", Schema: jira.FieldSchema{Type: "array", Items: "json"}},
+		{ID: "customfield_10002", Schema: jira.FieldSchema{Type: "number"}},
+		{ID: "customfield_10003", Schema: jira.FieldSchema{Type: "array", Items: "json", Custom: greenhopperSprint}},
+	}
+	want := map[string]bool{"customfield_10001": true}
+	if diff := cmp.Diff(arrayFields(fields), want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestWithSprintShape(t *testing.T) {
+	tests := []struct {
+		name   string
+		arrays map[string]bool
+		shape  string
+		want   interface{}
+	}{
+		{
+			name:   "scalar",
+			arrays: map[string]bool{},
+			want:   7,
+		},
+		{
+			name:   "array",
+			arrays: map[string]bool{"customfield_10001": true},
+			want:   []int{7},
+		},
+		{
+			name:   "configured-array",
+			arrays: map[string]bool{},
+			shape:  sprintShapeArray,
+			want:   []int{7},
+		},
+		{
+			name:   "configured-scalar",
+			arrays: map[string]bool{"customfield_10001": true},
+			shape:  sprintShapeScalar,
+			want:   7,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := Jira{
+				config: Config{
+					SprintShape:  tt.shape,
+					CustomFields: CustomFields{Sprints: "customfield_10001"},
+				},
+				schemas: &fieldSchemas{arrays: tt.arrays},
+			}
+			issue := &jira.Issue{
+				Fields: &jira.IssueFields{
+					Unknowns: map[string]interface{}{"customfield_10001": 7},
+				},
+			}
+			got := j.withSprintShape(context.Background(), issue)
+			if diff := cmp.Diff(got.Fields.Unknowns["customfield_10001"], tt.want); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+			// the issue passed in is left untouched
+			if issue.Fields.Unknowns["customfield_10001"] != 7 {
+				t.Errorf("got %v, want: %v", issue.Fields.Unknowns["customfield_10001"], 7)
+			}
+		})
+	}
+}
+
+func TestSprintValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		id    int
+		array bool
+		want  interface{}
+	}{
+		{name: "scalar", id: 7, want: 7},
+		{name: "scalar-clear", id: 0, want: nil},
+		{name: "array", id: 7, array: true, want: []int{7}},
+		{name: "array-clear", id: 0, array: true, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(sprintValue(tt.id, tt.array), tt.want); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestIsArrayFieldRetriesFailedFetch(t *testing.T) {
+	var requests int
+	client := newTestJira(t, func(req *http.Request) (*http.Response, error) {
+		requests++
+		if requests == 1 {
+			return &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Body:       io.NopCloser(strings.NewReader(`{}`)),
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`[{"id": "customfield_10001", "schema": {"type": "array", "items": "json"}}]`)),
+		}, nil
+	})
+	j := Jira{client: client, schemas: &fieldSchemas{}}
+
+	if j.isArrayField(context.Background(), "customfield_10001") {
+		t.Errorf("got %v, want: %v", true, false)
+	}
+	for i := 0; i < 2; i++ {
+		if !j.isArrayField(context.Background(), "customfield_10001") {
+			t.Errorf("got %v, want: %v", false, true)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("got %v, want: %v", requests, 2)
+	}
+}
@@ -0,0 +1,93 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SearchParents returns the cached epics and initiatives whose key or summary
+// contains the text, ignoring case. Archived epics are included since they
+// can still be referenced by key.
+func (d Data) SearchParents(text string) Issues {
+	text = strings.ToLower(text)
+	var matches Issues
+	for _, list := range []Issues{d.Epics, d.Initiatives} {
+		for _, issue := range list {
+			if strings.Contains(strings.ToLower(issue.Key), text) || strings.Contains(strings.ToLower(issue.Summary), text) {
+				matches = append(matches, issue)
+			}
+		}
+	}
+	return matches
+}
+
+// Search returns the issues whose key or summary contains each of the words,
+// ignoring case. If no issue contains all of them, issues are matched fuzzily
+// by summaries containing the letters of each word in order.
+func (i Issues) Search(words []string) Issues {
+	if len(words) == 0 {
+		return i
+	}
+	for _, match := range []func(issue Issue, word string) bool{containsWord, fuzzyContainsWord} {
+		var matches Issues
+		for _, issue := range i {
+			if matchesAll(issue, words, match) {
+				matches = append(matches, issue)
+			}
+		}
+		if len(matches) > 0 {
+			return matches
+		}
+	}
+	return nil
+}
+
+func matchesAll(issue Issue, words []string, match func(issue Issue, word string) bool) bool {
+	for _, word := range words {
+		if !match(issue, strings.ToLower(word)) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsWord(issue Issue, word string) bool {
+	return strings.Contains(strings.ToLower(issue.Key), word) || strings.Contains(strings.ToLower(issue.Summary), word)
+}
+
+// fuzzyContainsWord reports whether the summary contains the letters of the
+// word in order, for instance auth in authorization or athn in authentication.
+func fuzzyContainsWord(issue Issue, word string) bool {
+	summary := strings.ToLower(issue.Summary)
+	for _, r := range word {
+		i := strings.IndexRune(summary, r)
+		if i < 0 {
+			return false
+		}
+		summary = summary[i+len(string(r)):]
+	}
+	return true
+}
+
+// SearchParents queries the epics and initiatives of the project whose summary
+// matches the text, including epics assigned to other users.
+func (j Jira) SearchParents(ctx context.Context, project, text string) (Issues, error) {
+	conditions := []string{
+		"project = " + project,
+		"issueType IN (Epic, Initiative)",
+		"summary ~ " + jqlString(text),
+		"status != Closed",
+	}
+	jql := strings.Join(conditions, " AND ")
+	issues, err := j.search(ctx, jql)
+	if err != nil {
+		return nil, fmt.Errorf("SearchParents: %w", err)
+	}
+	return issues, nil
+}
+
+// jqlString quotes s as JQL string literal.
+func jqlString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
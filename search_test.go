@@ -0,0 +1,78 @@
+package kong
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDataSearchParents(t *testing.T) {
+	data := Data{
+		Epics: Issues{
+			{Key: "KONG-1", Summary: "Editor integrations"},
+			{Key: "KONG-2", Summary: "Daemon scheduling"},
+		},
+		Initiatives: Issues{
+			{Key: "KONG-3", Summary: "Developer experience"},
+		},
+	}
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{text: "editor", want: []string{"KONG-1"}},
+		{text: "IN", want: []string{"KONG-1", "KONG-2"}},
+		{text: "kong-3", want: []string{"KONG-3"}},
+		{text: "release"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			var got []string
+			for _, issue := range data.SearchParents(tt.text) {
+				got = append(got, issue.Key)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestJQLString(t *testing.T) {
+	got := jqlString(`say "hi" \o/`)
+	want := `"say \"hi\" \\o/"`
+	if got != want {
+		t.Errorf("got %v, want: %v", got, want)
+	}
+}
+
+func TestIssuesSearch(t *testing.T) {
+	issues := Issues{
+		{Key: "KONG-1", Summary: "Refresh auth tokens"},
+		{Key: "KONG-2", Summary: "Authorization header for bearer tokens"},
+		{Key: "KONG-3", Summary: "Daemon scheduling"},
+	}
+	tests := []struct {
+		name  string
+		words []string
+		want  []string
+	}{
+		{name: "none", want: []string{"KONG-1", "KONG-2", "KONG-3"}},
+		{name: "substring", words: []string{"AUTH"}, want: []string{"KONG-1", "KONG-2"}},
+		{name: "all words", words: []string{"auth", "bearer"}, want: []string{"KONG-2"}},
+		{name: "key", words: []string{"kong-3"}, want: []string{"KONG-3"}},
+		{name: "fuzzy", words: []string{"dmnsched"}, want: []string{"KONG-3"}},
+		{name: "no match", words: []string{"release"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, issue := range issues.Search(tt.words) {
+				got = append(got, issue.Key)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+)
+
+const serviceLabel = "com.github.konradreiche.kong"
+
+var errServiceUnsupported = errors.New("service management not supported on this platform")
+
+const systemdUnit = `[Unit]
+Description=Kong
+After=network.target
+
+[Service]
+ExecStart=%s daemon
+Restart=on-failure
+RestartSec=5s
+
+[Install]
+WantedBy = default.target
+`
+
+const launchdPlist = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+    <key>Label</key>
+    <string>` + serviceLabel + `</string>
+    <key>ProgramArguments</key>
+    <array>
+        <string>%s</string>
+        <string>daemon</string>
+    </array>
+    <key>RunAtLoad</key>
+    <true/>
+</dict>
+</plist>
+`
+
+// Service manages the daemon as a user service of the operating system
+// service manager: systemd on Linux, launchd on macOS and a scheduled task on
+// Windows. Every platform is described by the same set of commands so that
+// installing, starting and stopping the daemon share one code path.
+type Service struct {
+	// path and definition describe the service file, the path is empty for
+	// service managers without one
+	path       string
+	definition string
+
+	install   [][]string
+	uninstall [][]string
+	start     [][]string
+	stop      [][]string
+	status    [][]string
+}
+
+// NewService returns the service of the current platform running the daemon
+// of the kong executable currently running.
+func NewService() (Service, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return Service{}, fmt.Errorf("NewService: %w", err)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return Service{}, fmt.Errorf("NewService: %w", err)
+	}
+	return newService(runtime.GOOS, executable, home)
+}
+
+func newService(goos, executable, home string) (Service, error) {
+	switch goos {
+	case "linux":
+		path := filepath.Join(home, ".config", "systemd", "user", "kong.service")
+		return Service{
+			path:       path,
+			definition: fmt.Sprintf(systemdUnit, executable),
+			install: [][]string{
+				{"systemctl", "--user", "daemon-reload"},
+				{"systemctl", "--user", "enable", "kong.service"},
+			},
+			uninstall: [][]string{
+				{"systemctl", "--user", "disable", "--now", "kong.service"},
+			},
+			start:  [][]string{{"systemctl", "--user", "restart", "kong.service"}},
+			stop:   [][]string{{"systemctl", "--user", "stop", "kong.service"}},
+			status: [][]string{{"systemctl", "--user", "status", "kong.service"}},
+		}, nil
+	case "darwin":
+		path := filepath.Join(home, "Library", "LaunchAgents", serviceLabel+".plist")
+		return Service{
+			path:       path,
+			definition: fmt.Sprintf(launchdPlist, executable),
+			uninstall:  [][]string{{"launchctl", "unload", "-w", path}},
+			start: [][]string{
+				{"launchctl", "unload", "-w", path},
+				{"launchctl", "load", "-w", path},
+			},
+			stop:   [][]string{{"launchctl", "unload", "-w", path}},
+			status: [][]string{{"launchctl", "list", serviceLabel}},
+		}, nil
+	case "windows":
+		return Service{
+			install: [][]string{{
+				"schtasks", "/Create", "/F", "/SC", "ONLOGON", "/TN", "kong",
+				"/TR", fmt.Sprintf("\"%s\" daemon", executable),
+			}},
+			uninstall: [][]string{{"schtasks", "/Delete", "/F", "/TN", "kong"}},
+			start:     [][]string{{"schtasks", "/Run", "/TN", "kong"}},
+			stop:      [][]string{{"schtasks", "/End", "/TN", "kong"}},
+			status:    [][]string{{"schtasks", "/Query", "/TN", "kong"}},
+		}, nil
+	}
+	return Service{}, fmt.Errorf("%w: %s", errServiceUnsupported, goos)
+}
+
+// Install writes the service definition and registers the service to start
+// the daemon on login.
+func (s Service) Install(ctx context.Context) error {
+	if s.path != "" {
+		if err := os.MkdirAll(filepath.Dir(s.path), os.ModePerm); err != nil {
+			return err
+		}
+		if err := os.WriteFile(s.path, []byte(s.definition), 0o644); err != nil {
+			return err
+		}
+	}
+	return s.run(ctx, s.install, false)
+}
+
+// Uninstall stops the daemon and removes the service.
+func (s Service) Uninstall(ctx context.Context) error {
+	err := s.run(ctx, s.uninstall, false)
+
+	// remove the service file even if the service was not loaded
+	if s.path != "" {
+		if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// Start starts or restarts the daemon.
+func (s Service) Start(ctx context.Context) error {
+	// unloading a service which is not loaded fails on macOS
+	return s.run(ctx, s.start, true)
+}
+
+// Stop stops the daemon.
+func (s Service) Stop(ctx context.Context) error {
+	return s.run(ctx, s.stop, false)
+}
+
+// Status prints the status reported by the service manager.
+func (s Service) Status(ctx context.Context) error {
+	return s.run(ctx, s.status, false)
+}
+
+// run executes the commands in order. If ignoreErrors is set failures are
+// ignored for all but the last command.
+func (s Service) run(ctx context.Context, commands [][]string, ignoreErrors bool) error {
+	for i, args := range commands {
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		err := cmd.Run()
+		if err != nil && (!ignoreErrors || i == len(commands)-1) {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+	}
+	return nil
+}
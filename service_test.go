@@ -0,0 +1,34 @@
+package kong
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewService(t *testing.T) {
+	for _, goos := range []string{"linux", "darwin", "windows"} {
+		t.Run(goos, func(t *testing.T) {
+			service, err := newService(goos, "/opt/kong/bin/kong", "/home/kong")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if service.path != "" && !strings.HasPrefix(service.path, filepath.Join("/home/kong")) {
+				t.Errorf("got %s, want: path in home directory", service.path)
+			}
+			definition := service.definition + fmt.Sprint(service.install)
+			if !strings.Contains(definition, "/opt/kong/bin/kong") {
+				t.Errorf("got %s, want: executable in service definition", definition)
+			}
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := newService("plan9", "/opt/kong/bin/kong", "/home/kong")
+		if !errors.Is(err, errServiceUnsupported) {
+			t.Errorf("got %v, want: %v", err, errServiceUnsupported)
+		}
+	})
+}
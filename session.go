@@ -0,0 +1,75 @@
+package kong
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// session holds the state of a single process. Commands, the editor and the
+// Jira client each load the configuration and the data on their own, the
+// session ensures that every file is only read and decoded once as long as it
+// does not change on disk and that the Jira client is only constructed once per
+// version of the configuration.
+var session struct {
+	mu     sync.Mutex
+	config cachedFile[Config]
+	data   cachedFile[Data]
+	state  cachedFile[State]
+
+	// jira is keyed on the configuration file so that a long-running
+	// process picks up changes and retries after a failed construction
+	jiraMu sync.Mutex
+	jira   cachedFile[Jira]
+
+	profileOnce sync.Once
+	profile     string
+
+	// daemon is set once by the daemon process before it serves the socket
+	// so that it reads and writes the data file rather than calling itself
+	daemon bool
+}
+
+// fileVersion identifies the content of a file without reading it.
+type fileVersion struct {
+	path    string
+	modTime time.Time
+	size    int64
+}
+
+// cachedFile is the decoded content of a file along with the version it was
+// decoded from.
+type cachedFile[T any] struct {
+	version fileVersion
+	value   T
+	ok      bool
+}
+
+// load returns the cached value if the file is unchanged and otherwise calls
+// read to decode the file again. Errors are not cached.
+func (c *cachedFile[T]) load(path string, read func() (T, error)) (T, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		c.reset()
+		return read()
+	}
+	version := fileVersion{
+		path:    path,
+		modTime: info.ModTime(),
+		size:    info.Size(),
+	}
+	if c.ok && c.version == version {
+		return c.value, nil
+	}
+	value, err := read()
+	if err != nil {
+		c.reset()
+		return value, err
+	}
+	c.version, c.value, c.ok = version, value, true
+	return value, nil
+}
+
+func (c *cachedFile[T]) reset() {
+	*c = cachedFile[T]{}
+}
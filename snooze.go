@@ -0,0 +1,72 @@
+package kong
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var errSnoozeDuration = errors.New("snooze duration must be a number of hours, days or weeks, e.g. 3d")
+
+var snoozePattern = regexp.MustCompile(`^([1-9][0-9]*)([hdw])$`)
+
+// snoozeUntil returns the time a snooze of the given duration, for instance
+// 12h, 3d or 2w, started at now expires.
+func snoozeUntil(now time.Time, duration string) (time.Time, error) {
+	match := snoozePattern.FindStringSubmatch(duration)
+	if match == nil {
+		return time.Time{}, fmt.Errorf("%w: %s", errSnoozeDuration, duration)
+	}
+	n, err := strconv.Atoi(match[1])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s", errSnoozeDuration, duration)
+	}
+	switch match[2] {
+	case "h":
+		return now.Add(time.Duration(n) * time.Hour), nil
+	case "d":
+		return now.AddDate(0, 0, n), nil
+	}
+	return now.AddDate(0, 0, 7*n), nil
+}
+
+// Snooze hides the issue from listings for the given duration without
+// changing it in Jira and returns when the snooze expires. Expired snoozes
+// are removed.
+func (d *Data) Snooze(key, duration string, now time.Time) (time.Time, error) {
+	if _, ok := d.snapshot()[key]; !ok {
+		return time.Time{}, fmt.Errorf("%w: %s", errUnknownIssue, key)
+	}
+	until, err := snoozeUntil(now, duration)
+	if err != nil {
+		return time.Time{}, err
+	}
+	snoozed := map[string]time.Time{key: until}
+	for k, t := range d.Snoozed {
+		if k != key && t.After(now) {
+			snoozed[k] = t
+		}
+	}
+	d.Snoozed = snoozed
+	return until, nil
+}
+
+// Unsnooze reveals previously snoozed issues again.
+func (d *Data) Unsnooze(keys ...string) {
+	for _, key := range keys {
+		delete(d.Snoozed, key)
+	}
+}
+
+// WithoutSnoozed returns the issues which are not snoozed at the given time.
+func (d Data) WithoutSnoozed(issues Issues, now time.Time) Issues {
+	result := make(Issues, 0, len(issues))
+	for _, issue := range issues {
+		if until, ok := d.Snoozed[issue.Key]; !ok || !until.After(now) {
+			result = append(result, issue)
+		}
+	}
+	return result
+}
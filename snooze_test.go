@@ -0,0 +1,69 @@
+package kong
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSnoozeUntil(t *testing.T) {
+	now := time.Date(2024, 3, 13, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		duration string
+		want     time.Time
+		wantErr  error
+	}{
+		{duration: "12h", want: now.Add(12 * time.Hour)},
+		{duration: "3d", want: time.Date(2024, 3, 16, 15, 0, 0, 0, time.UTC)},
+		{duration: "2w", want: time.Date(2024, 3, 27, 15, 0, 0, 0, time.UTC)},
+		{duration: "0d", wantErr: errSnoozeDuration},
+		{duration: "3", wantErr: errSnoozeDuration},
+		{duration: "1m", wantErr: errSnoozeDuration},
+	}
+	for _, tt := range tests {
+		t.Run(tt.duration, func(t *testing.T) {
+			got, err := snoozeUntil(now, tt.duration)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want: %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("got %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDataSnooze(t *testing.T) {
+	now := time.Date(2024, 3, 13, 15, 0, 0, 0, time.UTC)
+	issues := Issues{{Key: "KONG-1"}, {Key: "KONG-2"}, {Key: "KONG-3"}}
+	data := Data{Issues: issues}
+	if _, err := data.Snooze("KONG-1", "1d", now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := data.Snooze("KONG-2", "3d", now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := data.Snooze("KONG-4", "3d", now); !errors.Is(err, errUnknownIssue) {
+		t.Errorf("got %v, want: %v", err, errUnknownIssue)
+	}
+	keys := func(issues Issues) []string {
+		var keys []string
+		for _, issue := range issues {
+			keys = append(keys, issue.Key)
+		}
+		return keys
+	}
+	if diff := cmp.Diff(keys(data.WithoutSnoozed(issues, now)), []string{"KONG-3"}); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	later := now.AddDate(0, 0, 2)
+	if diff := cmp.Diff(keys(data.WithoutSnoozed(issues, later)), []string{"KONG-1", "KONG-3"}); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	data.Unsnooze("KONG-2")
+	if diff := cmp.Diff(keys(data.WithoutSnoozed(issues, now)), []string{"KONG-2", "KONG-3"}); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
@@ -0,0 +1,96 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+const standupTimeLayout = "2006-01-02T15-04-05"
+
+// Standup is a previously generated standup message.
+type Standup struct {
+	Type      string
+	CreatedAt time.Time
+	Text      string
+}
+
+func standupDir() string {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "kong", "standups")
+	}
+	if runtime.GOOS == "windows" {
+		if dir, err := os.UserConfigDir(); err == nil {
+			return filepath.Join(dir, "kong", "standups")
+		}
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".local", "share", "kong", "standups")
+}
+
+// saveStandup archives the rendered standup message.
+func saveStandup(standupType string, text []byte, createdAt time.Time) error {
+	dir := standupDir()
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	filename := createdAt.Format(standupTimeLayout) + "-" + standupType
+	return os.WriteFile(filepath.Join(dir, filename), text, 0o600)
+}
+
+// ListStandups returns all archived standups created after the given time,
+// most recent first.
+func ListStandups(since time.Time) ([]Standup, error) {
+	entries, err := os.ReadDir(standupDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	standups := make([]Standup, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if len(name) <= len(standupTimeLayout) {
+			continue
+		}
+		createdAt, err := time.ParseInLocation(standupTimeLayout, name[:len(standupTimeLayout)], time.Local)
+		if err != nil || createdAt.Before(since) {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(standupDir(), name))
+		if err != nil {
+			return nil, err
+		}
+		standups = append(standups, Standup{
+			Type:      strings.TrimPrefix(name[len(standupTimeLayout):], "-"),
+			CreatedAt: createdAt,
+			Text:      string(b),
+		})
+	}
+	sort.Slice(standups, func(i, j int) bool {
+		return standups[i].CreatedAt.After(standups[j].CreatedAt)
+	})
+	return standups, nil
+}
+
+// Copy copies the standup message to the clipboard.
+func (s Standup) Copy(ctx context.Context, clipboard Clipboard) error {
+	return clipboard.Copy(ctx, []byte(s.Text))
+}
+
+// PrintStandups writes the standups with their index and creation time.
+func PrintStandups(w io.Writer, standups []Standup) {
+	for i, standup := range standups {
+		createdAt := standup.CreatedAt.Format("Mon 2006/1/2 15:04")
+		fmt.Fprintf(w, "# %d - %s - %s\n\n", i+1, createdAt, standup.Type)
+		fmt.Fprintln(w, strings.TrimRight(standup.Text, "\n"))
+		fmt.Fprintln(w)
+	}
+}
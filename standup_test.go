@@ -0,0 +1,63 @@
+package kong
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListStandups(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	if standups, err := ListStandups(time.Time{}); err != nil || standups != nil {
+		t.Fatalf("got %v, %v, want no standups", standups, err)
+	}
+
+	monday := time.Date(2024, 3, 11, 9, 30, 0, 0, time.Local)
+	for i, text := range []string{"Last week\n", "Yesterday\n", "Today\n"} {
+		createdAt := monday.AddDate(0, 0, i)
+		if err := saveStandup("daily", []byte(text), createdAt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := ListStandups(monday.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Standup{
+		{Type: "daily", CreatedAt: monday.AddDate(0, 0, 2), Text: "Today\n"},
+		{Type: "daily", CreatedAt: monday.AddDate(0, 0, 1), Text: "Yesterday\n"},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+
+	var b bytes.Buffer
+	PrintStandups(&b, got[:1])
+	wantOutput := "# 1 - Wed 2024/3/13 09:30 - daily\n\nToday\n\n"
+	if diff := cmp.Diff(b.String(), wantOutput); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+type recordingClipboard struct {
+	copied []byte
+}
+
+func (c *recordingClipboard) Copy(ctx context.Context, b []byte) error {
+	c.copied = b
+	return nil
+}
+
+func TestStandupCopy(t *testing.T) {
+	var clipboard recordingClipboard
+	standup := Standup{Type: "daily", Text: "Today\n"}
+	if err := standup.Copy(context.Background(), &clipboard); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(clipboard.copied); got != standup.Text {
+		t.Errorf("got %q, want: %q", got, standup.Text)
+	}
+}
@@ -0,0 +1,107 @@
+package kong
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gofrs/flock"
+)
+
+// State holds what users decide locally, like archived epics, pinned and
+// snoozed issues, epic changes and worklogs the daemon has not synced yet, the
+// links to GitHub issues or whether task file items were done at their last
+// sync. It is kept apart from the data file since the daemon rewrites the data
+// file on every sync, which would undo changes made in the meantime, and kong
+// cache reset removes it.
+type State struct {
+	ArchivedEpics    map[string]bool            `json:"archivedEpics,omitempty"`
+	Pinned           []string                   `json:"pinned,omitempty"`
+	Snoozed          map[string]time.Time       `json:"snoozed,omitempty"`
+	LastIssueCreated string                     `json:"lastIssueCreated,omitempty"`
+	LastReviewer     string                     `json:"lastReviewer,omitempty"`
+	GitHubIssues     map[string]string          `json:"githubIssues,omitempty"`
+	GitHubSynced     map[string]GitHubSyncState `json:"githubSynced,omitempty"`
+	EpicChanges      map[string]EpicChange      `json:"epicChanges,omitempty"`
+	LoggedWorklogs   Worklogs                   `json:"loggedWorklogs,omitempty"`
+	TaskItems        map[string]bool            `json:"taskItems,omitempty"`
+}
+
+// EpicChange records moving an issue to another epic, or out of its epic if
+// the epic key is empty, until a sync started after the change.
+type EpicChange struct {
+	EpicKey string    `json:"epicKey,omitempty"`
+	At      time.Time `json:"at"`
+}
+
+func statePath() string {
+	return cachePath() + ".state"
+}
+
+func stateLockPath() string {
+	return cachePath() + ".state.lock"
+}
+
+func readState() (State, error) {
+	var state State
+	b, err := os.ReadFile(statePath())
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	if err := json.Unmarshal(b, &state); err != nil {
+		return state, fmt.Errorf("readState: %w", err)
+	}
+	return state, nil
+}
+
+// updateState applies the change to the state on disk under a file lock so
+// that concurrent processes do not undo each other's changes.
+func updateState(update func(s *State) error) error {
+	path := stateLockPath()
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	lock := flock.New(path)
+	if err := lock.Lock(); err != nil {
+		return fmt.Errorf("updateState: %w", err)
+	}
+	defer func() {
+		if err := lock.Unlock(); err != nil {
+			fmt.Fprint(os.Stderr, err)
+		}
+	}()
+
+	state, err := readState()
+	if err != nil {
+		return err
+	}
+	if err := update(&state); err != nil {
+		return err
+	}
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	session.mu.Lock()
+	session.state.reset()
+	session.mu.Unlock()
+	return writeFileAtomic(statePath(), b)
+}
+
+// UpdateState applies the change to the state of the data, which is read from
+// disk again first, and writes the state. The data file is not written.
+func (d *Data) UpdateState(update func(d *Data) error) error {
+	return updateState(func(s *State) error {
+		d.State = *s
+		if err := update(d); err != nil {
+			return err
+		}
+		*s = d.State
+		return nil
+	})
+}
@@ -0,0 +1,82 @@
+package kong
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStateSurvivesDataWrites(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+	session.data.reset()
+	session.state.reset()
+
+	// the daemon reads the data before the user pins an issue
+	data := NewData()
+	data.Issues = Issues{{Key: "KONG-1"}, {Key: "KONG-2"}}
+	if err := data.WriteFile(); err != nil {
+		t.Fatal(err)
+	}
+	daemon, err := ReadData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cli, err := ReadData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cli.UpdateState(func(d *Data) error { return d.Pin("KONG-2") }); err != nil {
+		t.Fatal(err)
+	}
+	if err := daemon.WriteFile(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(got.Pinned, []string{"KONG-2"}); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+
+	// resetting the cache keeps the state
+	if err := ResetCache(); err != nil {
+		t.Fatal(err)
+	}
+	got, _ = ReadData()
+	if diff := cmp.Diff(got.Pinned, []string{"KONG-2"}); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestUpdateStateConcurrently(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+	session.state.reset()
+
+	keys := []string{"KONG-1", "KONG-2", "KONG-3", "KONG-4"}
+	var wg sync.WaitGroup
+	for _, key := range keys {
+		key := key
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := updateState(func(s *State) error {
+				s.Pinned = append(s.Pinned, key)
+				return nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	state, err := readState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.Pinned) != len(keys) {
+		t.Errorf("got %v, want: %v", state.Pinned, keys)
+	}
+}
@@ -0,0 +1,59 @@
+package kong
+
+import (
+	"time"
+)
+
+const defaultStatusFormat = `{{.InProgress}}/{{.Total}}{{if .ActiveSprint.Name}} {{.ActiveSprint.Name}} {{.ActiveSprint.DaysLeft}}d{{end}}`
+
+// StatusLine is a compact summary of the current sprint intended for status
+// bars and shell prompts.
+type StatusLine struct {
+	Total        int
+	ToDo         int
+	InProgress   int
+	Done         int
+	Points       float64
+	ActiveSprint StatusSprint
+	Stale        bool
+}
+
+// StatusSprint describes the active sprint of a StatusLine.
+type StatusSprint struct {
+	Name     string
+	DaysLeft int
+}
+
+// NewStatusLine summarizes the cached sprint issues.
+func NewStatusLine(data Data, calendar Calendar, now time.Time) StatusLine {
+	status := StatusLine{
+		Total: len(data.SprintIssues),
+		Stale: data.Stale(),
+	}
+	for _, issue := range data.SprintIssues {
+		switch {
+		case issue.Status.IsDone:
+			status.Done++
+		case contains(todoStatuses, issue.Status.Name):
+			status.ToDo++
+		default:
+			status.InProgress++
+		}
+		status.Points += issue.StoryPoints
+	}
+	if sprint, err := data.Sprints.ActiveSprint(); err == nil {
+		status.ActiveSprint.Name = sprint.Name
+		if !sprint.EndDate.IsZero() {
+			status.ActiveSprint.DaysLeft = calendar.DaysRemaining(now, sprint.EndDate)
+		}
+	}
+	return status
+}
+
+// Render executes the given format or a default one.
+func (s StatusLine) Render(format string) (string, error) {
+	if format == "" {
+		format = defaultStatusFormat
+	}
+	return renderTemplate("status", format, s)
+}
@@ -0,0 +1,154 @@
+package kong
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// streakEmoji is appended to the celebration line after done-transitions.
+const streakEmoji = "🦍"
+
+// Completion records an issue which was moved into a done status with kong.
+type Completion struct {
+	Time time.Time `json:"time"`
+	Key  string    `json:"key"`
+}
+
+// Completions is the log of issues completed on this machine.
+type Completions []Completion
+
+// StreakDay counts the distinct issues completed on a day in the board
+// timezone.
+type StreakDay struct {
+	Date  string
+	Count int
+}
+
+// streakPath returns the path of the append-only completion log next to the
+// data file.
+func streakPath() string {
+	return cachePath() + ".streak"
+}
+
+// AppendCompletions records the issues as completed at the given time.
+func AppendCompletions(keys []string, now time.Time) error {
+	for _, key := range keys {
+		if err := appendJSON(streakPath(), Completion{Time: now, Key: key}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadCompletions returns the completions recorded since the given time. A
+// missing completion log has no entries.
+func ReadCompletions(since time.Time) (Completions, error) {
+	f, err := os.Open(streakPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var completions Completions
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var completion Completion
+		if err := json.Unmarshal(scanner.Bytes(), &completion); err != nil {
+			return nil, fmt.Errorf("ReadCompletions: %w", err)
+		}
+		if completion.Time.Before(since) {
+			continue
+		}
+		completions = append(completions, completion)
+	}
+	return completions, scanner.Err()
+}
+
+// Days returns the number of distinct issues completed per day in the given
+// location ordered by date. Issues moved to done again on the same day are
+// counted once.
+func (c Completions) Days(loc *time.Location) []StreakDay {
+	seen := make(map[string]map[string]struct{})
+	for _, completion := range c {
+		date := completion.Time.In(loc).Format(snapshotDateLayout)
+		if seen[date] == nil {
+			seen[date] = make(map[string]struct{})
+		}
+		seen[date][completion.Key] = struct{}{}
+	}
+	days := make([]StreakDay, 0, len(seen))
+	for date, keys := range seen {
+		days = append(days, StreakDay{Date: date, Count: len(keys)})
+	}
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Date < days[j].Date
+	})
+	return days
+}
+
+// Streak returns the number of issues completed on the day of now and the
+// number of consecutive days with completed issues. A streak is not broken by
+// the current day until it is over, nor by weekends and holidays of the
+// calendar without completed issues.
+func (c Completions) Streak(now time.Time, calendar Calendar) (today, streak int) {
+	counts := make(map[string]int)
+	for _, day := range c.Days(now.Location()) {
+		counts[day.Date] = day.Count
+	}
+	day := startOfDay(now)
+	today = counts[day.Format(snapshotDateLayout)]
+	if today == 0 {
+		day = day.AddDate(0, 0, -1)
+	}
+	// bound the days skipped in a row in case every day is a holiday
+	for skipped := 0; skipped < maxSnapshots; day = day.AddDate(0, 0, -1) {
+		switch {
+		case counts[day.Format(snapshotDateLayout)] > 0:
+			streak++
+			skipped = 0
+		case calendar.IsWorkingDay(day):
+			return today, streak
+		default:
+			skipped++
+		}
+	}
+	return today, streak
+}
+
+// PrintCelebration writes the number of issues completed today and the
+// current streak.
+func PrintCelebration(output io.Writer, completions Completions, now time.Time, calendar Calendar) {
+	today, streak := completions.Streak(now, calendar)
+	fmt.Fprint(output, Plain(fmt.Sprintf("%d done today, %d-day streak %s\n", today, streak, streakEmoji)))
+}
+
+// PrintStreak writes the number of completed issues per day of the given
+// number of days up to now followed by the current streak.
+func PrintStreak(output io.Writer, completions Completions, now time.Time, days int, calendar Calendar) error {
+	if len(completions) == 0 {
+		fmt.Fprintln(output, "No issues completed yet, they are recorded when kong moves issues to done")
+		return nil
+	}
+	since := startOfDay(now).AddDate(0, 0, 1-days).Format(snapshotDateLayout)
+	w := tabwriter.NewWriter(output, 1, 1, 1, ' ', 0)
+	for _, day := range completions.Days(now.Location()) {
+		if day.Date < since {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t-\t%d done\n", day.Date, day.Count)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	PrintCelebration(output, completions, now, calendar)
+	return nil
+}
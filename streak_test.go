@@ -0,0 +1,85 @@
+package kong
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCompletionsStreak(t *testing.T) {
+	// Wednesday
+	now := time.Date(2024, 3, 13, 15, 0, 0, 0, time.UTC)
+	at := func(days int, key string) Completion {
+		return Completion{Time: now.AddDate(0, 0, -days), Key: key}
+	}
+	tests := []struct {
+		name        string
+		completions Completions
+		calendar    Calendar
+		wantToday   int
+		wantStreak  int
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:        "today counted once per issue",
+			completions: Completions{at(0, "KONG-1"), at(0, "KONG-1"), at(0, "KONG-2")},
+			wantToday:   2,
+			wantStreak:  1,
+		},
+		{
+			name:        "today pending",
+			completions: Completions{at(1, "KONG-1"), at(2, "KONG-2")},
+			wantStreak:  2,
+		},
+		{
+			name:        "broken by working day",
+			completions: Completions{at(0, "KONG-1"), at(2, "KONG-2")},
+			wantToday:   1,
+			wantStreak:  1,
+		},
+		{
+			name:        "spans weekend",
+			completions: Completions{at(0, "KONG-1"), at(1, "KONG-2"), at(2, "KONG-3"), at(5, "KONG-4")},
+			wantToday:   1,
+			wantStreak:  4,
+		},
+		{
+			name:        "spans holiday",
+			completions: Completions{at(0, "KONG-1"), at(2, "KONG-2")},
+			calendar:    NewCalendar(false, []string{"2024-03-12"}),
+			wantToday:   1,
+			wantStreak:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			today, streak := tt.completions.Streak(now, tt.calendar)
+			if today != tt.wantToday || streak != tt.wantStreak {
+				t.Errorf("got %d, %d, want: %d, %d", today, streak, tt.wantToday, tt.wantStreak)
+			}
+		})
+	}
+}
+
+func TestReadCompletions(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+	now := time.Date(2024, 3, 13, 15, 0, 0, 0, time.UTC)
+	if err := AppendCompletions([]string{"KONG-1"}, now.AddDate(0, 0, -10)); err != nil {
+		t.Fatal(err)
+	}
+	if err := AppendCompletions([]string{"KONG-2", "KONG-3"}, now); err != nil {
+		t.Fatal(err)
+	}
+	completions, err := ReadCompletions(now.AddDate(0, 0, -1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []StreakDay{{Date: "2024-03-13", Count: 2}}
+	if diff := cmp.Diff(completions.Days(time.UTC), want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
@@ -0,0 +1,203 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+// defaultSubtaskType is the issue type of subtasks unless configured otherwise.
+const defaultSubtaskType = "Sub-task"
+
+// parentKeyPattern matches rows of the new issue editor which reference an
+// existing issue as parent of the indented rows below.
+var parentKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*-[0-9]+$`)
+
+var (
+	errMissingParent     = errors.New("subtask must be indented below an issue")
+	errParentNotCreated  = errors.New("parent issue was not created")
+	errParentWithoutRows = errors.New("parent issue has no subtasks")
+)
+
+// SubtaskIssueType returns the configured issue type of subtasks.
+func (c Config) SubtaskIssueType() string {
+	if c.SubtaskType != "" {
+		return c.SubtaskType
+	}
+	return defaultSubtaskType
+}
+
+// newIssueRow is a row of the new issue editor. Rows either create an issue,
+// create a subtask of the row above or reference an existing parent issue by
+// key.
+type newIssueRow struct {
+	issue *jira.Issue
+	// key is the key of the existing parent issue if issue is nil
+	key string
+	// parent is the index of the parent row of subtasks, otherwise -1
+	parent int
+}
+
+// parseNewIssueRows parses the rows of the new issue editor. Indented rows
+// define subtasks of the row above without the epic and sprint columns since
+// subtasks belong to the epic and sprint of their parent.
+func (e Editor) parseNewIssueRows(lines []string) ([]newIssueRow, error) {
+	rows := make([]newIssueRow, 0, len(lines))
+	parent := -1
+	for _, line := range lines {
+		indented := strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+		line = strings.TrimSpace(line)
+		if !indented {
+			if parent >= 0 && rows[parent].issue == nil && parent == len(rows)-1 {
+				return nil, fmt.Errorf("%w: %s", errParentWithoutRows, rows[parent].key)
+			}
+			parent = len(rows)
+			if parentKeyPattern.MatchString(line) {
+				rows = append(rows, newIssueRow{key: line, parent: -1})
+				continue
+			}
+			columns, err := e.parseColumns([]string{line}, 5)
+			if err != nil {
+				return nil, err
+			}
+			issue, err := e.parseIssue(columns[0], e.config.IssueType)
+			if err != nil {
+				return nil, err
+			}
+			rows = append(rows, newIssueRow{issue: issue, parent: -1})
+			continue
+		}
+		if parent < 0 {
+			return nil, fmt.Errorf("%w: %s", errMissingParent, line)
+		}
+		columns, err := e.parseColumns([]string{line}, 3)
+		if err != nil {
+			return nil, err
+		}
+		issue, err := e.parseSubtask(columns[0], rows[parent].key)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, newIssueRow{issue: issue, parent: parent})
+	}
+	if parent >= 0 && rows[parent].issue == nil && parent == len(rows)-1 {
+		return nil, fmt.Errorf("%w: %s", errParentWithoutRows, rows[parent].key)
+	}
+	return rows, nil
+}
+
+// parseSubtask parses the summary, story points and description columns of a
+// subtask. The parent is set once it is known if parentKey is empty.
+func (e Editor) parseSubtask(columns []string, parentKey string) (*jira.Issue, error) {
+	issue, err := e.parseIssue(append([]string{"0", "0"}, columns...), e.config.SubtaskIssueType())
+	if err != nil {
+		return nil, err
+	}
+	if parentKey != "" {
+		issue.Fields.Parent = &jira.Parent{Key: parentKey}
+	}
+	return issue, nil
+}
+
+// newIssues returns the issues the rows create.
+func newIssues(rows []newIssueRow) []*jira.Issue {
+	issues := make([]*jira.Issue, 0, len(rows))
+	for _, row := range rows {
+		if row.issue != nil {
+			issues = append(issues, row.issue)
+		}
+	}
+	return issues
+}
+
+// createNewIssueRows creates the issues of the rows before their subtasks so
+// that the subtasks can reference them as parent. It returns the keys of the
+// created issues by row together with a CreateIssuesError by row.
+func (j Jira) createNewIssueRows(ctx context.Context, rows []newIssueRow) ([]string, error) {
+	keys := make([]string, len(rows))
+	errs := make(CreateIssuesError)
+	for i, row := range rows {
+		if row.issue == nil {
+			keys[i] = row.key
+		}
+	}
+
+	// subtasks are created in the second pass
+	for pass := 0; pass < 2; pass++ {
+		var (
+			indices []int
+			issues  []*jira.Issue
+		)
+		for i, row := range rows {
+			if row.issue == nil || (row.parent >= 0) != (pass == 1) {
+				continue
+			}
+			if row.parent >= 0 {
+				if keys[row.parent] == "" {
+					errs[i] = errParentNotCreated
+					continue
+				}
+				row.issue.Fields.Parent = &jira.Parent{Key: keys[row.parent]}
+			}
+			indices = append(indices, i)
+			issues = append(issues, row.issue)
+		}
+		if len(issues) == 0 {
+			continue
+		}
+		created, err := j.CreateIssues(ctx, issues)
+		var createErr CreateIssuesError
+		if err != nil && !errors.As(err, &createErr) {
+			return keys, err
+		}
+		for k, i := range indices {
+			keys[i] = created[k]
+			if err, ok := createErr[k]; ok {
+				errs[i] = err
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return keys, errs
+	}
+	return keys, nil
+}
+
+// createdKeys returns the keys of the issues created by the rows.
+func createdKeys(rows []newIssueRow, keys []string) []string {
+	var created []string
+	for i, row := range rows {
+		if row.issue != nil && keys[i] != "" {
+			created = append(created, keys[i])
+		}
+	}
+	return created
+}
+
+// replaceParentRows replaces the rows of created parents with failed subtasks
+// by their keys so that retrying the subtasks does not create the parent
+// again.
+func replaceParentRows(content string, rows []newIssueRow, keys []string, errs CreateIssuesError) string {
+	replace := make(map[int]string)
+	for i, row := range rows {
+		if _, failed := errs[i]; failed && row.parent >= 0 && rows[row.parent].issue != nil && keys[row.parent] != "" {
+			replace[row.parent] = keys[row.parent]
+		}
+	}
+	lines := strings.Split(content, "\n")
+	row := 0
+	for i, line := range lines {
+		if strings.HasPrefix(line, "#") || line == "" {
+			continue
+		}
+		if key, ok := replace[row]; ok {
+			lines[i] = key
+		}
+		row++
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,189 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseNewIssueRows(t *testing.T) {
+	editor := Editor{config: Config{IssueType: "Story"}}
+	tests := []struct {
+		name    string
+		lines   []string
+		parents []int
+		types   []string
+		keys    []string
+		err     error
+	}{
+		{
+			name:    "issues",
+			lines:   []string{"0,0,Issue,1,", "0,0,Other,1,"},
+			parents: []int{-1, -1},
+			types:   []string{"Story", "Story"},
+			keys:    []string{"", ""},
+		},
+		{
+			name:    "subtasks-of-new-issue",
+			lines:   []string{"0,0,Issue,1,", "  Subtask,1,Body, with comma", "\tOther,0,"},
+			parents: []int{-1, 0, 0},
+			types:   []string{"Story", "Sub-task", "Sub-task"},
+			keys:    []string{"", "", ""},
+		},
+		{
+			name:    "subtasks-of-existing-issue",
+			lines:   []string{"KONG-1", "  Subtask,1,", "0,0,Issue,1,"},
+			parents: []int{-1, 0, -1},
+			types:   []string{"", "Sub-task", "Story"},
+			keys:    []string{"KONG-1", "KONG-1", ""},
+		},
+		{
+			name:  "missing-parent",
+			lines: []string{"  Subtask,1,"},
+			err:   errMissingParent,
+		},
+		{
+			name:  "parent-without-subtasks",
+			lines: []string{"KONG-1", "0,0,Issue,1,"},
+			err:   errParentWithoutRows,
+		},
+		{
+			name:  "missing-column",
+			lines: []string{"0,0,Issue,1,", "  Subtask"},
+			err:   errMissingColumn,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := editor.parseNewIssueRows(tt.lines)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want: %v", err, tt.err)
+			}
+			if len(rows) != len(tt.parents) {
+				t.Fatalf("got %d rows, want: %d", len(rows), len(tt.parents))
+			}
+			for i, row := range rows {
+				if row.parent != tt.parents[i] {
+					t.Errorf("got %v, want: %v", row.parent, tt.parents[i])
+				}
+				var issueType, key string
+				if row.issue != nil {
+					issueType = row.issue.Fields.Type.Name
+					if row.issue.Fields.Parent != nil {
+						key = row.issue.Fields.Parent.Key
+					}
+				} else {
+					key = row.key
+				}
+				if issueType != tt.types[i] {
+					t.Errorf("got %v, want: %v", issueType, tt.types[i])
+				}
+				if key != tt.keys[i] {
+					t.Errorf("got %v, want: %v", key, tt.keys[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCreateNewIssueRows(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+	session.data.reset()
+	data := NewData()
+	data.Timestamp = time.Now().Unix()
+	if err := data.WriteFile(); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		mu      sync.Mutex
+		parents = make(map[string]string)
+		next    = 10
+	)
+	client := newTestJira(t, func(req *http.Request) (*http.Response, error) {
+		var issue jira.Issue
+		if err := json.NewDecoder(req.Body).Decode(&issue); err != nil {
+			return nil, err
+		}
+		if issue.Fields.Summary == "Rejected" {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Body:       io.NopCloser(strings.NewReader(`{"errorMessages": ["rejected"]}`)),
+			}, nil
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if issue.Fields.Parent != nil {
+			parents[issue.Fields.Summary] = issue.Fields.Parent.Key
+		}
+		key := fmt.Sprintf("KONG-%d", next)
+		next++
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       io.NopCloser(strings.NewReader(`{"key": "` + key + `"}`)),
+		}, nil
+	})
+	j := Jira{client: client}
+	editor := Editor{jira: j, config: Config{IssueType: "Story"}}
+	content := "# New Issues\n" +
+		"0,0,Issue,1,\n" +
+		"  Subtask,1,\n" +
+		"  Rejected,1,\n" +
+		"0,0,Rejected,1,\n" +
+		"  Orphan,1,\n" +
+		"KONG-1\n" +
+		"  Existing,1,\n"
+	rows, err := editor.parseNewIssueRows(editor.parseLines(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := j.createNewIssueRows(context.Background(), rows)
+	var createErr CreateIssuesError
+	if !errors.As(err, &createErr) {
+		t.Fatalf("got %v, want: CreateIssuesError", err)
+	}
+	for _, row := range []int{2, 3, 4} {
+		if _, ok := createErr[row]; !ok {
+			t.Errorf("got no error for row %d", row)
+		}
+	}
+	if !errors.Is(createErr[4], errParentNotCreated) {
+		t.Errorf("got %v, want: %v", createErr[4], errParentNotCreated)
+	}
+	wantParents := map[string]string{
+		"Subtask":  keys[0],
+		"Existing": "KONG-1",
+	}
+	if diff := cmp.Diff(parents, wantParents); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	if got := createdKeys(rows, keys); len(got) != 3 {
+		t.Errorf("got %v, want three created issues", got)
+	}
+
+	// the created parent is referenced by key to retry the failed subtask
+	annotated, _ := annotateErrors(replaceParentRows(content, rows, keys, createErr), createErr, nil)
+	want := "# New Issues\n" +
+		keys[0] + "\n" +
+		"# error: rejected\n" +
+		"  Rejected,1,\n" +
+		"# error: rejected\n" +
+		"0,0,Rejected,1,\n" +
+		"# error: parent issue was not created\n" +
+		"  Orphan,1,\n"
+	if diff := cmp.Diff(annotated, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
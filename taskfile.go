@@ -0,0 +1,223 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var (
+	markdownTaskPattern = regexp.MustCompile(`^(\s*[-*+] )\[([ xX])\] (.*)$`)
+	orgTaskPattern      = regexp.MustCompile(`^(\*+ )(TODO|DONE) (.*)$`)
+	taskKeyPattern      = regexp.MustCompile(`^([A-Z][A-Z0-9_]*-[0-9]+) (.*)$`)
+)
+
+type taskFormat int
+
+const (
+	taskFormatMarkdown taskFormat = iota
+	taskFormatOrg
+)
+
+// TaskFile is a local Markdown or org-mode file whose checklist items are
+// synchronized with Jira issues. Items are Markdown checkboxes or org-mode
+// TODO and DONE headings annotated with the issue key directly after the
+// checkbox or keyword.
+type TaskFile struct {
+	path  string
+	lines []string
+	items []taskItem
+}
+
+type taskItem struct {
+	line    int
+	format  taskFormat
+	prefix  string
+	done    bool
+	key     string
+	summary string
+}
+
+// ReadTaskFile reads and parses the task file at the given path.
+func ReadTaskFile(path string) (*TaskFile, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("ReadTaskFile: %w", err)
+	}
+	return parseTaskFile(path, string(b)), nil
+}
+
+func parseTaskFile(path, s string) *TaskFile {
+	t := &TaskFile{
+		path:  path,
+		lines: strings.Split(s, "\n"),
+	}
+	for i, line := range t.lines {
+		item, ok := parseTaskItem(line)
+		if !ok {
+			continue
+		}
+		item.line = i
+		t.items = append(t.items, item)
+	}
+	return t
+}
+
+func parseTaskItem(line string) (taskItem, bool) {
+	var (
+		item taskItem
+		text string
+	)
+	if m := markdownTaskPattern.FindStringSubmatch(line); m != nil {
+		item.format = taskFormatMarkdown
+		item.prefix = m[1]
+		item.done = m[2] != " "
+		text = m[3]
+	} else if m := orgTaskPattern.FindStringSubmatch(line); m != nil {
+		item.format = taskFormatOrg
+		item.prefix = m[1]
+		item.done = m[2] == "DONE"
+		text = m[3]
+	} else {
+		return item, false
+	}
+	if m := taskKeyPattern.FindStringSubmatch(text); m != nil {
+		item.key = m[1]
+		text = m[2]
+	}
+	item.summary = strings.TrimSpace(text)
+	return item, item.summary != "" || item.key != ""
+}
+
+func (i taskItem) String() string {
+	text := i.summary
+	if i.key != "" {
+		text = i.key + " " + text
+	}
+	switch i.format {
+	case taskFormatOrg:
+		keyword := "TODO"
+		if i.done {
+			keyword = "DONE"
+		}
+		return i.prefix + keyword + " " + text
+	default:
+		mark := " "
+		if i.done {
+			mark = "x"
+		}
+		return i.prefix + "[" + mark + "] " + text
+	}
+}
+
+// String returns the content of the task file including all changes applied
+// to its items.
+func (t *TaskFile) String() string {
+	for _, item := range t.items {
+		t.lines[item.line] = item.String()
+	}
+	return strings.Join(t.lines, "\n")
+}
+
+// Write writes the task file back to disk.
+func (t *TaskFile) Write() error {
+	return os.WriteFile(t.path, []byte(t.String()), 0o644)
+}
+
+// Sync creates issues for all items without an issue key and writes the new
+// keys back into the file. Items created checked are transitioned to done.
+// Items with an issue key are synchronized both ways: an item checked or
+// unchecked since the last sync transitions the issue, otherwise the item is
+// checked or unchecked according to the status of the issue in Jira. Jira
+// wins for items not synchronized before.
+func (t *TaskFile) Sync(ctx context.Context, j Jira) error {
+	state, err := readState()
+	if err != nil {
+		return fmt.Errorf("TaskFile.Sync: %w", err)
+	}
+	synced := make(map[string]bool, len(t.items))
+	for key, done := range state.TaskItems {
+		synced[key] = done
+	}
+
+	var keys []string
+	for i, item := range t.items {
+		if item.key != "" {
+			keys = append(keys, item.key)
+			continue
+		}
+		issue := j.newIssue(j.config.IssueType, item.summary, "", nil)
+		key, err := j.CreateIssue(ctx, issue)
+		if err != nil {
+			return fmt.Errorf("TaskFile.Sync: %w", err)
+		}
+		t.items[i].key = key
+		keys = append(keys, key)
+
+		// new issues are open, a checked item has to transition them
+		synced[key] = false
+
+		// persist keys immediately to avoid duplicates on subsequent failures
+		if err := t.Write(); err != nil {
+			return err
+		}
+	}
+
+	issues, err := j.ListIssuesByKey(ctx, keys)
+	if err != nil {
+		return fmt.Errorf("TaskFile.Sync: %w", err)
+	}
+	issueByKey := make(map[string]Issue, len(issues))
+	for _, issue := range issues {
+		issueByKey[issue.Key] = issue
+	}
+	var transitions []issueTransition
+	for i, item := range t.items {
+		issue, ok := issueByKey[item.key]
+		if !ok {
+			continue
+		}
+		last, ok := synced[item.key]
+		switch {
+		case issue.Status.IsDone == item.done:
+		case ok && item.done != last:
+			transition, err := taskTransition(issue, item.done, j.config.DoneStatusNames())
+			if err != nil {
+				return fmt.Errorf("TaskFile.Sync: %w", err)
+			}
+			transitions = append(transitions, transition)
+		default:
+			t.items[i].done = issue.Status.IsDone
+			fmt.Printf("%s - %s\n", item.key, issue.Status.Name)
+		}
+		synced[item.key] = t.items[i].done
+	}
+	if err := j.TransitionIssues(ctx, transitions); err != nil {
+		return fmt.Errorf("TaskFile.Sync: %w", err)
+	}
+	if err := t.Write(); err != nil {
+		return err
+	}
+	return updateState(func(s *State) error {
+		if s.TaskItems == nil {
+			s.TaskItems = make(map[string]bool, len(synced))
+		}
+		for key, done := range synced {
+			s.TaskItems[key] = done
+		}
+		return nil
+	})
+}
+
+// taskTransition returns the first transition of the issue into a done status
+// if done is set and into a status which is not done otherwise.
+func taskTransition(issue Issue, done bool, doneStatuses []string) (issueTransition, error) {
+	for _, transition := range issue.Transitions {
+		if contains(doneStatuses, transition.Name) == done && transition.Name != issue.Status.Name {
+			return issueTransition{issueKey: issue.Key, transition: transition}, nil
+		}
+	}
+	return issueTransition{}, fmt.Errorf("%w: %s", errUnknownTransition, issue.Key)
+}
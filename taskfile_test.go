@@ -0,0 +1,139 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseTaskFile(t *testing.T) {
+	content := `# Tasks
+
+- [ ] KONG-1 Add command to list issues
+- [x] Add command to create issues
+  * [ ] nested item
+* TODO KONG-2 Support org-mode
+* DONE Write tests
+Some paragraph
+`
+	got := parseTaskFile("TODO.md", content).items
+	want := []taskItem{
+		{line: 2, format: taskFormatMarkdown, prefix: "- ", key: "KONG-1", summary: "Add command to list issues"},
+		{line: 3, format: taskFormatMarkdown, prefix: "- ", done: true, summary: "Add command to create issues"},
+		{line: 4, format: taskFormatMarkdown, prefix: "  * ", summary: "nested item"},
+		{line: 5, format: taskFormatOrg, prefix: "* ", key: "KONG-2", summary: "Support org-mode"},
+		{line: 6, format: taskFormatOrg, prefix: "* ", done: true, summary: "Write tests"},
+	}
+	if diff := cmp.Diff(got, want, cmp.AllowUnexported(taskItem{})); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestTaskFileString(t *testing.T) {
+	content := "- [ ] Add command to list issues\n* TODO KONG-2 Support org-mode\n"
+	taskFile := parseTaskFile("TODO.md", content)
+	taskFile.items[0].key = "KONG-1"
+	taskFile.items[1].done = true
+
+	got := taskFile.String()
+	want := "- [ ] KONG-1 Add command to list issues\n* DONE KONG-2 Support org-mode\n"
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestTaskFileSync(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+	session.data.reset()
+	session.state.reset()
+	if err := updateState(func(s *State) error {
+		s.TaskItems = map[string]bool{"KONG-1": false, "KONG-2": false}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// KONG-1 was checked locally and KONG-2 completed in Jira since the last
+	// sync, KONG-3 is created for the checked item without a key
+	issue := func(key, status, category string) string {
+		return fmt.Sprintf(`{
+			"key": %q,
+			"fields": {
+				"summary": "Task",
+				"priority": {"name": "High"},
+				"status": {"name": %q, "statusCategory": {"key": %q}}
+			},
+			"transitions": [
+				{"id": "1", "name": "To Do", "to": {"name": "To Do"}},
+				{"id": "2", "name": "Done", "to": {"name": "Done"}}
+			]
+		}`, key, status, category)
+	}
+	var (
+		mu          sync.Mutex
+		transitions []string
+	)
+	client := newTestJira(t, func(req *http.Request) (*http.Response, error) {
+		body := `{}`
+		switch {
+		case strings.HasSuffix(req.URL.Path, "/transitions"):
+			mu.Lock()
+			transitions = append(transitions, strings.Split(req.URL.Path, "/")[5])
+			mu.Unlock()
+			return &http.Response{StatusCode: http.StatusNoContent, Body: io.NopCloser(strings.NewReader(""))}, nil
+		case strings.HasSuffix(req.URL.Path, "/search"):
+			body = `{"issues": [` +
+				issue("KONG-1", "To Do", "new") + "," +
+				issue("KONG-2", "Done", "done") + "," +
+				issue("KONG-3", "To Do", "new") +
+				`], "total": 3}`
+		case req.Method == http.MethodPost:
+			body = `{"key": "KONG-3"}`
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+	j := Jira{client: client, config: Config{IssueType: "Task"}}
+
+	path := filepath.Join(t.TempDir(), "TODO.md")
+	content := "- [x] KONG-1 Checked locally\n- [ ] KONG-2 Done in Jira\n- [x] New task\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	taskFile, err := ReadTaskFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := taskFile.Sync(context.Background(), j); err != nil {
+		t.Fatal(err)
+	}
+
+	sort.Strings(transitions)
+	if diff := cmp.Diff(transitions, []string{"KONG-1", "KONG-3"}); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "- [x] KONG-1 Checked locally\n- [x] KONG-2 Done in Jira\n- [x] KONG-3 New task\n"
+	if diff := cmp.Diff(string(b), want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	state, err := readState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantSynced := map[string]bool{"KONG-1": true, "KONG-2": true, "KONG-3": true}
+	if diff := cmp.Diff(state.TaskItems, wantSynced); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
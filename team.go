@@ -0,0 +1,79 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"golang.org/x/sync/errgroup"
+)
+
+var errTeamEmpty = errors.New("team is not configured")
+
+// Team is the workload of all configured team members in the current sprint.
+type Team []TeamMember
+
+// TeamMember is the workload of a single team member.
+type TeamMember struct {
+	Name   string
+	Issues Issues
+	Points float64
+}
+
+// ListTeam fetches the open sprint issues of every team member concurrently.
+func (j Jira) ListTeam(ctx context.Context) (Team, error) {
+	if len(j.config.Team) == 0 {
+		return nil, errTeamEmpty
+	}
+	team := make(Team, len(j.config.Team))
+	g, ctx := errgroup.WithContext(ctx)
+	for i, name := range j.config.Team {
+		// allocate variable to avoid scope capturing
+		i, name := i, name
+
+		g.Go(func() error {
+			issues, err := j.ListSprintIssuesForAssignee(ctx, name)
+			if err != nil {
+				return err
+			}
+			member := TeamMember{
+				Name: name,
+			}
+			for _, issue := range issues {
+				if issue.Status.IsDone {
+					continue
+				}
+				member.Issues = append(member.Issues, issue)
+				member.Points += issue.StoryPoints
+			}
+			team[i] = member
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("ListTeam: %w", err)
+	}
+	return team, nil
+}
+
+// Print formats the workload of every team member highlighting members which
+// are idle or exceed the given capacity.
+func (t Team) Print(output io.Writer, capacity float64) {
+	w := tabwriter.NewWriter(output, 1, 1, 1, ' ', 0)
+	for _, member := range t {
+		var note string
+		switch {
+		case len(member.Issues) == 0:
+			note = "\t-\tidle"
+		case capacity > 0 && member.Points > capacity:
+			note = "\t-\toverloaded"
+		}
+		fmt.Fprintf(w, "%s\t-\t%d issues\t-\t%g points%s\n", member.Name, len(member.Issues), member.Points, note)
+		for _, issue := range member.Issues.Sort() {
+			fmt.Fprintf(w, "  %s\t-\t%s\t-\t%s\n", issue.Key, issue.Status.Name, issue.Summary)
+		}
+	}
+	w.Flush()
+}
@@ -0,0 +1,86 @@
+package kong
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListTeam(t *testing.T) {
+	issue := func(key, status, category string, points float64) string {
+		return fmt.Sprintf(`{
+			"key": %q,
+			"fields": {
+				"summary": "Task",
+				"priority": {"name": "High"},
+				"status": {"name": %q, "statusCategory": {"key": %q}},
+				"customfield_10002": %g
+			},
+			"transitions": [{"id": "1", "name": "Done", "to": {"name": "Done"}}]
+		}`, key, status, category, points)
+	}
+	client := newTestJira(t, func(req *http.Request) (*http.Response, error) {
+		body := `{"issues": [], "total": 0}`
+		if strings.Contains(req.URL.Query().Get("jql"), `assignee = "alice"`) {
+			body = `{"issues": [` +
+				issue("KONG-1", "In Progress", "indeterminate", 3) + "," +
+				issue("KONG-2", "Done", "done", 5) +
+				`], "total": 2}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}, nil
+	})
+	j := Jira{
+		client: client,
+		config: Config{
+			Project:      "KONG",
+			Team:         []string{"alice", "bob"},
+			CustomFields: CustomFields{StoryPoints: "customfield_10002"},
+		},
+	}
+	team, err := j.ListTeam(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, member := range team {
+		got = append(got, fmt.Sprintf("%s %d %g", member.Name, len(member.Issues), member.Points))
+	}
+	// done issues do not count towards the workload
+	if diff := cmp.Diff(got, []string{"alice 1 3", "bob 0 0"}); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+
+	j.config.Team = nil
+	if _, err := j.ListTeam(context.Background()); !errors.Is(err, errTeamEmpty) {
+		t.Errorf("got %v, want: %v", err, errTeamEmpty)
+	}
+}
+
+func TestTeamPrint(t *testing.T) {
+	team := Team{
+		{
+			Name:   "alice",
+			Issues: Issues{{Key: "KONG-1", Summary: "Add socket", Status: Status{Name: "In Progress"}}},
+			Points: 8,
+		},
+		{Name: "bob"},
+	}
+	var b bytes.Buffer
+	team.Print(&b, 5)
+	want := "alice    - 1 issues    - 8 points - overloaded\n" +
+		"  KONG-1 - In Progress - Add socket\n" +
+		"bob      - 0 issues    - 0 points - idle\n"
+	if diff := cmp.Diff(b.String(), want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
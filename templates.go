@@ -0,0 +1,238 @@
+package kong
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// maxTemplateOutput limits the size of rendered templates to guard against
+// runaway loops in user-defined templates.
+const maxTemplateOutput = 1 << 20
+
+var (
+	errUnknownTemplate      = errors.New("template does not exist")
+	errTemplateOutputLimit  = errors.New("template output exceeds limit")
+	templateErrorPattern    = regexp.MustCompile(`^template: [^:]*:(\d+)(?::(\d+))?: (.*)$`)
+	templateExecutingPrefix = regexp.MustCompile(`^executing "[^"]*" at `)
+)
+
+// TemplateError reports a template parse or execution error together with
+// the position and the offending line of the template.
+type TemplateError struct {
+	Name    string
+	Line    int
+	Column  int
+	Snippet string
+	Err     error
+	msg     string
+}
+
+func (e *TemplateError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "template %s: line %d", e.Name, e.Line)
+	if e.Column > 0 {
+		fmt.Fprintf(&b, ", column %d", e.Column)
+	}
+	fmt.Fprintf(&b, ": %s", e.msg)
+	if e.Snippet != "" {
+		prefix := fmt.Sprintf("  %d | ", e.Line)
+		fmt.Fprintf(&b, "\n%s%s", prefix, e.Snippet)
+		if e.Column > 0 {
+			fmt.Fprintf(&b, "\n%s^", strings.Repeat(" ", len(prefix)+e.Column-1))
+		}
+	}
+	return b.String()
+}
+
+func (e *TemplateError) Unwrap() error {
+	return e.Err
+}
+
+// newTemplateError converts errors returned by text/template into a
+// TemplateError if the position can be determined.
+func newTemplateError(name, text string, err error) error {
+	m := templateErrorPattern.FindStringSubmatch(err.Error())
+	if m == nil {
+		return fmt.Errorf("template %s: %w", name, err)
+	}
+	line, _ := strconv.Atoi(m[1])
+	column, _ := strconv.Atoi(m[2])
+	templateErr := &TemplateError{
+		Name:   name,
+		Line:   line,
+		Column: column,
+		Err:    err,
+		msg:    templateExecutingPrefix.ReplaceAllString(m[3], ""),
+	}
+	if lines := strings.Split(text, "\n"); line > 0 && line <= len(lines) {
+		templateErr.Snippet = lines[line-1]
+	}
+	return templateErr
+}
+
+type limitedBuffer struct {
+	bytes.Buffer
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > maxTemplateOutput {
+		return 0, errTemplateOutputLimit
+	}
+	return b.Buffer.Write(p)
+}
+
+// parseTemplate parses a user-defined template reporting errors with their
+// position in the template.
+func parseTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, newTemplateError(name, text, err)
+	}
+	return tmpl, nil
+}
+
+// renderTemplate parses and executes a user-defined template against data.
+func renderTemplate(name, text string, data any) (string, error) {
+	s, err := executeTemplate(name, text, data)
+	if err != nil {
+		return "", err
+	}
+	return Plain(s), nil
+}
+
+// executeTemplate is renderTemplate without adjusting the output for the
+// terminal, for templates whose output is not shown.
+func executeTemplate(name, text string, data any) (string, error) {
+	tmpl, err := parseTemplate(name, text)
+	if err != nil {
+		return "", err
+	}
+	var buf limitedBuffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		if errors.Is(err, errTemplateOutputLimit) {
+			return "", fmt.Errorf("template %s: %w", name, errTemplateOutputLimit)
+		}
+		return "", newTemplateError(name, text, err)
+	}
+	return buf.String(), nil
+}
+
+// templateSource is a configured template and the source of its data.
+type templateSource struct {
+	text   string
+	source string
+}
+
+const (
+	templateSourcePullRequest = "pr"
+	templateSourceWeek        = "week"
+)
+
+func (c Config) templates() map[string]templateSource {
+	templates := map[string]templateSource{
+		templateSourcePullRequest: {c.PullRequestTemplate, templateSourcePullRequest},
+		templateSourceWeek:        {c.WeekTemplate, templateSourceWeek},
+	}
+	for _, name := range []string{StandupSourceSprint, StandupSourceEpics} {
+		standup, _ := c.StandupTemplate(name)
+		templates[name] = templateSource{standup.Template, standup.Source}
+	}
+	for name, standup := range c.StandupTemplates {
+		templates[name] = templateSource{standup.Template, standup.Source}
+	}
+	return templates
+}
+
+// TemplateNames returns the names of all configured templates.
+func (c Config) TemplateNames() []string {
+	var names []string
+	for name, t := range c.templates() {
+		if t.text != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateTemplates parses all configured templates and returns the first
+// error including the position of the error.
+func (c Config) ValidateTemplates() error {
+	for _, name := range c.TemplateNames() {
+		if _, err := parseTemplate(name, c.templates()[name].text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TestTemplate renders the template of the given name against the cached
+// data without performing any requests.
+func TestTemplate(config Config, data Data, name string) (string, error) {
+	t, ok := config.templates()[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", errUnknownTemplate, name)
+	}
+	if err := data.LoadBodies(); err != nil {
+		return "", err
+	}
+	switch name {
+	case templateSourcePullRequest:
+		if t.text == "" {
+			t.text = defaultPullRequestTemplate
+		}
+	case templateSourceWeek:
+		if t.text == "" {
+			t.text = defaultWeekReport
+		}
+	}
+	return renderTemplate(name, t.text, data.templateSample(t.source))
+}
+
+// templateSample returns cached data in the shape a template of the given
+// source receives when rendered for real.
+func (d Data) templateSample(source string) any {
+	switch source {
+	case StandupSourceEpics:
+		return d.Epics
+	case StandupSourceJQL:
+		return d.Issues
+	case StandupSourceTeam:
+		return Team{
+			{
+				Name:   "Team Member",
+				Issues: d.SprintIssues,
+			},
+		}
+	case templateSourcePullRequest:
+		var pr PullRequest
+		if len(d.SprintIssues) > 0 {
+			issue := d.SprintIssues[0]
+			pr = PullRequest{
+				Key:                issue.Key,
+				Summary:            issue.Summary,
+				Description:        issue.Description,
+				AcceptanceCriteria: issue.AcceptanceCriteria,
+			}
+		}
+		return pr
+	case templateSourceWeek:
+		var week Week
+		for _, issue := range d.SprintIssues {
+			if issue.Status.IsDone {
+				week.Completed = append(week.Completed, issue)
+				week.Points += issue.StoryPoints
+				continue
+			}
+			week.Started = append(week.Started, issue)
+		}
+		return week
+	}
+	return d.SprintIssues
+}
@@ -0,0 +1,57 @@
+package kong
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRenderTemplateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		data any
+		want string
+	}{
+		{
+			name: "parse-error",
+			text: "Yesterday:\n{{range .}}{{.Key}\n",
+			want: "template sprint: line 2: bad character U+007D '}'\n  2 | {{range .}}{{.Key}",
+		},
+		{
+			name: "exec-error",
+			text: "Yesterday:\n{{range .}}{{.Foo}}{{end}}\n",
+			data: Issues{{Key: "KONG-1"}},
+			want: "template sprint: line 2, column 13: <.Foo>: can't evaluate field Foo in type kong.Issue\n" +
+				"  2 | {{range .}}{{.Foo}}{{end}}\n" +
+				"                  ^",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := renderTemplate("sprint", tt.text, tt.data)
+			var templateErr *TemplateError
+			if !errors.As(err, &templateErr) {
+				t.Fatalf("got %v, want: TemplateError", err)
+			}
+			if diff := cmp.Diff(err.Error(), tt.want); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
+
+func BenchmarkRenderTemplate(b *testing.B) {
+	data := newFixture(fixtureIssues, fixtureEpics).data(b)
+	text := `{{range .Epics}}{{.Key}} {{.Summary}}
+{{end}}{{range .SprintIssues}}- {{.Key}} {{.Summary}} ({{.Status.Name}}, {{.StoryPoints}} pts)
+{{end}}`
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := renderTemplate("benchmark", text, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
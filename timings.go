@@ -0,0 +1,54 @@
+package kong
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// loadTiming is the time it took to fetch a section or another part of the
+// data from Jira.
+type loadTiming struct {
+	name     string
+	duration time.Duration
+}
+
+// loadTimings records the time each loader took. It is shared by the loaders
+// running concurrently and does nothing if nil.
+type loadTimings struct {
+	mu      sync.Mutex
+	timings []loadTiming
+}
+
+func (t *loadTimings) record(name string, startedAt time.Time) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.timings = append(t.timings, loadTiming{name: name, duration: time.Since(startedAt)})
+}
+
+// PrintLoadTimings writes the time it took to fetch each part of the data
+// during the last blocking load, ordered by name.
+func (d Data) PrintLoadTimings(output io.Writer) error {
+	if d.timings == nil {
+		return nil
+	}
+	d.timings.mu.Lock()
+	timings := make([]loadTiming, len(d.timings.timings))
+	copy(timings, d.timings.timings)
+	d.timings.mu.Unlock()
+
+	sort.Slice(timings, func(i, j int) bool {
+		return timings[i].name < timings[j].name
+	})
+	w := tabwriter.NewWriter(output, 1, 1, 1, ' ', 0)
+	for _, timing := range timings {
+		fmt.Fprintf(w, "%s\t-\t%s\n", timing.name, timing.duration.Round(time.Millisecond))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,31 @@
+package kong
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPrintLoadTimings(t *testing.T) {
+	data := NewData()
+	data.timings = &loadTimings{}
+	now := time.Now()
+	data.timings.record(SectionSprints.String(), now.Add(-1500*time.Millisecond))
+	data.timings.record("board", now.Add(-300*time.Millisecond))
+	data.timings.record(SectionIssues.String(), now.Add(-2*time.Second))
+
+	var b bytes.Buffer
+	if err := data.PrintLoadTimings(&b); err != nil {
+		t.Fatal(err)
+	}
+	// the durations depend on the time of the test
+	var names []string
+	for _, line := range bytes.Split(bytes.TrimSpace(b.Bytes()), []byte("\n")) {
+		names = append(names, string(bytes.Fields(line)[0]))
+	}
+	if diff := cmp.Diff(names, []string{"board", "issues", "sprints"}); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
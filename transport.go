@@ -0,0 +1,118 @@
+package kong
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+var errCertificateInvalid = errors.New("no valid certificate found")
+
+// Authentication types for the Jira client.
+const (
+	// AuthBasic authenticates with username and password.
+	AuthBasic = "basic"
+	// AuthToken authenticates against Jira Cloud with the account email as
+	// username and an API token.
+	AuthToken = "token"
+	// AuthPAT authenticates against Jira Server and Data Center with a
+	// personal access token sent as bearer token.
+	AuthPAT = "pat"
+)
+
+// authTransport returns the transport authenticating requests according to
+// the configured authentication type.
+func (c Config) authTransport(transport http.RoundTripper) http.RoundTripper {
+	switch c.AuthType {
+	case AuthToken:
+		return &jira.BasicAuthTransport{
+			Username:  c.Username,
+			Password:  c.Token,
+			Transport: transport,
+		}
+	case AuthPAT:
+		return bearerAuthTransport{
+			token:     c.Token,
+			transport: transport,
+		}
+	}
+	return &jira.BasicAuthTransport{
+		Username:  c.Username,
+		Password:  c.Password,
+		Transport: transport,
+	}
+}
+
+// bearerAuthTransport sets the token as bearer token on every request.
+type bearerAuthTransport struct {
+	token     string
+	transport http.RoundTripper
+}
+
+func (t bearerAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	// round trippers must not modify the request
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return transport.RoundTrip(req)
+}
+
+// transport returns the HTTP transport used for the Jira client. It respects
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY unless a proxy is configured and
+// applies the configured TLS options.
+func (c Config) transport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if c.Proxy != "" {
+		proxy, err := url.Parse(c.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("transport: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	tlsConfig, err := c.TLS.config()
+	if err != nil {
+		return nil, fmt.Errorf("transport: %w", err)
+	}
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
+
+func (t TLS) config() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// users opt into this explicitly for self-signed test instances
+		InsecureSkipVerify: t.InsecureSkipVerify, //nolint:gosec
+	}
+	if t.CAFile != "" {
+		b, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("%w: %s", errCertificateInvalid, t.CAFile)
+		}
+		config.RootCAs = pool
+	}
+	if t.CertFile != "" || t.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
@@ -0,0 +1,73 @@
+package kong
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestAuthTransport(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   string
+	}{
+		{
+			name:   "basic",
+			config: Config{Username: "kong", Password: "banana"},
+			want:   "Basic a29uZzpiYW5hbmE=",
+		},
+		{
+			name:   "token",
+			config: Config{AuthType: AuthToken, Username: "kong", Password: "unused", Token: "banana"},
+			want:   "Basic a29uZzpiYW5hbmE=",
+		},
+		{
+			name:   "pat",
+			config: Config{AuthType: AuthPAT, Username: "kong", Token: "banana"},
+			want:   "Bearer banana",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			transport := tt.config.authTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				got = req.Header.Get("Authorization")
+				return &http.Response{StatusCode: http.StatusOK}, nil
+			}))
+			req, err := http.NewRequest(http.MethodGet, "https://jira.example.com/rest/api/2/myself", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := transport.RoundTrip(req); err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want: %v", got, tt.want)
+			}
+			if req.Header.Get("Authorization") != "" {
+				t.Error("transport modified the original request")
+			}
+		})
+	}
+}
+
+func TestConfigValidateAuthType(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   error
+	}{
+		{name: "default", config: Config{}},
+		{name: "pat", config: Config{AuthType: AuthPAT, Token: "banana"}},
+		{name: "missing token", config: Config{AuthType: AuthToken}, want: errConfigToken},
+		{name: "unknown", config: Config{AuthType: "oauth"}, want: errConfigAuthType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want: %v", err, tt.want)
+			}
+		})
+	}
+}
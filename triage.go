@@ -0,0 +1,206 @@
+package kong
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+var (
+	errUnknownTriageAction = errors.New("unknown triage action")
+	errMissingArgument     = errors.New("missing argument")
+)
+
+type triageActionType int
+
+const (
+	triageSkip triageActionType = iota
+	triageQuit
+	triageAssign
+	triagePriority
+	triageSprint
+	triageDuplicate
+)
+
+type triageAction struct {
+	typ      triageActionType
+	issue    Issue
+	argument string
+}
+
+// Triage iterates new unassigned bugs one at a time and collects quick
+// actions which are applied in batch once all bugs have been reviewed.
+type Triage struct {
+	jira    Jira
+	sprints Sprints
+
+	// oldestFirst reviews the bugs in the order they were created.
+	oldestFirst bool
+}
+
+// NewTriage returns a new instance of Triage.
+func NewTriage(ctx context.Context) (Triage, error) {
+	jira, err := NewJira()
+	if err != nil {
+		return Triage{}, err
+	}
+	data, err := LoadData()
+	if err != nil {
+		return Triage{}, err
+	}
+	sprints, err := data.GetSprints(ctx)
+	if err != nil {
+		return Triage{}, err
+	}
+	return Triage{
+		jira:    jira,
+		sprints: sprints,
+	}, nil
+}
+
+// SetOldestFirst reviews the oldest bugs first instead of the newest.
+func (t *Triage) SetOldestFirst(oldestFirst bool) {
+	t.oldestFirst = oldestFirst
+}
+
+// Run prompts for actions on every untriaged bug reading the answers from r
+// and applies all actions at the end.
+func (t Triage) Run(ctx context.Context, r io.Reader, w io.Writer) error {
+	bugs, err := t.jira.ListUntriagedBugs(ctx, t.jira.config.Project)
+	if err != nil {
+		return err
+	}
+	if len(bugs) == 0 {
+		fmt.Fprintln(w, "No bugs to triage")
+		return nil
+	}
+	if t.oldestFirst {
+		sort.SliceStable(bugs, func(i, j int) bool {
+			return bugs[i].Created.Before(bugs[j].Created)
+		})
+	}
+
+	reader := bufio.NewReader(r)
+	actions := make([]triageAction, 0, len(bugs))
+
+bugs:
+	for i, bug := range bugs {
+		t.printBug(w, bug, i+1, len(bugs))
+		for {
+			fmt.Fprint(w, "> ")
+			line, err := reader.ReadString('\n')
+			if err == io.EOF {
+				break bugs
+			}
+			if err != nil {
+				return err
+			}
+			action, err := t.parseAction(line)
+			if err != nil {
+				fmt.Fprintln(w, err)
+				continue
+			}
+			switch action.typ {
+			case triageSkip:
+				continue bugs
+			case triageQuit:
+				break bugs
+			}
+			action.issue = bug
+			actions = append(actions, action)
+		}
+	}
+	return t.apply(ctx, actions)
+}
+
+func (t Triage) printBug(w io.Writer, bug Issue, n, total int) {
+	fmt.Fprintf(w, "\n[%d/%d] %s - %s - %s\n", n, total, bug.Key, bug.Priority, bug.Summary)
+	if bug.Reporter != "" {
+		fmt.Fprintf(w, "Reported by %s on %s\n", bug.Reporter, formatTimestamp(bug.Created))
+	}
+	if bug.Description != "" {
+		fmt.Fprintf(w, "\n%s\n", bug.Description)
+	}
+	fmt.Fprintln(w)
+
+	tw := tabwriter.NewWriter(w, 1, 1, 1, ' ', 0)
+	fmt.Fprint(tw, "a\t=\tAssign to me\n")
+	fmt.Fprint(tw, "p <priority>\t=\tSet priority\n")
+	for i, sprint := range t.sprints {
+		fmt.Fprintf(tw, "s %d\t=\tAdd to %s\n", i+1, sprint.Name)
+	}
+	fmt.Fprint(tw, "d <key>\t=\tClose as duplicate\n")
+	fmt.Fprint(tw, "<enter>\t=\tNext bug\n")
+	fmt.Fprint(tw, "q\t=\tApply actions and quit\n")
+	tw.Flush()
+}
+
+func (t Triage) parseAction(line string) (triageAction, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return triageAction{typ: triageSkip}, nil
+	}
+	argument := strings.Join(fields[1:], " ")
+
+	var action triageAction
+	switch fields[0] {
+	case "a":
+		action.typ = triageAssign
+	case "p":
+		action.typ = triagePriority
+	case "s":
+		action.typ = triageSprint
+		index, err := strconv.Atoi(argument)
+		if err != nil {
+			return action, err
+		}
+		if index < 1 || index > len(t.sprints) {
+			return action, errSprintMismatch
+		}
+	case "d":
+		action.typ = triageDuplicate
+	case "q":
+		return triageAction{typ: triageQuit}, nil
+	default:
+		return action, fmt.Errorf("%w: %s", errUnknownTriageAction, fields[0])
+	}
+	if action.typ != triageAssign && argument == "" {
+		return action, fmt.Errorf("%w: %s", errMissingArgument, fields[0])
+	}
+	action.argument = argument
+	return action, nil
+}
+
+func (t Triage) apply(ctx context.Context, actions []triageAction) error {
+	keysBySprint := make(map[int][]string)
+	for _, action := range actions {
+		var err error
+		switch action.typ {
+		case triageAssign:
+			err = t.jira.AssignIssue(ctx, action.issue.Key)
+		case triagePriority:
+			err = t.jira.SetPriority(ctx, action.issue.Key, action.argument)
+		case triageSprint:
+			// index has been validated while parsing the action
+			index, _ := strconv.Atoi(action.argument)
+			keysBySprint[index-1] = append(keysBySprint[index-1], action.issue.Key)
+		case triageDuplicate:
+			err = t.jira.CloseAsDuplicate(ctx, action.issue, action.argument)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for index, keys := range keysBySprint {
+		if err := t.jira.MoveIssuesToSprint(ctx, t.sprints[index], keys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
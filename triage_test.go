@@ -0,0 +1,86 @@
+package kong
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestParseTriageAction(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want triageAction
+		err  error
+	}{
+		{
+			name: "skip",
+			line: "\n",
+			want: triageAction{typ: triageSkip},
+		},
+		{
+			name: "assign",
+			line: "a\n",
+			want: triageAction{typ: triageAssign},
+		},
+		{
+			name: "priority",
+			line: "p Very High\n",
+			want: triageAction{typ: triagePriority, argument: "Very High"},
+		},
+		{
+			name: "sprint",
+			line: "s 1\n",
+			want: triageAction{typ: triageSprint, argument: "1"},
+		},
+		{
+			name: "duplicate",
+			line: "d KONG-1\n",
+			want: triageAction{typ: triageDuplicate, argument: "KONG-1"},
+		},
+		{
+			name: "fails-non-integer-sprint",
+			line: "s foo\n",
+			err:  strconv.ErrSyntax,
+		},
+		{
+			name: "fails-missing-sprint",
+			line: "s 2\n",
+			err:  errSprintMismatch,
+		},
+		{
+			name: "fails-missing-duplicate",
+			line: "d\n",
+			err:  errMissingArgument,
+		},
+		{
+			name: "fails-unknown-action",
+			line: "x\n",
+			err:  errUnknownTriageAction,
+		},
+	}
+
+	triage := Triage{
+		sprints: Sprints{
+			{ID: 1, Name: "Komodo"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := triage.parseAction(tt.line)
+			if tt.err == nil && err != nil {
+				t.Fatal(err)
+			}
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("got %v, want: %v", err, tt.err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package kong
+
+import (
+	"context"
+	"regexp"
+	"time"
+)
+
+var variablePattern = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+// Variables resolves substitution variables like {{sprint}}, {{today}},
+// {{me}} and {{branch}} in user input to avoid retyping recurring naming
+// conventions.
+type Variables struct {
+	Sprint string
+	Me     string
+	Epic   string
+	now    func() time.Time
+}
+
+// NewVariables returns a new instance of Variables based on the active sprint
+// and the current Jira user.
+func NewVariables(data Data, jira Jira) Variables {
+	var v Variables
+	if sprint, err := data.Sprints.ActiveSprint(); err == nil {
+		v.Sprint = sprint.Name
+	}
+	if jira.user != nil {
+		v.Me = jira.user.DisplayName
+	}
+	return v
+}
+
+// Expand replaces all known variables in s. Unknown variables are left
+// untouched.
+func (v Variables) Expand(s string) string {
+	return variablePattern.ReplaceAllStringFunc(s, func(match string) string {
+		name := variablePattern.FindStringSubmatch(match)[1]
+		value, ok := v.lookup(name)
+		if !ok {
+			return match
+		}
+		return value
+	})
+}
+
+func (v Variables) lookup(name string) (string, bool) {
+	switch name {
+	case "sprint":
+		return v.Sprint, true
+	case "me":
+		return v.Me, true
+	case "epic":
+		return v.Epic, v.Epic != ""
+	case "today":
+		now := time.Now
+		if v.now != nil {
+			now = v.now
+		}
+		return now().Format("2006-01-02"), true
+	case "branch":
+		branch, err := currentBranch(context.Background())
+		if err != nil {
+			return "", false
+		}
+		return branch, true
+	}
+	return "", false
+}
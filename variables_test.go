@@ -0,0 +1,44 @@
+package kong
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVariablesExpand(t *testing.T) {
+	v := Variables{
+		Sprint: "Komodo",
+		Me:     "Kong",
+		now: func() time.Time {
+			return time.Date(1933, time.April, 7, 0, 0, 0, 0, time.UTC)
+		},
+	}
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{
+			name: "no-variables",
+			s:    "Add command to list issues",
+			want: "Add command to list issues",
+		},
+		{
+			name: "known-variables",
+			s:    "[{{sprint}}] Release notes {{ today }} by {{me}}",
+			want: "[Komodo] Release notes 1933-04-07 by Kong",
+		},
+		{
+			name: "unknown-variable",
+			s:    "Release {{version}}",
+			want: "Release {{version}}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v.Expand(tt.s); got != tt.want {
+				t.Errorf("got %q, want: %q", got, tt.want)
+			}
+		})
+	}
+}
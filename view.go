@@ -0,0 +1,154 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+var (
+	errUnknownView      = errors.New("view does not exist")
+	errConfigViewSource = errors.New("unknown view source")
+	errConfigViewField  = errors.New("unknown view field")
+)
+
+// View data sources available to saved views.
+const (
+	ViewSourceIssues = "issues"
+	ViewSourceSprint = "sprint"
+	ViewSourceEpics  = "epics"
+)
+
+// defaultViewColumns match the columns of the regular issue list.
+var defaultViewColumns = []string{"key", "status", "summary"}
+
+// View is a named list of cached issues combining a filter, sort order,
+// columns and grouping. Sort, columns and grouping refer to the same fields
+// as filter expressions.
+type View struct {
+	// Source is one of issues, sprint or epics and defaults to issues.
+	Source string `yaml:"source"`
+	Filter string `yaml:"filter"`
+	// Sort orders by the given field, descending if prefixed with -. Issues
+	// are ordered by status if empty.
+	Sort    string   `yaml:"sort"`
+	Columns []string `yaml:"columns"`
+	GroupBy string   `yaml:"groupBy"`
+}
+
+// View returns the saved view of the given name.
+func (c Config) View(name string) (View, error) {
+	view, ok := c.Views[name]
+	if !ok {
+		return View{}, fmt.Errorf("%w: %s", errUnknownView, name)
+	}
+	return view, nil
+}
+
+func (v View) validate() error {
+	switch v.Source {
+	case "", ViewSourceIssues, ViewSourceSprint, ViewSourceEpics:
+	default:
+		return fmt.Errorf("%w: %s", errConfigViewSource, v.Source)
+	}
+	if v.Filter != "" {
+		if _, err := ParseFilter(v.Filter); err != nil {
+			return err
+		}
+	}
+	fields := append([]string{strings.TrimPrefix(v.Sort, "-"), v.GroupBy}, v.Columns...)
+	for _, field := range fields {
+		if _, ok := filterFields[field]; field != "" && !ok {
+			return fmt.Errorf("%w: %s", errConfigViewField, field)
+		}
+	}
+	return nil
+}
+
+// Issues returns the issues of the view from the given data.
+func (v View) Issues(ctx context.Context, data Data) (Issues, error) {
+	var (
+		issues Issues
+		err    error
+	)
+	switch v.Source {
+	case ViewSourceSprint:
+		issues, err = data.GetSprintIssues(ctx)
+	case ViewSourceEpics:
+		issues, err = data.GetEpics(ctx)
+	default:
+		issues, err = data.GetIssues(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if v.Filter != "" {
+		filter, err := ParseFilter(v.Filter)
+		if err != nil {
+			return nil, err
+		}
+		issues = issues.Filter(filter)
+	}
+	return v.sort(issues), nil
+}
+
+func (v View) sort(issues Issues) Issues {
+	if v.Sort == "" {
+		return issues.Sort()
+	}
+	field := filterFields[strings.TrimPrefix(v.Sort, "-")]
+	descending := strings.HasPrefix(v.Sort, "-")
+	sort.SliceStable(issues, func(a, b int) bool {
+		if descending {
+			a, b = b, a
+		}
+		return lessValue(field(issues[a]), field(issues[b]))
+	})
+	return issues
+}
+
+func lessValue(a, b any) bool {
+	switch a := a.(type) {
+	case float64:
+		return a < b.(float64)
+	case string:
+		return a < b.(string)
+	case bool:
+		return !a && b.(bool)
+	}
+	return false
+}
+
+// Print writes the issues in the columns of the view, grouped by the value of
+// the group field if set.
+func (v View) Print(w io.Writer, issues Issues) {
+	columns := v.Columns
+	if len(columns) == 0 {
+		columns = defaultViewColumns
+	}
+	if v.GroupBy == "" {
+		issues.PrintColumns(w, columns)
+		return
+	}
+	var (
+		groups []string
+		byKey  = make(map[string]Issues)
+	)
+	for _, issue := range issues {
+		group := formatValue(filterFields[v.GroupBy](issue))
+		if _, ok := byKey[group]; !ok {
+			groups = append(groups, group)
+		}
+		byKey[group] = append(byKey[group], issue)
+	}
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, group)
+		byKey[group].PrintColumns(w, columns)
+	}
+}
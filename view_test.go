@@ -0,0 +1,69 @@
+package kong
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestViewPrint(t *testing.T) {
+	issues := Issues{
+		{Key: "KONG-1", Summary: "Small", Status: Status{Name: "To Do"}, StoryPoints: 1},
+		{Key: "KONG-2", Summary: "Large", Status: Status{Name: "In Progress"}, StoryPoints: 8},
+		{Key: "KONG-3", Summary: "Medium", Status: Status{Name: "To Do"}, StoryPoints: 3},
+	}
+	view := View{
+		Sort:    "-points",
+		Columns: []string{"key", "points", "summary"},
+		GroupBy: "status",
+	}
+	var b bytes.Buffer
+	view.Print(&b, view.sort(issues))
+
+	want := "In Progress\n" +
+		"KONG-2 - 8 - Large\n" +
+		"\n" +
+		"To Do\n" +
+		"KONG-3 - 3 - Medium\n" +
+		"KONG-1 - 1 - Small\n"
+	if diff := cmp.Diff(b.String(), want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func TestViewValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		view View
+		want error
+	}{
+		{
+			name: "valid",
+			view: View{Source: ViewSourceSprint, Filter: "points > 3", Sort: "-points", Columns: []string{"key"}},
+		},
+		{
+			name: "unknown-source",
+			view: View{Source: "jql"},
+			want: errConfigViewSource,
+		},
+		{
+			name: "unknown-column",
+			view: View{Columns: []string{"assignee"}},
+			want: errConfigViewField,
+		},
+		{
+			name: "invalid-filter",
+			view: View{Filter: "points >"},
+			want: errFilterSyntax,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.view.validate(); !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want: %v", err, tt.want)
+			}
+		})
+	}
+}
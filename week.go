@@ -0,0 +1,170 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+const (
+	weekDays          = 7
+	jiraTimeLayout    = "2006-01-02T15:04:05.999-0700"
+	defaultWeekReport = `Week of {{.Since.Format "Jan 2"}}
+{{if .Completed}}
+Completed ({{.Points}} points):
+{{range .Completed}}- {{.Key}} {{.Summary}}
+{{end}}{{end}}{{if .Started}}
+Started:
+{{range .Started}}- {{.Key}} {{.Summary}}
+{{end}}{{end}}{{if .Comments}}
+Comments:
+{{range .Comments}}- {{.Key}} {{.Body}}
+{{end}}{{end}}`
+)
+
+var (
+	// todoStatuses are the status names an issue leaves when work is started.
+	todoStatuses = []string{"To Do", "Open", "Backlog"}
+	// doneStatuses are the status names an issue enters when work is done
+	// unless done statuses are configured.
+	doneStatuses = []string{"Done", "Closed", "Resolved"}
+)
+
+// Week summarizes the activity of the current user during the past days.
+type Week struct {
+	Since     time.Time
+	Completed Issues
+	Started   Issues
+	Comments  []WeekComment
+	Points    float64
+}
+
+// WeekComment is a comment the current user made on an issue.
+type WeekComment struct {
+	Key  string
+	Body string
+}
+
+// GetWeek collects issues completed and started as well as comments made by
+// the current user based on the changelog of recently updated issues.
+func (j Jira) GetWeek(ctx context.Context, now time.Time) (Week, error) {
+	week := Week{
+		Since: now.AddDate(0, 0, -weekDays),
+	}
+	conditions := []string{
+		j.config.projectCondition(),
+		fmt.Sprintf("updated >= -%dd", weekDays),
+		"(assignee = \"" + j.user.DisplayName + "\" OR watcher = currentUser())",
+	}
+	jql := strings.Join(conditions, " AND ")
+	issues, err := j.searchWithExpand(ctx, jql, "changelog")
+	if err != nil {
+		return week, fmt.Errorf("GetWeek: %w", err)
+	}
+	for _, issue := range issues {
+		j.summarizeIssue(&week, issue)
+	}
+	return week, nil
+}
+
+func (j Jira) summarizeIssue(week *Week, issue jira.Issue) {
+	if issue.Fields == nil {
+		return
+	}
+	summary := Issue{
+		Key:     issue.Key,
+		Summary: issue.Fields.Summary,
+		Status:  NewStatus(issue).withDoneStatuses(j.config.DoneStatuses),
+	}
+
+	var completed, started bool
+	if issue.Changelog != nil {
+		for _, history := range issue.Changelog.Histories {
+			createdAt, err := history.CreatedTime()
+			if err != nil || createdAt.Before(week.Since) {
+				continue
+			}
+			for _, item := range history.Items {
+				if item.Field != "status" {
+					continue
+				}
+				if contains(j.config.DoneStatusNames(), item.ToString) {
+					completed = true
+				}
+				if contains(todoStatuses, item.FromString) {
+					started = true
+				}
+			}
+		}
+	}
+
+	// only count issues as completed which are assigned to the current user
+	mine := issue.Fields.Assignee != nil && j.isUser(*issue.Fields.Assignee)
+	if mine && completed && summary.Status.IsDone {
+		week.Completed = append(week.Completed, summary)
+		if points, ok := issue.Fields.Unknowns[j.config.CustomFields.StoryPoints].(float64); ok {
+			week.Points += points
+		}
+	} else if mine && started {
+		week.Started = append(week.Started, summary)
+	}
+
+	if issue.Fields.Comments == nil {
+		return
+	}
+	for _, comment := range issue.Fields.Comments.Comments {
+		if comment == nil || !j.isUser(comment.Author) {
+			continue
+		}
+		createdAt, err := time.Parse(jiraTimeLayout, comment.Created)
+		if err != nil || createdAt.Before(week.Since) {
+			continue
+		}
+		week.Comments = append(week.Comments, WeekComment{
+			Key:  issue.Key,
+			Body: firstLine(comment.Body),
+		})
+	}
+}
+
+// Render executes the configured weekly report template or a default one.
+func (w Week) Render(text string) (string, error) {
+	if text == "" {
+		text = defaultWeekReport
+	}
+	return renderTemplate(templateSourceWeek, text, w)
+}
+
+// PrintWeek renders the weekly report, writes it to stdout and copies it to
+// the clipboard.
+func (j Jira) PrintWeek(ctx context.Context) error {
+	week, err := j.GetWeek(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	report, err := week.Render(j.config.WeekTemplate)
+	if err != nil {
+		return err
+	}
+	fmt.Print(report)
+	return copyToClipboard(ctx, j.config.CopyCommand, []byte(report))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return strings.TrimSpace(s[:i])
+	}
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,93 @@
+package kong
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSummarizeIssue(t *testing.T) {
+	me := &jira.User{Name: "kong"}
+	j := Jira{
+		user: me,
+		config: Config{
+			CustomFields: CustomFields{StoryPoints: "storyPoints"},
+		},
+	}
+	week := Week{
+		Since: time.Date(2023, time.July, 3, 0, 0, 0, 0, time.UTC),
+	}
+	done := &jira.Status{
+		Name:           "Done",
+		StatusCategory: jira.StatusCategory{Key: "done"},
+	}
+
+	j.summarizeIssue(&week, jira.Issue{
+		Key: "KONG-1",
+		Fields: &jira.IssueFields{
+			Summary:  "Completed this week",
+			Assignee: me,
+			Status:   done,
+			Unknowns: map[string]any{"storyPoints": 3.0},
+			Comments: &jira.Comments{
+				Comments: []*jira.Comment{
+					{Author: *me, Body: "Shipped\nDetails", Created: "2023-07-05T10:00:00.000+0000"},
+					{Author: jira.User{Name: "other"}, Body: "Thanks", Created: "2023-07-05T11:00:00.000+0000"},
+				},
+			},
+		},
+		Changelog: &jira.Changelog{
+			Histories: []jira.ChangelogHistory{
+				{
+					Created: "2023-07-04T10:00:00.000+0000",
+					Items:   []jira.ChangelogItems{{Field: "status", FromString: "In Progress", ToString: "Done"}},
+				},
+			},
+		},
+	})
+	j.summarizeIssue(&week, jira.Issue{
+		Key: "KONG-2",
+		Fields: &jira.IssueFields{
+			Summary:  "Started this week",
+			Assignee: me,
+			Status:   &jira.Status{Name: "In Progress"},
+		},
+		Changelog: &jira.Changelog{
+			Histories: []jira.ChangelogHistory{
+				{
+					Created: "2023-07-04T10:00:00.000+0000",
+					Items:   []jira.ChangelogItems{{Field: "status", FromString: "To Do", ToString: "In Progress"}},
+				},
+			},
+		},
+	})
+	j.summarizeIssue(&week, jira.Issue{
+		Key: "KONG-3",
+		Fields: &jira.IssueFields{
+			Summary:  "Completed last week",
+			Assignee: me,
+			Status:   done,
+		},
+		Changelog: &jira.Changelog{
+			Histories: []jira.ChangelogHistory{
+				{
+					Created: "2023-06-30T10:00:00.000+0000",
+					Items:   []jira.ChangelogItems{{Field: "status", FromString: "To Do", ToString: "Done"}},
+				},
+			},
+		},
+	})
+
+	want := Week{
+		Since:     week.Since,
+		Completed: Issues{{Key: "KONG-1", Summary: "Completed this week", Status: Status{Name: "Done", IsDone: true}}},
+		Started:   Issues{{Key: "KONG-2", Summary: "Started this week", Status: Status{Name: "In Progress"}}},
+		Comments:  []WeekComment{{Key: "KONG-1", Body: "Shipped"}},
+		Points:    3,
+	}
+	if diff := cmp.Diff(week, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
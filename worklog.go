@@ -0,0 +1,262 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"golang.org/x/sync/errgroup"
+)
+
+// Worklog is time the current user logged on an issue.
+type Worklog struct {
+	ID        string
+	Key       string
+	Summary   string
+	Started   time.Time
+	TimeSpent time.Duration
+	Comment   string
+}
+
+// Worklogs is a list of worklogs.
+type Worklogs []Worklog
+
+// AddWorklog logs the time spent on the issue, for instance 1h 30m, starting
+// now.
+func (j Jira) AddWorklog(ctx context.Context, key, timeSpent, comment string) (Worklog, error) {
+	if err := ValidateEstimate(timeSpent); err != nil {
+		return Worklog{}, fmt.Errorf("AddWorklog: %w", err)
+	}
+	started := jira.Time(time.Now())
+	record, resp, err := j.client.Issue.AddWorklogRecordWithContext(ctx, key, &jira.WorklogRecord{
+		Comment:   comment,
+		Started:   &started,
+		TimeSpent: timeSpent,
+	})
+	if err != nil {
+		return Worklog{}, fmt.Errorf("AddWorklog: %w", parseResponseError(resp))
+	}
+	fmt.Printf("%s - Logged %s\n", key, timeSpent)
+	worklog := newWorklog(key, "", *record)
+	return worklog, recordWorklog(worklog)
+}
+
+// recordWorklog stores the worklog in the state so that it is listed before
+// the daemon fetches the worklogs again. The data file is left to the daemon
+// which would overwrite it.
+func recordWorklog(worklog Worklog) error {
+	return updateState(func(s *State) error {
+		s.LoggedWorklogs = append(s.LoggedWorklogs.without(worklog.ID), worklog)
+		return nil
+	})
+}
+
+// pruneLoggedWorklogs drops the worklogs logged before the worklogs were
+// fetched at the given time since the fetched worklogs include them.
+func pruneLoggedWorklogs(fetched time.Time) error {
+	state, err := readState()
+	if err != nil || len(state.LoggedWorklogs) == 0 {
+		return err
+	}
+	return updateState(func(s *State) error {
+		s.LoggedWorklogs = s.LoggedWorklogs.Since(fetched)
+		return nil
+	})
+}
+
+// ListWorklogs returns the worklogs of the current user started since the
+// given time across all projects.
+func (j Jira) ListWorklogs(ctx context.Context, since time.Time) (Worklogs, error) {
+	jql := fmt.Sprintf("worklogAuthor = currentUser() AND worklogDate >= %q", since.Format(snapshotDateLayout))
+	issues, err := j.searchWithExpand(ctx, jql, "")
+	if err != nil {
+		return nil, fmt.Errorf("ListWorklogs: %w", err)
+	}
+
+	var (
+		mu       sync.Mutex
+		worklogs Worklogs
+	)
+	g, ctx := errgroup.WithContext(ctx)
+	for _, issue := range issues {
+		// allocate variable to avoid scope capturing
+		issue := issue
+
+		g.Go(func() error {
+			result, resp, err := j.client.Issue.GetWorklogsWithContext(ctx, issue.ID)
+			if err != nil {
+				return fmt.Errorf("ListWorklogs: %w", parseResponseError(resp))
+			}
+			var summary string
+			if issue.Fields != nil {
+				summary = issue.Fields.Summary
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, record := range result.Worklogs {
+				if record.Author == nil || !j.isUser(*record.Author) {
+					continue
+				}
+				worklog := newWorklog(issue.Key, summary, record)
+				if !worklog.Started.Before(since) {
+					worklogs = append(worklogs, worklog)
+				}
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return worklogs.Sort(), nil
+}
+
+func newWorklog(key, summary string, record jira.WorklogRecord) Worklog {
+	worklog := Worklog{
+		ID:        record.ID,
+		Key:       key,
+		Summary:   summary,
+		TimeSpent: time.Duration(record.TimeSpentSeconds) * time.Second,
+		Comment:   record.Comment,
+	}
+	if record.Started != nil {
+		worklog.Started = time.Time(*record.Started)
+	}
+	return worklog
+}
+
+// loadWorklogs fetches the worklogs of the current user of the past week.
+// Worklogs are not part of a section since they span all projects.
+func (d *Data) loadWorklogs(ctx context.Context) error {
+	now := time.Now()
+	worklogs, err := d.jira.ListWorklogs(ctx, startOfDay(now).AddDate(0, 0, -weekDays))
+	if err != nil {
+		return err
+	}
+	d.Worklogs = worklogs
+	return pruneLoggedWorklogs(now)
+}
+
+// GetWorklogs returns the worklogs of the current user of the past week. If
+// the data on disk is out of date it will request the latest worklogs from
+// Jira.
+func (d Data) GetWorklogs(ctx context.Context) (Worklogs, error) {
+	if !d.Stale() {
+		return d.Worklogs, nil
+	}
+	if err := d.loadWorklogs(ctx); err != nil {
+		return nil, err
+	}
+	d.applyLoggedWorklogs()
+	return d.Worklogs, nil
+}
+
+// applyLoggedWorklogs adds the worklogs logged with kong which the fetched
+// worklogs do not include yet.
+func (d *Data) applyLoggedWorklogs() {
+	if len(d.LoggedWorklogs) == 0 {
+		return
+	}
+	issues := d.snapshot()
+	worklogs := d.Worklogs
+	for _, worklog := range d.LoggedWorklogs {
+		if issue, ok := issues[worklog.Key]; ok && worklog.Summary == "" {
+			worklog.Summary = issue.Summary
+		}
+		worklogs = append(worklogs.without(worklog.ID), worklog)
+	}
+	d.Worklogs = worklogs.Sort()
+}
+
+// without returns the worklogs except the one with the given ID.
+func (w Worklogs) without(id string) Worklogs {
+	result := make(Worklogs, 0, len(w)+1)
+	for _, worklog := range w {
+		if worklog.ID != id || id == "" {
+			result = append(result, worklog)
+		}
+	}
+	return result
+}
+
+// Sort returns the worklogs ordered by the time they were started.
+func (w Worklogs) Sort() Worklogs {
+	sorted := append(Worklogs(nil), w...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Started.Before(sorted[j].Started)
+	})
+	return sorted
+}
+
+// Since returns the worklogs started at or after the given time.
+func (w Worklogs) Since(t time.Time) Worklogs {
+	var result Worklogs
+	for _, worklog := range w {
+		if !worklog.Started.Before(t) {
+			result = append(result, worklog)
+		}
+	}
+	return result
+}
+
+// Total returns the time spent of all worklogs.
+func (w Worklogs) Total() time.Duration {
+	var total time.Duration
+	for _, worklog := range w {
+		total += worklog.TimeSpent
+	}
+	return total
+}
+
+// Print writes the worklogs of the week of now, or only of the day of now,
+// followed by the time logged today and this week. Times are shown in the
+// location of now.
+func (w Worklogs) Print(output io.Writer, now time.Time, todayOnly bool) {
+	since := startOfWeek(now)
+	if todayOnly {
+		since = startOfDay(now)
+	}
+	worklogs := w.Since(since)
+	tw := tabwriter.NewWriter(output, 1, 1, 1, ' ', 0)
+	for _, worklog := range worklogs {
+		started := worklog.Started.In(now.Location())
+		fmt.Fprintf(tw, "%s\t-\t%s\t-\t%s\t-\t%s", started.Format("Mon Jan 2 15:04"), worklog.Key, formatTimeSpent(worklog.TimeSpent), worklog.Summary)
+		if worklog.Comment != "" {
+			fmt.Fprintf(tw, " (%s)", firstLine(worklog.Comment))
+		}
+		fmt.Fprint(tw, "\n")
+	}
+	tw.Flush()
+	today := w.Since(startOfDay(now)).Total()
+	fmt.Fprintf(output, "Today: %s", formatTimeSpent(today))
+	if !todayOnly {
+		fmt.Fprintf(output, ", this week: %s", formatTimeSpent(worklogs.Total()))
+	}
+	fmt.Fprintln(output)
+}
+
+// formatTimeSpent formats the duration in hours and minutes like Jira, for
+// instance 1h 30m.
+func formatTimeSpent(d time.Duration) string {
+	hours, minutes := int(d.Hours()), int(d.Minutes())%60
+	var parts []string
+	if hours > 0 {
+		parts = append(parts, fmt.Sprintf("%dh", hours))
+	}
+	if minutes > 0 || hours == 0 {
+		parts = append(parts, fmt.Sprintf("%dm", minutes))
+	}
+	return strings.Join(parts, " ")
+}
+
+// startOfWeek returns the beginning of the Monday of the week of t.
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return startOfDay(t).AddDate(0, 0, -offset)
+}
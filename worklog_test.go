@@ -0,0 +1,106 @@
+package kong
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFormatTimeSpent(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{d: 0, want: "0m"},
+		{d: 45 * time.Minute, want: "45m"},
+		{d: 2 * time.Hour, want: "2h"},
+		{d: 90 * time.Minute, want: "1h 30m"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := formatTimeSpent(tt.d); got != tt.want {
+				t.Errorf("got %s, want: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorklogsPrint(t *testing.T) {
+	// Wednesday
+	now := time.Date(2024, 3, 13, 15, 0, 0, 0, time.UTC)
+	var data Data
+	data.Worklogs = Worklogs{
+		{ID: "3", Key: "KONG-2", Summary: "Daemon", Started: now.Add(-2 * time.Hour), TimeSpent: 30 * time.Minute, Comment: "Review"},
+		{ID: "1", Key: "KONG-1", Summary: "Editor", Started: now.AddDate(0, 0, -7), TimeSpent: time.Hour},
+		{ID: "2", Key: "KONG-1", Summary: "Editor", Started: now.AddDate(0, 0, -1), TimeSpent: 2 * time.Hour},
+	}.Sort()
+	tests := []struct {
+		name      string
+		todayOnly bool
+		want      string
+	}{
+		{
+			name: "week",
+			want: "Tue Mar 12 15:00 - KONG-1 - 2h  - Editor\n" +
+				"Wed Mar 13 13:00 - KONG-2 - 30m - Daemon (Review)\n" +
+				"Today: 30m, this week: 2h 30m\n",
+		},
+		{
+			name:      "today",
+			todayOnly: true,
+			want: "Wed Mar 13 13:00 - KONG-2 - 30m - Daemon (Review)\n" +
+				"Today: 30m\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			data.Worklogs.Print(&b, now, tt.todayOnly)
+			if got := b.String(); got != tt.want {
+				t.Errorf("got %q, want: %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadDataLoggedWorklogs(t *testing.T) {
+	t.Setenv("KONG_CACHE", filepath.Join(t.TempDir(), "kong"))
+	session.data.reset()
+	session.state.reset()
+
+	now := time.Now()
+	data := NewData()
+	data.Issues = Issues{{Key: "KONG-1", Summary: "Editor"}}
+	data.Worklogs = Worklogs{{ID: "1", Key: "KONG-1", Started: now.Add(-time.Hour), TimeSpent: time.Hour}}
+	if err := data.WriteFile(); err != nil {
+		t.Fatal(err)
+	}
+	logged := Worklog{ID: "2", Key: "KONG-1", Started: now, TimeSpent: 30 * time.Minute}
+	if err := recordWorklog(logged); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	logged.Summary = "Editor"
+	want := Worklogs{data.Worklogs[0], logged}
+	if diff := cmp.Diff(got.Worklogs, want); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+
+	// worklogs fetched after logging include the logged worklog
+	if err := pruneLoggedWorklogs(now.Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	state, err := readState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.LoggedWorklogs) != 0 {
+		t.Errorf("got %v, want no logged worklogs", state.LoggedWorklogs)
+	}
+}